@@ -0,0 +1,50 @@
+package interpreter
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/Antonious-Stewart/15-Most-Common-Design-Patterns/structural/composite"
+)
+
+// Demo evaluates a pricing rule and runs a query over a small platoon.
+func Demo(w io.Writer) error {
+	expr, err := Parse("qty * price - (qty >= 10) * 5")
+	if err != nil {
+		return err
+	}
+	for _, qty := range []float64{4, 12} {
+		total, err := expr.Interpret(map[string]float64{"qty": qty, "price": 2.5})
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "%s with qty=%g is %g\n", expr, qty, total)
+	}
+
+	platoon := composite.NewPlatoon("Alpha")
+	big, small := composite.NewSquad("Alpha-1"), composite.NewSquad("Alpha-2")
+	for _, name := range []string{"Ana", "Ben", "Cy"} {
+		if err := big.Add(composite.NewEnlisted(name)); err != nil {
+			return err
+		}
+	}
+	if err := small.Add(composite.NewEnlisted("Dee")); err != nil {
+		return err
+	}
+	if err := platoon.Add(big, small); err != nil {
+		return err
+	}
+
+	query := `rank == "squad" and headcount > 2`
+	pred, err := CompilePredicate(query)
+	if err != nil {
+		return err
+	}
+	found := make([]string, 0)
+	for _, s := range FindAll(platoon, pred) {
+		found = append(found, s.Name())
+	}
+	fmt.Fprintf(w, "%s: %s\n", query, strings.Join(found, ", "))
+	return nil
+}
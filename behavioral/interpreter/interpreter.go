@@ -0,0 +1,239 @@
+package interpreter
+
+import (
+	"errors"
+	"fmt"
+)
+
+//Interpreter is a behavioral design pattern that, given a language, defines a representation for its grammar along with an interpreter that uses the representation to interpret sentences in the language.
+//The Interpreter pattern suggests that you represent every rule of the grammar as a class. Sentences become trees of these classes, the abstract syntax tree, and interpreting a sentence means asking the root of the tree to interpret itself.
+//Terminal expressions, such as numbers and variables, interpret themselves directly. Non-terminal expressions, such as an addition, interpret their sub-expressions and combine the results.
+//The context holds whatever state lives outside the sentence, here the values of the variables.
+
+//How to Implement
+//
+//Write down the grammar of the language, keeping it small. The pattern is a poor fit for large grammars.
+//
+//Declare the expression interface with an interpret method taking the context.
+//
+//Create a terminal expression class for every terminal symbol of the grammar and a non-terminal expression class for every rule. Non-terminals hold their sub-expressions.
+//
+//Build the abstract syntax tree, either by hand or with a parser, then call interpret on its root with a context.
+
+var (
+	ErrDivisionByZero    = errors.New("interpreter: division by zero")
+	ErrUndefinedVariable = errors.New("interpreter: undefined variable")
+)
+
+// Expression is a node of the syntax tree. Comparisons and logic interpret
+// to 1 for true and 0 for false, and treat any value other than 0 as true.
+type Expression interface {
+	Interpret(ctx map[string]float64) (float64, error)
+	// String prints the expression fully parenthesised.
+	String() string
+}
+
+// Number is a literal.
+type Number struct {
+	Value float64
+}
+
+func (n Number) Interpret(map[string]float64) (float64, error) {
+	return n.Value, nil
+}
+
+func (n Number) String() string {
+	return fmt.Sprint(n.Value)
+}
+
+// Variable is looked up in the context.
+type Variable struct {
+	Name string
+}
+
+func (v Variable) Interpret(ctx map[string]float64) (float64, error) {
+	value, ok := ctx[v.Name]
+	if !ok {
+		return 0, fmt.Errorf("%w: %s", ErrUndefinedVariable, v.Name)
+	}
+	return value, nil
+}
+
+func (v Variable) String() string {
+	return v.Name
+}
+
+// Negate is unary minus.
+type Negate struct {
+	Operand Expression
+}
+
+func (n Negate) Interpret(ctx map[string]float64) (float64, error) {
+	v, err := n.Operand.Interpret(ctx)
+	return -v, err
+}
+
+func (n Negate) String() string {
+	return "(-" + n.Operand.String() + ")"
+}
+
+// operands interprets both sides, left first.
+func operands(ctx map[string]float64, left, right Expression) (float64, float64, error) {
+	l, err := left.Interpret(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+	r, err := right.Interpret(ctx)
+	return l, r, err
+}
+
+func binary(left Expression, op string, right Expression) string {
+	return "(" + left.String() + " " + op + " " + right.String() + ")"
+}
+
+type Add struct {
+	Left, Right Expression
+}
+
+func (a Add) Interpret(ctx map[string]float64) (float64, error) {
+	l, r, err := operands(ctx, a.Left, a.Right)
+	return l + r, err
+}
+
+func (a Add) String() string {
+	return binary(a.Left, "+", a.Right)
+}
+
+type Subtract struct {
+	Left, Right Expression
+}
+
+func (s Subtract) Interpret(ctx map[string]float64) (float64, error) {
+	l, r, err := operands(ctx, s.Left, s.Right)
+	return l - r, err
+}
+
+func (s Subtract) String() string {
+	return binary(s.Left, "-", s.Right)
+}
+
+type Multiply struct {
+	Left, Right Expression
+}
+
+func (m Multiply) Interpret(ctx map[string]float64) (float64, error) {
+	l, r, err := operands(ctx, m.Left, m.Right)
+	return l * r, err
+}
+
+func (m Multiply) String() string {
+	return binary(m.Left, "*", m.Right)
+}
+
+type Divide struct {
+	Left, Right Expression
+}
+
+func (d Divide) Interpret(ctx map[string]float64) (float64, error) {
+	l, r, err := operands(ctx, d.Left, d.Right)
+	if err != nil {
+		return 0, err
+	}
+	if r == 0 {
+		return 0, fmt.Errorf("%w: %s", ErrDivisionByZero, d)
+	}
+	return l / r, nil
+}
+
+func (d Divide) String() string {
+	return binary(d.Left, "/", d.Right)
+}
+
+// Compare is one of < <= > >= == !=.
+type Compare struct {
+	Op          string
+	Left, Right Expression
+}
+
+func (c Compare) Interpret(ctx map[string]float64) (float64, error) {
+	l, r, err := operands(ctx, c.Left, c.Right)
+	if err != nil {
+		return 0, err
+	}
+	var result bool
+	switch c.Op {
+	case "<":
+		result = l < r
+	case "<=":
+		result = l <= r
+	case ">":
+		result = l > r
+	case ">=":
+		result = l >= r
+	case "==":
+		result = l == r
+	case "!=":
+		result = l != r
+	default:
+		return 0, fmt.Errorf("interpreter: unknown comparison %q", c.Op)
+	}
+	return truth(result), nil
+}
+
+func (c Compare) String() string {
+	return binary(c.Left, c.Op, c.Right)
+}
+
+// And doesn't interpret Right when Left is false.
+type And struct {
+	Left, Right Expression
+}
+
+func (a And) Interpret(ctx map[string]float64) (float64, error) {
+	l, err := a.Left.Interpret(ctx)
+	if err != nil || l == 0 {
+		return 0, err
+	}
+	r, err := a.Right.Interpret(ctx)
+	return truth(r != 0), err
+}
+
+func (a And) String() string {
+	return binary(a.Left, "and", a.Right)
+}
+
+// Or doesn't interpret Right when Left is true.
+type Or struct {
+	Left, Right Expression
+}
+
+func (o Or) Interpret(ctx map[string]float64) (float64, error) {
+	l, err := o.Left.Interpret(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if l != 0 {
+		return 1, nil
+	}
+	r, err := o.Right.Interpret(ctx)
+	return truth(r != 0), err
+}
+
+func (o Or) String() string {
+	return binary(o.Left, "or", o.Right)
+}
+
+func truth(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+//Pros and Cons
+//
+//It’s easy to change and extend the grammar: rules are classes, so new ones can be added by writing new expression types.
+//Implementing the grammar is straightforward, since the node classes all look alike.
+//
+//Complex grammars are hard to maintain: every rule is at least one class, and a grammar of any size is better served by a parser generator.
+//Interpreting a tree node by node is slower than compiling it to something flatter.
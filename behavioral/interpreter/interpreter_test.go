@@ -0,0 +1,128 @@
+package interpreter
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParsePrecedence(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"1 + 2 * 3", "(1 + (2 * 3))"},
+		{"(1 + 2) * 3", "((1 + 2) * 3)"},
+		{"8 - 4 - 2", "((8 - 4) - 2)"},
+		{"8 / 4 / 2", "((8 / 4) / 2)"},
+		{"-x * 2", "((-x) * 2)"},
+		{"- -1", "(-(-1))"},
+		{"a + 1 > b * 2", "((a + 1) > (b * 2))"},
+		{"a or b and c", "(a or (b and c))"},
+		{"a < 1 || b >= 2 && c != 3", "((a < 1) or ((b >= 2) and (c != 3)))"},
+		{"x == 1.5", "(x == 1.5)"},
+	}
+	for _, tt := range tests {
+		expr, err := Parse(tt.input)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", tt.input, err)
+		}
+		if got := expr.String(); got != tt.want {
+			t.Fatalf("Parse(%q) = %s, want %s", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestInterpret(t *testing.T) {
+	ctx := map[string]float64{"qty": 12, "price": 2.5, "vip": 1, "zero": 0}
+	tests := []struct {
+		input string
+		want  float64
+	}{
+		{"qty * price", 30},
+		{"qty * price - 10 / 4", 27.5},
+		{"-qty + 2", -10},
+		{"qty >= 10 and price < 3", 1},
+		{"qty > 20 or vip", 1},
+		{"qty > 20 or zero", 0},
+		{"vip and qty", 1},
+		{"qty == 12", 1},
+		{"qty != 12", 0},
+		{"qty <= 11", 0},
+		// the right side is never interpreted
+		{"zero and missing", 0},
+		{"vip or 1 / zero", 1},
+	}
+	for _, tt := range tests {
+		expr, err := Parse(tt.input)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", tt.input, err)
+		}
+		got, err := expr.Interpret(ctx)
+		if err != nil {
+			t.Fatalf("Interpret(%q): %v", tt.input, err)
+		}
+		if got != tt.want {
+			t.Fatalf("Interpret(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestInterpretErrors(t *testing.T) {
+	ctx := map[string]float64{"zero": 0}
+	tests := []struct {
+		input string
+		want  error
+	}{
+		{"1 / zero", ErrDivisionByZero},
+		{"1 / (2 - 2)", ErrDivisionByZero},
+		{"missing + 1", ErrUndefinedVariable},
+		{"1 + missing", ErrUndefinedVariable},
+		{"-missing", ErrUndefinedVariable},
+		{"missing and 1", ErrUndefinedVariable},
+		{"1 and missing", ErrUndefinedVariable},
+		{"zero or missing", ErrUndefinedVariable},
+		{"missing or 1", ErrUndefinedVariable},
+		{"missing < 1", ErrUndefinedVariable},
+		{"1 / missing", ErrUndefinedVariable},
+	}
+	for _, tt := range tests {
+		expr, err := Parse(tt.input)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", tt.input, err)
+		}
+		if _, err := expr.Interpret(ctx); !errors.Is(err, tt.want) {
+			t.Fatalf("Interpret(%q) = %v, want %v", tt.input, err, tt.want)
+		}
+	}
+	if _, err := (Compare{Op: "<>", Left: Number{1}, Right: Number{2}}).Interpret(nil); err == nil {
+		t.Fatal("unknown comparison interpreted")
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		input string
+		pos   int
+	}{
+		{"", 0},
+		{"1 +", 3},
+		{"(1 + 2", 6},
+		{"1 2", 2},
+		{"1 < 2 < 3", 6},
+		{"a $ b", 2},
+		{"1..2", 0},
+		{"and", 0},
+		{")", 0},
+		{"* 2", 0},
+	}
+	for _, tt := range tests {
+		_, err := Parse(tt.input)
+		var syntax *SyntaxError
+		if !errors.As(err, &syntax) {
+			t.Fatalf("Parse(%q) = %v, want a SyntaxError", tt.input, err)
+		}
+		if syntax.Pos != tt.pos {
+			t.Fatalf("Parse(%q) failed at %d, want %d: %v", tt.input, syntax.Pos, tt.pos, err)
+		}
+	}
+}
@@ -0,0 +1,255 @@
+package interpreter
+
+import (
+	"fmt"
+	"strconv"
+	"unicode"
+)
+
+// SyntaxError reports where Parse gave up. Pos is a byte offset into the input.
+type SyntaxError struct {
+	Pos int
+	Msg string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("interpreter: syntax error at %d: %s", e.Pos, e.Msg)
+}
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokNumber
+	tokIdent
+	tokOp
+	tokString
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+// operators lists the symbols the lexer knows, longest first so "<=" isn't read as "<".
+var operators = []string{"<=", ">=", "==", "!=", "=~", "&&", "||", "+", "-", "*", "/", "<", ">", "(", ")"}
+
+func lex(input string) ([]token, error) {
+	tokens := make([]token, 0)
+	for i := 0; i < len(input); {
+		c := rune(input[i])
+		switch {
+		case unicode.IsSpace(c):
+			i++
+		case unicode.IsDigit(c) || c == '.':
+			start := i
+			for i < len(input) && (unicode.IsDigit(rune(input[i])) || input[i] == '.') {
+				i++
+			}
+			tokens = append(tokens, token{tokNumber, input[start:i], start})
+		case c == '"':
+			start := i
+			for i++; i < len(input) && input[i] != '"'; i++ {
+				if input[i] == '\\' {
+					i++
+				}
+			}
+			if i >= len(input) {
+				return nil, &SyntaxError{Pos: start, Msg: "unterminated string"}
+			}
+			i++
+			text, err := strconv.Unquote(input[start:i])
+			if err != nil {
+				return nil, &SyntaxError{Pos: start, Msg: fmt.Sprintf("bad string %s", input[start:i])}
+			}
+			tokens = append(tokens, token{tokString, text, start})
+		case unicode.IsLetter(c) || c == '_':
+			start := i
+			for i < len(input) && (unicode.IsLetter(rune(input[i])) || unicode.IsDigit(rune(input[i])) || input[i] == '_') {
+				i++
+			}
+			tokens = append(tokens, token{tokIdent, input[start:i], start})
+		default:
+			op := ""
+			for _, candidate := range operators {
+				if len(input)-i >= len(candidate) && input[i:i+len(candidate)] == candidate {
+					op = candidate
+					break
+				}
+			}
+			if op == "" {
+				return nil, &SyntaxError{Pos: i, Msg: fmt.Sprintf("unexpected %q", c)}
+			}
+			tokens = append(tokens, token{tokOp, op, i})
+			i += len(op)
+		}
+	}
+	return append(tokens, token{tokEOF, "", len(input)}), nil
+}
+
+// Parse builds the syntax tree of input. From loosest to tightest binding:
+// or (also ||), and (also &&), one comparison, + and -, * and /, unary
+// minus, then numbers, variables and parentheses. Binary operators group
+// to the left.
+func Parse(input string) (Expression, error) {
+	tokens, err := lex(input)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	expr, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokEOF {
+		return nil, &SyntaxError{Pos: t.pos, Msg: fmt.Sprintf("unexpected %q", t.text)}
+	}
+	return expr, nil
+}
+
+type parser struct {
+	tokens []token
+	next   int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.next]
+}
+
+// accept consumes the next token if it is one of texts.
+func (p *parser) accept(texts ...string) (string, bool) {
+	t := p.peek()
+	if t.kind != tokOp && t.kind != tokIdent {
+		return "", false
+	}
+	for _, text := range texts {
+		if t.text == text {
+			p.next++
+			return text, true
+		}
+	}
+	return "", false
+}
+
+func (p *parser) or() (Expression, error) {
+	left, err := p.and()
+	for err == nil {
+		if _, ok := p.accept("or", "||"); !ok {
+			return left, nil
+		}
+		var right Expression
+		if right, err = p.and(); err == nil {
+			left = Or{Left: left, Right: right}
+		}
+	}
+	return nil, err
+}
+
+func (p *parser) and() (Expression, error) {
+	left, err := p.comparison()
+	for err == nil {
+		if _, ok := p.accept("and", "&&"); !ok {
+			return left, nil
+		}
+		var right Expression
+		if right, err = p.comparison(); err == nil {
+			left = And{Left: left, Right: right}
+		}
+	}
+	return nil, err
+}
+
+func (p *parser) comparison() (Expression, error) {
+	left, err := p.sum()
+	if err != nil {
+		return nil, err
+	}
+	op, ok := p.accept("<", "<=", ">", ">=", "==", "!=")
+	if !ok {
+		return left, nil
+	}
+	right, err := p.sum()
+	if err != nil {
+		return nil, err
+	}
+	return Compare{Op: op, Left: left, Right: right}, nil
+}
+
+func (p *parser) sum() (Expression, error) {
+	left, err := p.term()
+	for err == nil {
+		op, ok := p.accept("+", "-")
+		if !ok {
+			return left, nil
+		}
+		var right Expression
+		if right, err = p.term(); err == nil {
+			if op == "+" {
+				left = Add{Left: left, Right: right}
+			} else {
+				left = Subtract{Left: left, Right: right}
+			}
+		}
+	}
+	return nil, err
+}
+
+func (p *parser) term() (Expression, error) {
+	left, err := p.unary()
+	for err == nil {
+		op, ok := p.accept("*", "/")
+		if !ok {
+			return left, nil
+		}
+		var right Expression
+		if right, err = p.unary(); err == nil {
+			if op == "*" {
+				left = Multiply{Left: left, Right: right}
+			} else {
+				left = Divide{Left: left, Right: right}
+			}
+		}
+	}
+	return nil, err
+}
+
+func (p *parser) unary() (Expression, error) {
+	if _, ok := p.accept("-"); ok {
+		operand, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return Negate{Operand: operand}, nil
+	}
+	return p.primary()
+}
+
+func (p *parser) primary() (Expression, error) {
+	t := p.peek()
+	switch {
+	case t.kind == tokNumber:
+		p.next++
+		v, err := strconv.ParseFloat(t.text, 64)
+		if err != nil {
+			return nil, &SyntaxError{Pos: t.pos, Msg: fmt.Sprintf("bad number %q", t.text)}
+		}
+		return Number{Value: v}, nil
+	case t.kind == tokIdent && t.text != "and" && t.text != "or":
+		p.next++
+		return Variable{Name: t.text}, nil
+	case t.kind == tokOp && t.text == "(":
+		p.next++
+		expr, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := p.accept(")"); !ok {
+			return nil, &SyntaxError{Pos: p.peek().pos, Msg: "missing )"}
+		}
+		return expr, nil
+	case t.kind == tokEOF:
+		return nil, &SyntaxError{Pos: t.pos, Msg: "unexpected end of input"}
+	}
+	return nil, &SyntaxError{Pos: t.pos, Msg: fmt.Sprintf("unexpected %q", t.text)}
+}
@@ -0,0 +1,222 @@
+package interpreter
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/Antonious-Stewart/15-Most-Common-Design-Patterns/structural/composite"
+)
+
+var (
+	ErrUnknownField = errors.New("interpreter: unknown field")
+	ErrTypeMismatch = errors.New("interpreter: operator does not fit the field")
+)
+
+// Predicate is a compiled query.
+type Predicate func(s composite.Soldier) bool
+
+type fieldKind int
+
+const (
+	textField fieldKind = iota
+	numberField
+)
+
+// field is one of the things a query can ask about a soldier.
+type field struct {
+	kind   fieldKind
+	text   func(s composite.Soldier) string
+	number func(s composite.Soldier) float64
+}
+
+var fields = map[string]field{
+	"name":      {kind: textField, text: composite.Soldier.Name},
+	"rank":      {kind: textField, text: rankOf},
+	"headcount": {kind: numberField, number: headcount},
+	"children":  {kind: numberField, number: childCount},
+}
+
+func rankOf(s composite.Soldier) string {
+	switch s.(type) {
+	case *composite.Division:
+		return "division"
+	case *composite.Brigade:
+		return "brigade"
+	case *composite.Platoon:
+		return "platoon"
+	case *composite.Squad:
+		return "squad"
+	case *composite.Enlisted:
+		return "enlisted"
+	}
+	return ""
+}
+
+func children(s composite.Soldier) []composite.Soldier {
+	if c, ok := s.(interface{ Children() []composite.Soldier }); ok {
+		return c.Children()
+	}
+	return nil
+}
+
+func headcount(s composite.Soldier) float64 {
+	return float64(s.Count())
+}
+
+func childCount(s composite.Soldier) float64 {
+	return float64(len(children(s)))
+}
+
+// CompilePredicate turns a query such as
+//
+//	rank == "squad" and (headcount > 5 or name =~ "^Alpha")
+//
+// into a Predicate. The fields are name and rank, compared as text with ==,
+// != or =~, and headcount and children, compared as numbers with == != < <=
+// > >=. Conditions combine with and and or, and parentheses group them.
+// Ranks are lower case. =~ matches a regular expression anywhere in the text;
+// anchor it with ^ and $ to match the whole. Unknown fields, operators that
+// don't fit a field and bad patterns are reported here rather than when the
+// predicate runs.
+func CompilePredicate(query string) (Predicate, error) {
+	tokens, err := lex(query)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	pred, err := p.queryOr()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokEOF {
+		return nil, &SyntaxError{Pos: t.pos, Msg: fmt.Sprintf("unexpected %q", t.text)}
+	}
+	return pred, nil
+}
+
+func (p *parser) queryOr() (Predicate, error) {
+	left, err := p.queryAnd()
+	for err == nil {
+		if _, ok := p.accept("or", "||"); !ok {
+			return left, nil
+		}
+		var right Predicate
+		if right, err = p.queryAnd(); err == nil {
+			l := left
+			left = func(s composite.Soldier) bool { return l(s) || right(s) }
+		}
+	}
+	return nil, err
+}
+
+func (p *parser) queryAnd() (Predicate, error) {
+	left, err := p.condition()
+	for err == nil {
+		if _, ok := p.accept("and", "&&"); !ok {
+			return left, nil
+		}
+		var right Predicate
+		if right, err = p.condition(); err == nil {
+			l := left
+			left = func(s composite.Soldier) bool { return l(s) && right(s) }
+		}
+	}
+	return nil, err
+}
+
+func (p *parser) condition() (Predicate, error) {
+	if _, ok := p.accept("("); ok {
+		pred, err := p.queryOr()
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := p.accept(")"); !ok {
+			return nil, &SyntaxError{Pos: p.peek().pos, Msg: "missing )"}
+		}
+		return pred, nil
+	}
+
+	name := p.peek()
+	if name.kind != tokIdent {
+		return nil, &SyntaxError{Pos: name.pos, Msg: "expected a field"}
+	}
+	f, ok := fields[name.text]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q at %d", ErrUnknownField, name.text, name.pos)
+	}
+	p.next++
+
+	opTok := p.peek()
+	op, ok := p.accept("==", "!=", "<", "<=", ">", ">=", "=~")
+	if !ok {
+		return nil, &SyntaxError{Pos: opTok.pos, Msg: fmt.Sprintf("expected an operator after %s", name.text)}
+	}
+	value := p.peek()
+	if value.kind == tokEOF {
+		return nil, &SyntaxError{Pos: value.pos, Msg: "unexpected end of input"}
+	}
+	p.next++
+	switch f.kind {
+	case textField:
+		return textCondition(name.text, f, op, value)
+	default:
+		return numberCondition(name.text, f, op, value)
+	}
+}
+
+func textCondition(name string, f field, op string, value token) (Predicate, error) {
+	if value.kind != tokString {
+		return nil, fmt.Errorf("%w: %s needs a string at %d", ErrTypeMismatch, name, value.pos)
+	}
+	switch op {
+	case "==":
+		return func(s composite.Soldier) bool { return f.text(s) == value.text }, nil
+	case "!=":
+		return func(s composite.Soldier) bool { return f.text(s) != value.text }, nil
+	case "=~":
+		re, err := regexp.Compile(value.text)
+		if err != nil {
+			return nil, fmt.Errorf("interpreter: bad pattern at %d: %w", value.pos, err)
+		}
+		return func(s composite.Soldier) bool { return re.MatchString(f.text(s)) }, nil
+	}
+	return nil, fmt.Errorf("%w: %s %s", ErrTypeMismatch, name, op)
+}
+
+func numberCondition(name string, f field, op string, value token) (Predicate, error) {
+	if op == "=~" {
+		return nil, fmt.Errorf("%w: %s %s", ErrTypeMismatch, name, op)
+	}
+	if value.kind != tokNumber {
+		return nil, fmt.Errorf("%w: %s needs a number at %d", ErrTypeMismatch, name, value.pos)
+	}
+	v, err := strconv.ParseFloat(value.text, 64)
+	if err != nil {
+		return nil, &SyntaxError{Pos: value.pos, Msg: fmt.Sprintf("bad number %q", value.text)}
+	}
+	// the comparison itself is an ordinary expression over one variable
+	cmp := Compare{Op: op, Left: Variable{Name: name}, Right: Number{Value: v}}
+	return func(s composite.Soldier) bool {
+		result, err := cmp.Interpret(map[string]float64{name: f.number(s)})
+		return err == nil && result != 0
+	}, nil
+}
+
+// FindAll returns every soldier in root's subtree, root included, that
+// pred accepts, depth first.
+func FindAll(root composite.Soldier, pred Predicate) []composite.Soldier {
+	found := make([]composite.Soldier, 0)
+	var walk func(s composite.Soldier)
+	walk = func(s composite.Soldier) {
+		if pred(s) {
+			found = append(found, s)
+		}
+		for _, child := range children(s) {
+			walk(child)
+		}
+	}
+	walk(root)
+	return found
+}
@@ -0,0 +1,135 @@
+package interpreter
+
+import (
+	"errors"
+	"reflect"
+	"regexp/syntax"
+	"strings"
+	"testing"
+
+	"github.com/Antonious-Stewart/15-Most-Common-Design-Patterns/structural/composite"
+)
+
+// army is a division with one brigade of two platoons: Alpha has a full squad
+// of six and a pair, Bravo one squad of three.
+func army() *composite.Division {
+	enlist := func(s *composite.Squad, names ...string) *composite.Squad {
+		for _, name := range names {
+			s.Add(composite.NewEnlisted(name))
+		}
+		return s
+	}
+	alpha := composite.NewPlatoon("Alpha")
+	alpha.Add(
+		enlist(composite.NewSquad("Alpha-1"), "a", "b", "c", "d", "e", "f"),
+		enlist(composite.NewSquad("Alpha-2"), "g", "h"),
+	)
+	bravo := composite.NewPlatoon("Bravo")
+	bravo.Add(enlist(composite.NewSquad("Bravo-1"), "i", "j", "k"))
+	brigade := composite.NewBrigade("1st")
+	brigade.Add(alpha, bravo)
+	division := composite.NewDivision("3rd")
+	division.Add(brigade)
+	return division
+}
+
+func names(soldiers []composite.Soldier) []string {
+	found := make([]string, 0, len(soldiers))
+	for _, s := range soldiers {
+		found = append(found, s.Name())
+	}
+	return found
+}
+
+func TestCompilePredicate(t *testing.T) {
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{`rank == "squad"`, []string{"Alpha-1", "Alpha-2", "Bravo-1"}},
+		{`rank == "squad" and headcount > 5`, []string{"Alpha-1"}},
+		{`name =~ "^Alpha"`, []string{"Alpha", "Alpha-1", "Alpha-2"}},
+		{`name =~ "-1"`, []string{"Alpha-1", "Bravo-1"}},
+		{`rank == "platoon" && children >= 2`, []string{"Alpha"}},
+		{`headcount == 11`, []string{"3rd", "1st"}},
+		{`rank != "enlisted" and headcount < 3`, []string{"Alpha-2"}},
+		{`rank == "enlisted" and (name == "a" or name == "k")`, []string{"a", "k"}},
+		{`rank == "squad" and headcount <= 3 || rank == "brigade"`, []string{"1st", "Alpha-2", "Bravo-1"}},
+		{`rank == "general"`, []string{}},
+	}
+	root := army()
+	for _, tt := range tests {
+		pred, err := CompilePredicate(tt.query)
+		if err != nil {
+			t.Fatalf("CompilePredicate(%q): %v", tt.query, err)
+		}
+		if got := names(FindAll(root, pred)); !reflect.DeepEqual(got, tt.want) {
+			t.Fatalf("FindAll(%q) = %v, want %v", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestCompilePredicateErrors(t *testing.T) {
+	tests := []struct {
+		query string
+		want  error
+	}{
+		{`salary > 5`, ErrUnknownField},
+		{`rank == "squad" and age < 30`, ErrUnknownField},
+		{`rank > "squad"`, ErrTypeMismatch},
+		{`rank == 3`, ErrTypeMismatch},
+		{`headcount =~ "5"`, ErrTypeMismatch},
+		{`headcount == "five"`, ErrTypeMismatch},
+	}
+	for _, tt := range tests {
+		if _, err := CompilePredicate(tt.query); !errors.Is(err, tt.want) {
+			t.Fatalf("CompilePredicate(%q) = %v, want %v", tt.query, err, tt.want)
+		}
+	}
+}
+
+func TestCompilePredicateBadPattern(t *testing.T) {
+	_, err := CompilePredicate(`name =~ "Alpha("`)
+	var bad *syntax.Error
+	if !errors.As(err, &bad) {
+		t.Fatalf("CompilePredicate() = %v, want a *syntax.Error", err)
+	}
+	if !strings.Contains(err.Error(), "at 8") {
+		t.Fatalf("error %q doesn't say where the pattern is", err)
+	}
+}
+
+func TestCompilePredicateSyntax(t *testing.T) {
+	tests := []struct {
+		query string
+		pos   int
+	}{
+		{`rank`, 4},
+		{`rank ==`, 7},
+		{`"squad" == rank`, 0},
+		{`(rank == "squad"`, 16},
+		{`rank == "squad" headcount > 1`, 16},
+		{`name == "Alpha`, 8},
+	}
+	for _, tt := range tests {
+		_, err := CompilePredicate(tt.query)
+		var se *SyntaxError
+		if !errors.As(err, &se) {
+			t.Fatalf("CompilePredicate(%q) = %v, want a *SyntaxError", tt.query, err)
+		}
+		if se.Pos != tt.pos {
+			t.Fatalf("CompilePredicate(%q) failed at %d, want %d", tt.query, se.Pos, tt.pos)
+		}
+	}
+}
+
+func TestFindAllIncludesRoot(t *testing.T) {
+	squad := composite.NewSquad("Lone")
+	pred, err := CompilePredicate(`headcount == 0`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := names(FindAll(squad, pred)); !reflect.DeepEqual(got, []string{"Lone"}) {
+		t.Fatalf("FindAll() = %v, want [Lone]", got)
+	}
+}
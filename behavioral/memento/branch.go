@@ -0,0 +1,109 @@
+package memento
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+var ErrUnknownBranch = errors.New("memento: unknown branch")
+
+// branch is a parked line of history: its undo snapshots, its redo stack and
+// the editor state it was left in.
+type branch struct {
+	history []*snapshot
+	redo    []*Memento
+	tip     *Memento
+}
+
+type BranchInfo struct {
+	ID     int
+	Undo   int
+	Redo   int
+	Active bool
+}
+
+// WithBranching keeps the redo stack as a separate branch instead of
+// discarding it when a new change is made after Undo.
+func WithBranching() Option {
+	return func(c *Caretaker) {
+		c.branching = true
+	}
+}
+
+// Redo re-applies the most recently undone state, snapshotting the current one for Undo.
+func (c *Caretaker) Redo() error {
+	if len(c.redo) == 0 {
+		return ErrNoRedo
+	}
+	last := len(c.redo) - 1
+	m := c.redo[last]
+	c.redo[last] = nil
+	c.redo = c.redo[:last]
+	c.bytes -= m.Size()
+
+	c.push(c.editor.Save())
+	c.enforceLimits()
+	return c.editor.Restore(m)
+}
+
+// ListBranches returns every line of history, including the active one, ordered by ID.
+func (c *Caretaker) ListBranches() []BranchInfo {
+	infos := []BranchInfo{{
+		ID:     c.branch,
+		Undo:   len(c.history),
+		Redo:   len(c.redo),
+		Active: true,
+	}}
+	for id, b := range c.branches {
+		infos = append(infos, BranchInfo{
+			ID:   id,
+			Undo: len(b.history),
+			Redo: len(b.redo),
+		})
+	}
+	slices.SortFunc(infos, func(a, b BranchInfo) int {
+		return a.ID - b.ID
+	})
+	return infos
+}
+
+// SwitchBranch parks the active line and restores the editor to where branch id was left.
+func (c *Caretaker) SwitchBranch(id int) error {
+	if id == c.branch {
+		return nil
+	}
+	target, ok := c.branches[id]
+	if !ok {
+		return fmt.Errorf("%w: %d", ErrUnknownBranch, id)
+	}
+	delete(c.branches, id)
+	c.branches[c.branch] = &branch{
+		history: c.history,
+		redo:    c.redo,
+		tip:     c.editor.Save(),
+	}
+
+	c.branch = id
+	c.history = target.history
+	c.redo = target.redo
+	c.bytes = 0
+	for _, s := range c.history {
+		c.bytes += s.size()
+	}
+	for _, m := range c.redo {
+		c.bytes += m.Size()
+	}
+	return c.editor.Restore(target.tip)
+}
+
+// fork parks the redo stack as a new branch. The branch shares the snapshot
+// records of the common history; they are never mutated, only replaced.
+func (c *Caretaker) fork() {
+	c.nextBranch++
+	c.branches[c.nextBranch] = &branch{
+		history: slices.Clone(c.history),
+		redo:    c.redo,
+		tip:     c.editor.Save(),
+	}
+}
@@ -0,0 +1,148 @@
+package memento
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestLinearUndoRedo(t *testing.T) {
+	e := NewEditor()
+	c := NewCaretaker(e)
+	edit(c, e, "a", "b", "c")
+
+	steps := []struct {
+		op   func() error
+		want string
+	}{
+		{c.Undo, "ab"},
+		{c.Undo, "a"},
+		{c.Redo, "ab"},
+		{c.Redo, "abc"},
+		{c.Undo, "ab"},
+	}
+	for i, step := range steps {
+		if err := step.op(); err != nil {
+			t.Fatalf("step %d: %v", i, err)
+		}
+		if e.Content() != step.want {
+			t.Fatalf("step %d: content = %q, want %q", i, e.Content(), step.want)
+		}
+	}
+	if stats := c.Stats(); stats.Snapshots != 2 || stats.Redo != 1 {
+		t.Fatalf("Stats() = %+v, want 2 undo and 1 redo", stats)
+	}
+}
+
+func TestRedoDiscardedByNewChange(t *testing.T) {
+	e := NewEditor()
+	c := NewCaretaker(e)
+	edit(c, e, "a", "b")
+	if err := c.Undo(); err != nil {
+		t.Fatal(err)
+	}
+	edit(c, e, "X")
+
+	if err := c.Redo(); !errors.Is(err, ErrNoRedo) {
+		t.Fatalf("Redo after a new change = %v, want ErrNoRedo", err)
+	}
+	if stats := c.Stats(); stats.Branches != 0 {
+		t.Fatalf("Stats().Branches = %d, want 0 without WithBranching", stats.Branches)
+	}
+}
+
+func TestBranchCreationAndSwitching(t *testing.T) {
+	e := NewEditor()
+	c := NewCaretaker(e, WithBranching())
+	edit(c, e, "a", "b", "c")
+	if err := c.Undo(); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Undo(); err != nil {
+		t.Fatal(err)
+	}
+	// "a" with "b" and "c" on the redo stack; a new change forks them off
+	edit(c, e, "X")
+
+	branches := c.ListBranches()
+	want := []BranchInfo{
+		{ID: 0, Undo: 2, Redo: 0, Active: true},
+		{ID: 1, Undo: 1, Redo: 2},
+	}
+	if len(branches) != len(want) {
+		t.Fatalf("ListBranches() = %+v, want %+v", branches, want)
+	}
+	for i := range want {
+		if branches[i] != want[i] {
+			t.Fatalf("ListBranches()[%d] = %+v, want %+v", i, branches[i], want[i])
+		}
+	}
+
+	if err := c.SwitchBranch(1); err != nil {
+		t.Fatal(err)
+	}
+	if e.Content() != "a" {
+		t.Fatalf("content on branch 1 = %q, want %q", e.Content(), "a")
+	}
+	if err := c.Redo(); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Redo(); err != nil {
+		t.Fatal(err)
+	}
+	if e.Content() != "abc" {
+		t.Fatalf("content after redoing branch 1 = %q, want %q", e.Content(), "abc")
+	}
+
+	if err := c.SwitchBranch(0); err != nil {
+		t.Fatal(err)
+	}
+	if e.Content() != "aX" {
+		t.Fatalf("content back on branch 0 = %q, want %q", e.Content(), "aX")
+	}
+	if err := c.SwitchBranch(7); !errors.Is(err, ErrUnknownBranch) {
+		t.Fatalf("SwitchBranch(7) = %v, want ErrUnknownBranch", err)
+	}
+}
+
+func TestAbandonedBranchStillRestores(t *testing.T) {
+	e := NewEditor()
+	c := NewCaretaker(e, WithBranching(), WithIncremental(3))
+	edit(c, e, "a", "b", "c")
+	if err := c.Undo(); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Undo(); err != nil {
+		t.Fatal(err)
+	}
+	edit(c, e, "X", "Y", "Z")
+
+	if err := c.SwitchBranch(1); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Undo(); err != nil {
+		t.Fatal(err)
+	}
+	if e.Content() != "" {
+		t.Fatalf("undo on the abandoned branch = %q, want %q", e.Content(), "")
+	}
+	for _, want := range []string{"a", "ab", "abc"} {
+		if err := c.Redo(); err != nil {
+			t.Fatal(err)
+		}
+		if e.Content() != want {
+			t.Fatalf("redo on the abandoned branch = %q, want %q", e.Content(), want)
+		}
+	}
+
+	if err := c.SwitchBranch(0); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"aXY", "aX", "a", ""} {
+		if err := c.Undo(); err != nil {
+			t.Fatal(err)
+		}
+		if e.Content() != want {
+			t.Fatalf("undo on the newer branch = %q, want %q", e.Content(), want)
+		}
+	}
+}
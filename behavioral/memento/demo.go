@@ -0,0 +1,38 @@
+package memento
+
+import (
+	"fmt"
+	"io"
+)
+
+// Demo types a few words, undoes them, then branches off with a new edit
+// and switches back to the abandoned branch.
+func Demo(w io.Writer) error {
+	editor := NewEditor()
+	caretaker := NewCaretaker(editor, WithBranching())
+	for _, text := range []string{"Hello", ", world", "!"} {
+		caretaker.Backup()
+		editor.Write(text)
+		fmt.Fprintf(w, "wrote  %q\n", editor.Content())
+	}
+	for range 2 {
+		if err := caretaker.Undo(); err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "undo   %q\n", editor.Content())
+	}
+	caretaker.Backup()
+	editor.Write(", there")
+	fmt.Fprintf(w, "wrote  %q\n", editor.Content())
+
+	for _, branch := range caretaker.ListBranches() {
+		if branch.Active {
+			continue
+		}
+		if err := caretaker.SwitchBranch(branch.ID); err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "branch %d: %q\n", branch.ID, editor.Content())
+	}
+	return nil
+}
@@ -0,0 +1,162 @@
+package memento
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+)
+
+// formatVersion is written as the first byte of every encoded memento and history.
+const formatVersion byte = 1
+
+var (
+	ErrUnknownVersion = errors.New("memento: unknown format version")
+	ErrCorrupt        = errors.New("memento: corrupt or truncated data")
+)
+
+// MarshalBinary encodes the snapshot. The bytes are as opaque as the memento
+// itself: the only useful thing to do with them is UnmarshalBinary and Restore.
+func (m *Memento) MarshalBinary() ([]byte, error) {
+	buf := make([]byte, 0, 1+2*binary.MaxVarintLen64+len(m.content))
+	buf = append(buf, formatVersion)
+	buf = binary.AppendUvarint(buf, uint64(len(m.content)))
+	buf = append(buf, m.content...)
+	buf = binary.AppendUvarint(buf, uint64(m.cursor))
+	return buf, nil
+}
+
+func (m *Memento) UnmarshalBinary(data []byte) error {
+	r := bytes.NewReader(data)
+	decoded, err := readMemento(r)
+	if err != nil {
+		return err
+	}
+	if r.Len() != 0 {
+		return fmt.Errorf("%w: %d trailing bytes", ErrCorrupt, r.Len())
+	}
+	*m = *decoded
+	return nil
+}
+
+// SaveTo writes the retained undo history of the active line so it can be reloaded with LoadFrom after a restart.
+// Incremental snapshots are written out in full, so the format does not depend
+// on how the caretaker that saved it was configured.
+func (c *Caretaker) SaveTo(w io.Writer) error {
+	buf := []byte{formatVersion}
+	buf = binary.AppendUvarint(buf, uint64(c.evictions))
+	buf = binary.AppendUvarint(buf, uint64(len(c.history)))
+	for i := range c.history {
+		data, err := c.memento(i).MarshalBinary()
+		if err != nil {
+			return err
+		}
+		buf = binary.AppendUvarint(buf, uint64(len(data)))
+		buf = append(buf, data...)
+	}
+	_, err := w.Write(buf)
+	return err
+}
+
+// LoadFrom replaces the caretaker's undo history with one written by SaveTo
+// and clears the redo stack. On error the current history is left untouched.
+func (c *Caretaker) LoadFrom(r io.Reader) error {
+	br := bufio.NewReader(r)
+	if err := readVersion(br); err != nil {
+		return err
+	}
+	evictions, err := readUvarint(br)
+	if err != nil {
+		return err
+	}
+	count, err := readUvarint(br)
+	if err != nil {
+		return err
+	}
+
+	mementos := make([]*Memento, 0)
+	for i := uint64(0); i < count; i++ {
+		data, err := readFrame(br)
+		if err != nil {
+			return err
+		}
+		m := &Memento{}
+		if err := m.UnmarshalBinary(data); err != nil {
+			return err
+		}
+		mementos = append(mementos, m)
+	}
+
+	c.history = make([]*snapshot, 0, len(mementos))
+	c.redo = make([]*Memento, 0)
+	c.bytes = 0
+	c.evictions = int(evictions)
+	for _, m := range mementos {
+		c.push(m)
+		c.enforceLimits()
+	}
+	return nil
+}
+
+func readMemento(r *bytes.Reader) (*Memento, error) {
+	if err := readVersion(r); err != nil {
+		return nil, err
+	}
+	content, err := readFrame(r)
+	if err != nil {
+		return nil, err
+	}
+	cursor, err := readUvarint(r)
+	if err != nil {
+		return nil, err
+	}
+	if cursor > uint64(len(content)) {
+		return nil, fmt.Errorf("%w: cursor %d past end of content", ErrCorrupt, cursor)
+	}
+	return &Memento{
+		content: string(content),
+		cursor:  int(cursor),
+	}, nil
+}
+
+func readVersion(r io.ByteReader) error {
+	v, err := r.ReadByte()
+	if err != nil {
+		return fmt.Errorf("%w: missing version byte", ErrCorrupt)
+	}
+	if v != formatVersion {
+		return fmt.Errorf("%w: %d", ErrUnknownVersion, v)
+	}
+	return nil
+}
+
+func readUvarint(r io.ByteReader) (uint64, error) {
+	n, err := binary.ReadUvarint(r)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrCorrupt, err)
+	}
+	return n, nil
+}
+
+// readFrame reads a length-prefixed byte string without trusting the length
+// for the allocation, so a corrupt prefix can't request gigabytes up front.
+func readFrame(r interface {
+	io.Reader
+	io.ByteReader
+}) ([]byte, error) {
+	n, err := readUvarint(r)
+	if err != nil {
+		return nil, err
+	}
+	if n > math.MaxInt64 {
+		return nil, fmt.Errorf("%w: frame length %d", ErrCorrupt, n)
+	}
+	var buf bytes.Buffer
+	if _, err := io.CopyN(&buf, r, int64(n)); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrCorrupt, err)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,146 @@
+package memento
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestMementoBinaryRoundTrip(t *testing.T) {
+	e := NewEditor()
+	e.Write("hello world")
+	e.SetCursor(5)
+
+	data, err := e.Save().MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := &Memento{}
+	if err := m.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+
+	fresh := NewEditor()
+	if err := fresh.Restore(m); err != nil {
+		t.Fatal(err)
+	}
+	if fresh.Content() != "hello world" || fresh.Cursor() != 5 {
+		t.Fatalf("restored %q at %d, want %q at 5", fresh.Content(), fresh.Cursor(), "hello world")
+	}
+}
+
+func TestMementoUnmarshalRejectsBadInput(t *testing.T) {
+	good, err := (&Memento{content: "abc", cursor: 2}).MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		data []byte
+		want error
+	}{
+		{"empty", nil, ErrCorrupt},
+		{"unknown version", append([]byte{formatVersion + 1}, good[1:]...), ErrUnknownVersion},
+		{"truncated content", good[:3], ErrCorrupt},
+		{"missing cursor", good[:len(good)-1], ErrCorrupt},
+		{"trailing bytes", append(append([]byte{}, good...), 0), ErrCorrupt},
+		{"cursor past content", []byte{formatVersion, 1, 'a', 2}, ErrCorrupt},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &Memento{content: "untouched"}
+			if err := m.UnmarshalBinary(tt.data); !errors.Is(err, tt.want) {
+				t.Fatalf("UnmarshalBinary = %v, want %v", err, tt.want)
+			}
+			if m.content != "untouched" {
+				t.Fatalf("failed UnmarshalBinary changed the memento to %q", m.content)
+			}
+		})
+	}
+}
+
+func TestHistoryRoundTripIntoFreshEditor(t *testing.T) {
+	e := NewEditor()
+	c := NewCaretaker(e)
+	edit(c, e, "a", "b", "c")
+
+	var buf bytes.Buffer
+	if err := c.SaveTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	fresh := NewEditor()
+	loaded := NewCaretaker(fresh)
+	if err := loaded.LoadFrom(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if got := loaded.Stats().Snapshots; got != 3 {
+		t.Fatalf("loaded %d snapshots, want 3", got)
+	}
+	for _, want := range []string{"ab", "a", ""} {
+		if err := loaded.Undo(); err != nil {
+			t.Fatal(err)
+		}
+		if fresh.Content() != want {
+			t.Fatalf("content = %q, want %q", fresh.Content(), want)
+		}
+	}
+}
+
+func TestHistoryLoadKeepsEvictionCount(t *testing.T) {
+	e := NewEditor()
+	c := NewCaretaker(e, WithMaxSnapshots(1))
+	edit(c, e, "a", "b")
+
+	var buf bytes.Buffer
+	if err := c.SaveTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	loaded := NewCaretaker(NewEditor())
+	if err := loaded.LoadFrom(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if err := loaded.Undo(); err != nil {
+		t.Fatal(err)
+	}
+	var evicted *EvictedError
+	if err := loaded.Undo(); !errors.As(err, &evicted) {
+		t.Fatalf("Undo past loaded history = %v, want *EvictedError", err)
+	}
+}
+
+func TestHistoryLoadRejectsBadInput(t *testing.T) {
+	e := NewEditor()
+	c := NewCaretaker(e)
+	edit(c, e, "one", "two")
+	var buf bytes.Buffer
+	if err := c.SaveTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	data := buf.Bytes()
+
+	for i := 0; i < len(data); i++ {
+		if err := NewCaretaker(NewEditor()).LoadFrom(bytes.NewReader(data[:i])); !errors.Is(err, ErrCorrupt) {
+			t.Fatalf("LoadFrom of %d/%d bytes = %v, want ErrCorrupt", i, len(data), err)
+		}
+	}
+
+	bad := append([]byte{formatVersion + 1}, data[1:]...)
+	if err := NewCaretaker(NewEditor()).LoadFrom(bytes.NewReader(bad)); !errors.Is(err, ErrUnknownVersion) {
+		t.Fatalf("LoadFrom with unknown version = %v, want ErrUnknownVersion", err)
+	}
+}
+
+func TestHistoryLoadFailureKeepsCurrentHistory(t *testing.T) {
+	e := NewEditor()
+	c := NewCaretaker(e)
+	edit(c, e, "a", "b")
+
+	if err := c.LoadFrom(bytes.NewReader([]byte{formatVersion, 0, 5})); err == nil {
+		t.Fatal("LoadFrom of a truncated history succeeded")
+	}
+	if got := c.Stats().Snapshots; got != 2 {
+		t.Fatalf("history has %d snapshots after a failed load, want 2", got)
+	}
+}
@@ -0,0 +1,55 @@
+package memento
+
+// snapshot is a caretaker record: either a full memento (a keyframe) or a
+// delta against the record just before it in the history.
+type snapshot struct {
+	full  *Memento
+	delta *delta
+	// chain counts the deltas since the last keyframe, zero for a keyframe.
+	chain int
+}
+
+func (s *snapshot) size() int {
+	if s.full != nil {
+		return s.full.Size()
+	}
+	return s.delta.size()
+}
+
+// delta describes the content as prefix bytes kept from the previous
+// snapshot, then insert, then suffix bytes kept from its end.
+type delta struct {
+	prefix int
+	suffix int
+	insert string
+	cursor int
+}
+
+func diff(prev, next *Memento) *delta {
+	a, b := prev.content, next.content
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+	return &delta{
+		prefix: prefix,
+		suffix: suffix,
+		insert: b[prefix : len(b)-suffix],
+		cursor: next.cursor,
+	}
+}
+
+func (d *delta) apply(prev *Memento) *Memento {
+	return &Memento{
+		content: prev.content[:d.prefix] + d.insert + prev.content[len(prev.content)-d.suffix:],
+		cursor:  d.cursor,
+	}
+}
+
+func (d *delta) size() int {
+	return len(d.insert) + 3*cursorSize
+}
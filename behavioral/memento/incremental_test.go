@@ -0,0 +1,119 @@
+package memento
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+// randomEdit writes a short random string at a random position in both editors.
+func randomEdit(r *rand.Rand, editors ...*Editor) {
+	pos := r.Intn(len(editors[0].Content()) + 1)
+	text := strings.Repeat(string(rune('a'+r.Intn(3))), 1+r.Intn(4))
+	for _, e := range editors {
+		e.SetCursor(pos)
+		e.Write(text)
+	}
+}
+
+func TestIncrementalRestoresLikeFullCopies(t *testing.T) {
+	for seed := int64(0); seed < 100; seed++ {
+		r := rand.New(rand.NewSource(seed))
+		limit := r.Intn(30)
+		keyframes := 2 + r.Intn(6)
+
+		full, inc := NewEditor(), NewEditor()
+		fullHistory := NewCaretaker(full, WithMaxSnapshots(limit))
+		incHistory := NewCaretaker(inc, WithMaxSnapshots(limit), WithIncremental(keyframes))
+
+		for step := 0; step < 80; step++ {
+			switch r.Intn(6) {
+			case 0:
+				fullErr, incErr := fullHistory.Undo(), incHistory.Undo()
+				if (fullErr == nil) != (incErr == nil) {
+					t.Fatalf("seed %d step %d: Undo = %v in full mode, %v in incremental mode", seed, step, fullErr, incErr)
+				}
+			default:
+				fullHistory.Backup()
+				incHistory.Backup()
+				randomEdit(r, full, inc)
+			}
+			if full.Content() != inc.Content() || full.Cursor() != inc.Cursor() {
+				t.Fatalf("seed %d step %d: full %q@%d, incremental %q@%d", seed, step, full.Content(), full.Cursor(), inc.Content(), inc.Cursor())
+			}
+		}
+
+		for {
+			fullErr, incErr := fullHistory.Undo(), incHistory.Undo()
+			if (fullErr == nil) != (incErr == nil) {
+				t.Fatalf("seed %d: unwinding, Undo = %v in full mode, %v in incremental mode", seed, fullErr, incErr)
+			}
+			if full.Content() != inc.Content() || full.Cursor() != inc.Cursor() {
+				t.Fatalf("seed %d: unwinding, full %q, incremental %q", seed, full.Content(), inc.Content())
+			}
+			if fullErr != nil {
+				break
+			}
+		}
+	}
+}
+
+func TestIncrementalWritesKeyframes(t *testing.T) {
+	e := NewEditor()
+	c := NewCaretaker(e, WithIncremental(3))
+	edit(c, e, "a", "b", "c", "d", "e", "f", "g")
+
+	for i, s := range c.history {
+		if keyframe := i%3 == 0; (s.full != nil) != keyframe {
+			t.Fatalf("snapshot %d: keyframe = %v, want %v", i, s.full != nil, keyframe)
+		}
+	}
+}
+
+func TestIncrementalEvictionPromotesKeyframe(t *testing.T) {
+	e := NewEditor()
+	c := NewCaretaker(e, WithIncremental(10), WithMaxSnapshots(2))
+	edit(c, e, "a", "b", "c")
+
+	if c.history[0].full == nil {
+		t.Fatal("oldest retained snapshot is a delta; it has nothing to apply to")
+	}
+	if err := c.Undo(); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Undo(); err != nil {
+		t.Fatal(err)
+	}
+	if e.Content() != "a" {
+		t.Fatalf("content = %q, want %q", e.Content(), "a")
+	}
+}
+
+// BenchmarkEditingSession reports how many bytes a long session keeps alive
+// in each mode. Run with: go test -bench EditingSession -benchmem
+func BenchmarkEditingSession(b *testing.B) {
+	modes := []struct {
+		name string
+		opts []Option
+	}{
+		{"full", nil},
+		{"incremental", []Option{WithIncremental(16)}},
+	}
+	for _, mode := range modes {
+		b.Run(mode.name, func(b *testing.B) {
+			var stats Stats
+			for i := 0; i < b.N; i++ {
+				r := rand.New(rand.NewSource(1))
+				e := NewEditor()
+				e.Write(strings.Repeat("lorem ipsum ", 400))
+				c := NewCaretaker(e, mode.opts...)
+				for step := 0; step < 1000; step++ {
+					c.Backup()
+					randomEdit(r, e)
+				}
+				stats = c.Stats()
+			}
+			b.ReportMetric(float64(stats.Bytes), "history-bytes")
+		})
+	}
+}
@@ -0,0 +1,283 @@
+package memento
+
+import (
+	"errors"
+	"fmt"
+)
+
+//Memento is a behavioral design pattern that lets you save and restore the previous state of an object without revealing the details of its implementation.
+//The Memento pattern delegates creating the state snapshots to the actual owner of that state, the originator object.
+//Instead of other objects trying to copy the editor’s state from the “outside,” the editor class itself can make the snapshot since it has full access to its own state.
+//The snapshot is stored in a special object called memento. The contents of the memento aren’t accessible to any other object except the one that produced it.
+
+//How to Implement
+//
+//Determine what class will play the role of the originator. It’s important to know whether the program uses one central object of this type or multiple smaller ones.
+//
+//Create the memento class. One by one, declare a set of fields that mirror the fields declared inside the originator class.
+//
+//Make the memento class immutable. A memento should accept the data just once, via the constructor. The class should have no setters.
+//
+//If your programming language supports nested classes, nest the memento inside the originator.
+//If not, extract a blank interface from the memento class and make all other objects use it to refer to the memento.
+//In Go the same effect comes from unexported fields: only code inside this package can read what a memento holds.
+//
+//Add a method for producing mementos to the originator class. The originator should pass its state to the memento via one or multiple arguments of the memento’s constructor.
+//
+//Add a method for restoring the originator’s state to its class. It should accept a memento object as an argument.
+//
+//The caretaker, whether it represents a command object, a history, or something entirely different, should know when to request new mementos from the originator, how to store them and when to restore the originator with a particular memento.
+//
+//The link between caretakers and originators may be moved into the memento class. In this case, each memento must be connected to the originator that had created it.
+
+var (
+	ErrNilMemento = errors.New("memento: nil memento")
+	ErrNoHistory  = errors.New("memento: no snapshot to restore")
+	ErrNoRedo     = errors.New("memento: nothing to redo")
+)
+
+// cursorSize is the approximate cost of the non-text part of a snapshot.
+const cursorSize = 8
+
+type Editor struct {
+	content string
+	cursor  int
+}
+
+func NewEditor() *Editor {
+	return &Editor{}
+}
+
+// Write inserts text at the cursor and moves the cursor past it.
+func (e *Editor) Write(text string) {
+	e.content = e.content[:e.cursor] + text + e.content[e.cursor:]
+	e.cursor += len(text)
+}
+
+// SetCursor moves the cursor, clamping it to the bounds of the content.
+func (e *Editor) SetCursor(pos int) {
+	if pos < 0 {
+		pos = 0
+	}
+	if pos > len(e.content) {
+		pos = len(e.content)
+	}
+	e.cursor = pos
+}
+
+func (e *Editor) Content() string {
+	return e.content
+}
+
+func (e *Editor) Cursor() int {
+	return e.cursor
+}
+
+func (e *Editor) Save() *Memento {
+	return &Memento{
+		content: e.content,
+		cursor:  e.cursor,
+	}
+}
+
+func (e *Editor) Restore(m *Memento) error {
+	if m == nil {
+		return ErrNilMemento
+	}
+	e.content = m.content
+	e.cursor = m.cursor
+	return nil
+}
+
+type Memento struct {
+	content string
+	cursor  int
+}
+
+// Size reports the approximate number of bytes the snapshot keeps alive.
+func (m *Memento) Size() int {
+	return len(m.content) + cursorSize
+}
+
+// EvictedError is returned by Undo when the history ran dry because older
+// snapshots were dropped to respect the caretaker's limits.
+type EvictedError struct {
+	Evicted int
+}
+
+func (e *EvictedError) Error() string {
+	return fmt.Sprintf("memento: no snapshot to restore, %d older snapshot(s) were evicted by the history limits", e.Evicted)
+}
+
+// Stats describes the active line of history. Bytes covers both the undo
+// snapshots and the redo stack; preserved branches are only counted.
+type Stats struct {
+	Snapshots int
+	Redo      int
+	Bytes     int
+	Evictions int
+	Branches  int
+}
+
+type Option func(c *Caretaker)
+
+// WithMaxSnapshots caps the number of retained snapshots. Zero or negative means unlimited.
+func WithMaxSnapshots(n int) Option {
+	return func(c *Caretaker) {
+		c.maxSnapshots = n
+	}
+}
+
+// WithMaxBytes caps the summed Size of retained snapshots. Zero or negative means unlimited.
+// The newest snapshot is always kept, even when it alone is over the budget.
+func WithMaxBytes(n int) Option {
+	return func(c *Caretaker) {
+		c.maxBytes = n
+	}
+}
+
+// WithIncremental stores every snapshot after a keyframe as a diff against the
+// one before it, writing a full keyframe every keyframeEvery snapshots.
+// A value of 1 or less keeps every snapshot as a full copy.
+func WithIncremental(keyframeEvery int) Option {
+	return func(c *Caretaker) {
+		c.keyframeEvery = keyframeEvery
+	}
+}
+
+type Caretaker struct {
+	editor        *Editor
+	history       []*snapshot
+	redo          []*Memento
+	maxSnapshots  int
+	maxBytes      int
+	keyframeEvery int
+	bytes         int
+	evictions     int
+
+	branching  bool
+	branch     int
+	nextBranch int
+	branches   map[int]*branch
+}
+
+func NewCaretaker(editor *Editor, opts ...Option) *Caretaker {
+	c := &Caretaker{
+		editor:   editor,
+		history:  make([]*snapshot, 0),
+		redo:     make([]*Memento, 0),
+		branches: make(map[int]*branch),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Backup snapshots the editor, evicting the oldest snapshots first when a limit is crossed.
+// Call it before every change. A backup taken after Undo starts a new line of
+// history: the redo stack is discarded, or kept as a branch with WithBranching.
+func (c *Caretaker) Backup() {
+	if len(c.redo) > 0 {
+		if c.branching {
+			c.fork()
+		}
+		for _, m := range c.redo {
+			c.bytes -= m.Size()
+		}
+		c.redo = make([]*Memento, 0)
+	}
+	c.push(c.editor.Save())
+	c.enforceLimits()
+}
+
+// Undo restores the most recent snapshot, moving the editor's current state onto the redo stack.
+func (c *Caretaker) Undo() error {
+	if len(c.history) == 0 {
+		if c.evictions > 0 {
+			return &EvictedError{Evicted: c.evictions}
+		}
+		return ErrNoHistory
+	}
+	last := len(c.history) - 1
+	m := c.memento(last)
+	c.bytes -= c.history[last].size()
+	c.history[last] = nil
+	c.history = c.history[:last]
+
+	current := c.editor.Save()
+	c.redo = append(c.redo, current)
+	c.bytes += current.Size()
+	return c.editor.Restore(m)
+}
+
+func (c *Caretaker) Stats() Stats {
+	return Stats{
+		Snapshots: len(c.history),
+		Redo:      len(c.redo),
+		Bytes:     c.bytes,
+		Evictions: c.evictions,
+		Branches:  len(c.branches),
+	}
+}
+
+// push appends m to the history, as a diff against the newest snapshot when
+// running in incremental mode and no keyframe is due.
+func (c *Caretaker) push(m *Memento) {
+	s := &snapshot{full: m}
+	if last := len(c.history) - 1; last >= 0 && c.history[last].chain+1 < c.keyframeEvery {
+		s = &snapshot{
+			delta: diff(c.memento(last), m),
+			chain: c.history[last].chain + 1,
+		}
+	}
+	c.history = append(c.history, s)
+	c.bytes += s.size()
+}
+
+// memento rebuilds the full snapshot at index i by replaying diffs forward
+// from the nearest keyframe at or before it.
+func (c *Caretaker) memento(i int) *Memento {
+	start := i
+	for c.history[start].full == nil {
+		start--
+	}
+	m := c.history[start].full
+	for j := start + 1; j <= i; j++ {
+		m = c.history[j].delta.apply(m)
+	}
+	return m
+}
+
+func (c *Caretaker) enforceLimits() {
+	for len(c.history) > 1 && c.overLimit() {
+		oldest := c.history[0]
+		if next := c.history[1]; next.full == nil {
+			// the next snapshot is a diff against the one being evicted,
+			// so it becomes the new keyframe
+			c.bytes -= next.size()
+			c.history[1] = &snapshot{full: c.memento(1)}
+			c.bytes += c.history[1].size()
+		}
+		c.history[0] = nil
+		c.history = c.history[1:]
+		c.bytes -= oldest.size()
+		c.evictions++
+	}
+}
+
+func (c *Caretaker) overLimit() bool {
+	if c.maxSnapshots > 0 && len(c.history) > c.maxSnapshots {
+		return true
+	}
+	return c.maxBytes > 0 && c.bytes > c.maxBytes
+}
+
+//Pros and Cons
+//
+//You can produce snapshots of the object’s state without violating its encapsulation.
+//You can simplify the originator’s code by letting the caretaker maintain the history of the originator’s state.
+//
+//The app might consume lots of RAM if clients create mementos too often. Capping the caretaker's history by count or bytes keeps this bounded, at the cost of losing the oldest undo steps.
+//Caretakers should track the originator’s lifecycle to be able to destroy obsolete mementos.
+//Most dynamic programming languages, such as PHP, Python and JavaScript, can’t guarantee that the state within the memento stays untouched.
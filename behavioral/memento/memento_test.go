@@ -0,0 +1,117 @@
+package memento
+
+import (
+	"errors"
+	"testing"
+)
+
+// edit backs up the editor and then appends text, the way a client using the caretaker would.
+func edit(c *Caretaker, e *Editor, texts ...string) {
+	for _, text := range texts {
+		c.Backup()
+		e.Write(text)
+	}
+}
+
+func TestRestoreNilMemento(t *testing.T) {
+	if err := NewEditor().Restore(nil); !errors.Is(err, ErrNilMemento) {
+		t.Fatalf("Restore(nil) = %v, want ErrNilMemento", err)
+	}
+}
+
+func TestUndoWalksBackThroughHistory(t *testing.T) {
+	e := NewEditor()
+	c := NewCaretaker(e)
+	edit(c, e, "a", "b", "c")
+
+	for _, want := range []string{"ab", "a", ""} {
+		if err := c.Undo(); err != nil {
+			t.Fatal(err)
+		}
+		if e.Content() != want {
+			t.Fatalf("after undo content = %q, want %q", e.Content(), want)
+		}
+	}
+	if err := c.Undo(); !errors.Is(err, ErrNoHistory) {
+		t.Fatalf("Undo on empty history = %v, want ErrNoHistory", err)
+	}
+}
+
+func TestMaxSnapshotsEvictsOldestFirst(t *testing.T) {
+	e := NewEditor()
+	c := NewCaretaker(e, WithMaxSnapshots(3))
+	edit(c, e, "a", "b", "c", "d", "e")
+
+	stats := c.Stats()
+	if stats.Snapshots != 3 || stats.Evictions != 2 {
+		t.Fatalf("Stats() = %+v, want 3 snapshots and 2 evictions", stats)
+	}
+	// the snapshots of "" and "a" went first, so undo stops at "ab"
+	for _, want := range []string{"abcd", "abc", "ab"} {
+		if err := c.Undo(); err != nil {
+			t.Fatal(err)
+		}
+		if e.Content() != want {
+			t.Fatalf("content = %q, want %q", e.Content(), want)
+		}
+	}
+
+	var evicted *EvictedError
+	if err := c.Undo(); !errors.As(err, &evicted) || evicted.Evicted != 2 {
+		t.Fatalf("Undo past the retained history = %v, want *EvictedError{2}", err)
+	}
+}
+
+func TestMaxBytesEvictsOldestFirst(t *testing.T) {
+	e := NewEditor()
+	// snapshots of "", "1234", "12345678" cost 8, 12 and 16 bytes
+	c := NewCaretaker(e, WithMaxBytes(30))
+	edit(c, e, "1234", "5678", "90")
+
+	stats := c.Stats()
+	if stats.Snapshots != 2 || stats.Evictions != 1 || stats.Bytes != 28 {
+		t.Fatalf("Stats() = %+v, want 2 snapshots, 1 eviction and 28 bytes", stats)
+	}
+	undos := 0
+	for c.Undo() == nil {
+		undos++
+	}
+	if undos != stats.Snapshots {
+		t.Fatalf("undid %d times, want the %d retained snapshots", undos, stats.Snapshots)
+	}
+	if e.Content() != "1234" {
+		t.Fatalf("oldest retained content = %q, want %q", e.Content(), "1234")
+	}
+}
+
+func TestMaxBytesKeepsNewestSnapshot(t *testing.T) {
+	e := NewEditor()
+	c := NewCaretaker(e, WithMaxBytes(1))
+	e.Write("larger than the whole budget")
+	c.Backup()
+
+	if stats := c.Stats(); stats.Snapshots != 1 || stats.Evictions != 0 {
+		t.Fatalf("Stats() = %+v, want the single snapshot kept", stats)
+	}
+}
+
+func TestBothLimitsTogether(t *testing.T) {
+	e := NewEditor()
+	c := NewCaretaker(e, WithMaxSnapshots(4), WithMaxBytes(40))
+	edit(c, e, "aaaa", "bbbb", "cccc", "dddd", "eeee", "ffff")
+
+	stats := c.Stats()
+	if stats.Bytes > 40 || stats.Snapshots > 4 {
+		t.Fatalf("Stats() = %+v, over a limit", stats)
+	}
+	if stats.Snapshots+stats.Evictions != 6 {
+		t.Fatalf("Stats() = %+v, want every backup either retained or evicted", stats)
+	}
+	total := 0
+	for i := range c.history {
+		total += c.memento(i).Size()
+	}
+	if total != stats.Bytes {
+		t.Fatalf("Stats().Bytes = %d, want the summed size %d", stats.Bytes, total)
+	}
+}
@@ -0,0 +1,190 @@
+package observer
+
+import "sync"
+
+// pool runs async notifications. A subscriber with queued events is handed to
+// at most one worker at a time, which drains its mailbox in order; that is
+// what keeps per-observer ordering while different observers run in parallel.
+//
+// Published events go through a single dispatcher first. It hands each
+// delivery group to the workers in turn and waits for a group to finish
+// before starting the next, without making the publisher wait.
+type pool[E any] struct {
+	topic       *Topic[E]
+	workers     sync.WaitGroup
+	dispatching sync.WaitGroup
+
+	mu       sync.Mutex
+	queue    []dispatch[E]
+	ready    []*subscriber[E]
+	pending  int
+	stopped  bool
+	draining bool
+	queued   *sync.Cond
+	work     *sync.Cond
+	idle     *sync.Cond
+}
+
+type dispatch[E any] struct {
+	event  E
+	groups [][]*subscriber[E]
+}
+
+// delivery is a queued event; done is set when a dispatcher waits on it.
+type delivery[E any] struct {
+	event E
+	done  *sync.WaitGroup
+}
+
+func newPool[E any](t *Topic[E], workers int) *pool[E] {
+	p := &pool[E]{
+		topic: t,
+		queue: make([]dispatch[E], 0),
+		ready: make([]*subscriber[E], 0),
+	}
+	p.queued = sync.NewCond(&p.mu)
+	p.work = sync.NewCond(&p.mu)
+	p.idle = sync.NewCond(&p.mu)
+	p.workers.Add(workers)
+	for i := 0; i < workers; i++ {
+		go p.run()
+	}
+	p.dispatching.Add(1)
+	go p.dispatch()
+	return p
+}
+
+// publish queues e for the dispatcher. It drops the event once the pool is stopped.
+func (p *pool[E]) publish(e E, groups [][]*subscriber[E]) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.stopped {
+		return
+	}
+	p.pending++
+	p.queue = append(p.queue, dispatch[E]{event: e, groups: groups})
+	p.queued.Signal()
+}
+
+func (p *pool[E]) dispatch() {
+	defer p.dispatching.Done()
+	for {
+		p.mu.Lock()
+		for len(p.queue) == 0 && !p.stopped {
+			p.queued.Wait()
+		}
+		if len(p.queue) == 0 {
+			p.mu.Unlock()
+			return
+		}
+		d := p.queue[0]
+		p.queue = p.queue[1:]
+		p.mu.Unlock()
+
+		for i, group := range d.groups {
+			// the last group has nobody after it to hold back
+			var done *sync.WaitGroup
+			if i < len(d.groups)-1 {
+				done = &sync.WaitGroup{}
+				done.Add(len(group))
+			}
+			for _, sub := range group {
+				p.enqueue(sub, delivery[E]{event: d.event, done: done})
+			}
+			if done != nil {
+				done.Wait()
+			}
+		}
+		p.finish()
+	}
+}
+
+func (p *pool[E]) enqueue(sub *subscriber[E], d delivery[E]) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.pending++
+
+	sub.mu.Lock()
+	sub.mailbox = append(sub.mailbox, d)
+	schedule := !sub.scheduled
+	sub.scheduled = true
+	sub.mu.Unlock()
+
+	if schedule {
+		p.ready = append(p.ready, sub)
+		p.work.Signal()
+	}
+}
+
+func (p *pool[E]) run() {
+	defer p.workers.Done()
+	for {
+		p.mu.Lock()
+		for len(p.ready) == 0 && !p.draining {
+			p.work.Wait()
+		}
+		if len(p.ready) == 0 {
+			p.mu.Unlock()
+			return
+		}
+		sub := p.ready[0]
+		p.ready = p.ready[1:]
+		p.mu.Unlock()
+
+		p.drain(sub)
+	}
+}
+
+func (p *pool[E]) drain(sub *subscriber[E]) {
+	for {
+		sub.mu.Lock()
+		if len(sub.mailbox) == 0 {
+			sub.scheduled = false
+			sub.mu.Unlock()
+			return
+		}
+		d := sub.mailbox[0]
+		sub.mailbox = sub.mailbox[1:]
+		sub.mu.Unlock()
+
+		p.topic.deliver(sub, d.event)
+		if d.done != nil {
+			d.done.Done()
+		}
+		p.finish()
+	}
+}
+
+// finish marks one dispatched event or one delivery as handled.
+func (p *pool[E]) finish() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.pending--
+	if p.pending == 0 {
+		p.idle.Broadcast()
+	}
+}
+
+func (p *pool[E]) flush() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for p.pending > 0 {
+		p.idle.Wait()
+	}
+}
+
+// stop lets the dispatcher hand out everything already published, then lets
+// the workers run their mailboxes dry before exiting.
+func (p *pool[E]) stop() {
+	p.mu.Lock()
+	p.stopped = true
+	p.queued.Broadcast()
+	p.mu.Unlock()
+	p.dispatching.Wait()
+
+	p.mu.Lock()
+	p.draining = true
+	p.work.Broadcast()
+	p.mu.Unlock()
+	p.workers.Wait()
+}
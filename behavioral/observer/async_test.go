@@ -0,0 +1,177 @@
+package observer
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestAsyncKeepsPerObserverOrder(t *testing.T) {
+	const observers, events = 6, 300
+	topic := NewTopic[int](Async(4))
+	defer topic.Stop()
+
+	got := make([][]int, observers)
+	for i := range got {
+		topic.Subscribe(func(v int) {
+			// uneven work so observers drift apart
+			if v%(i+2) == 0 {
+				time.Sleep(time.Microsecond)
+			}
+			got[i] = append(got[i], v)
+		})
+	}
+	for v := 0; v < events; v++ {
+		topic.Publish(v)
+	}
+	topic.Flush()
+
+	for i, seen := range got {
+		if len(seen) != events {
+			t.Fatalf("observer %d got %d events, want %d", i, len(seen), events)
+		}
+		for j, v := range seen {
+			if v != j {
+				t.Fatalf("observer %d got event %d at position %d", i, v, j)
+			}
+		}
+	}
+}
+
+func TestAsyncKeepsOrderPerPublisher(t *testing.T) {
+	const publishers, events = 4, 100
+	topic := NewTopic[[2]int](Async(3))
+	defer topic.Stop()
+
+	last := make([]int, publishers)
+	for i := range last {
+		last[i] = -1
+	}
+	var outOfOrder atomic.Int64
+	topic.Subscribe(func(e [2]int) {
+		if e[1] != last[e[0]]+1 {
+			outOfOrder.Add(1)
+		}
+		last[e[0]] = e[1]
+	})
+
+	var wg sync.WaitGroup
+	for p := 0; p < publishers; p++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for v := 0; v < events; v++ {
+				topic.Publish([2]int{p, v})
+			}
+		}()
+	}
+	wg.Wait()
+	topic.Flush()
+
+	if n := outOfOrder.Load(); n != 0 {
+		t.Fatalf("%d events arrived out of their publisher's order", n)
+	}
+}
+
+func TestAsyncPublishDoesNotWaitForObservers(t *testing.T) {
+	topic := NewTopic[int](Async(1))
+	defer topic.Stop()
+	release := make(chan struct{})
+	topic.Subscribe(func(int) {
+		<-release
+	})
+
+	published := make(chan struct{})
+	go func() {
+		topic.Publish(1)
+		topic.Publish(2)
+		close(published)
+	}()
+	select {
+	case <-published:
+	case <-time.After(time.Second):
+		t.Fatal("Publish waited for a slow observer")
+	}
+	close(release)
+	topic.Flush()
+}
+
+func TestAsyncFlushWaitsForQuiescence(t *testing.T) {
+	topic := NewTopic[int](Async(2))
+	defer topic.Stop()
+	var handled atomic.Int64
+	for i := 0; i < 3; i++ {
+		topic.Subscribe(func(int) {
+			time.Sleep(time.Millisecond)
+			handled.Add(1)
+		})
+	}
+	for v := 0; v < 10; v++ {
+		topic.Publish(v)
+	}
+	topic.Flush()
+
+	if n := handled.Load(); n != 30 {
+		t.Fatalf("handled %d deliveries when Flush returned, want 30", n)
+	}
+	// a synchronous topic has nothing to flush
+	NewTopic[int]().Flush()
+}
+
+func TestAsyncIsolatesPanics(t *testing.T) {
+	var mu sync.Mutex
+	var reported []error
+	topic := NewTopic[int](Async(2), OnError(func(err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		reported = append(reported, err)
+	}))
+	defer topic.Stop()
+
+	topic.Subscribe(func(v int) {
+		if v == 3 {
+			panic("boom")
+		}
+	})
+	var healthy atomic.Int64
+	topic.Subscribe(func(int) {
+		healthy.Add(1)
+	})
+	for v := 0; v < 10; v++ {
+		topic.Publish(v)
+	}
+	topic.Flush()
+
+	if n := healthy.Load(); n != 10 {
+		t.Fatalf("healthy observer got %d events, want 10", n)
+	}
+	if len(reported) != 1 {
+		t.Fatalf("OnError got %v, want one *PanicError", reported)
+	}
+	if _, ok := reported[0].(*PanicError); !ok {
+		t.Fatalf("OnError got %T, want *PanicError", reported[0])
+	}
+}
+
+func TestAsyncStopDrainsThenDrops(t *testing.T) {
+	topic := NewTopic[int](Async(2))
+	var handled atomic.Int64
+	topic.Subscribe(func(int) {
+		time.Sleep(time.Millisecond)
+		handled.Add(1)
+	})
+	for v := 0; v < 5; v++ {
+		topic.Publish(v)
+	}
+	topic.Stop()
+	if n := handled.Load(); n != 5 {
+		t.Fatalf("Stop returned after %d of 5 queued events", n)
+	}
+
+	topic.Publish(99)
+	topic.Flush()
+	if n := handled.Load(); n != 5 {
+		t.Fatal("an event published after Stop was delivered")
+	}
+}
@@ -0,0 +1,53 @@
+package observer
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/Antonious-Stewart/15-Most-Common-Design-Patterns/creational/factoryMethod"
+	"github.com/Antonious-Stewart/15-Most-Common-Design-Patterns/internal/output"
+	"github.com/Antonious-Stewart/15-Most-Common-Design-Patterns/structural/composite"
+)
+
+// Demo logs the events of a phone that a reminder keeps charged, then
+// indexes a division as units are attached to and removed from it.
+func Demo(w io.Writer) error {
+	phones := NewTopic[factoryMethod.PhoneEvent]()
+	reminder := NewChargeReminderObserver(50)
+	phones.Subscribe(NewLoggingObserver(w).Notify)
+	phones.Subscribe(reminder.Notify)
+
+	phone := factoryMethod.NewAndroid(
+		factoryMethod.WithPublisher(phones),
+		factoryMethod.WithSink(output.NewWriter(w)),
+	)
+	if err := phone.TurnOn(); err != nil {
+		return err
+	}
+	if err := phone.Elapse(9); err != nil {
+		return err
+	}
+	if err := phone.TurnOff(); err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "charged %d time(s), battery at %d%%\n", reminder.Charged(), phone.BatteryLevel())
+
+	changes := NewTopic[composite.ChangeEvent]()
+	index := NewUnitIndex()
+	changes.Subscribe(index.Notify)
+	division := composite.NewDivision("1st")
+	division.SetPublisher(changes)
+	alpha, bravo := composite.NewBrigade("Alpha"), composite.NewBrigade("Bravo")
+	platoon := composite.NewPlatoon("1st Platoon")
+	if err := alpha.Add(platoon); err != nil {
+		return err
+	}
+	if err := division.Add(alpha, bravo); err != nil {
+		return err
+	}
+	division.Remove(bravo)
+	for _, path := range index.Paths() {
+		fmt.Fprintln(w, path)
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package observer
+
+import "context"
+
+type subscribeConfig struct {
+	limited  bool
+	limit    int
+	ctx      context.Context
+	priority int
+	group    int
+}
+
+type SubscribeOption func(c *subscribeConfig)
+
+// Once expires the subscription after its first delivery.
+func Once() SubscribeOption {
+	return Times(1)
+}
+
+// Times expires the subscription after n deliveries. With n below one the
+// subscription never receives anything.
+func Times(n int) SubscribeOption {
+	return func(c *subscribeConfig) {
+		c.limited = true
+		c.limit = n
+	}
+}
+
+// Until expires the subscription when ctx is done. A delivery already running
+// finishes, but no new one starts.
+func Until(ctx context.Context) SubscribeOption {
+	return func(c *subscribeConfig) {
+		c.ctx = ctx
+	}
+}
+
+// SubscribeOnce delivers exactly one event to fn. Pass FilterSubscribe Once()
+// instead to count only matching events.
+func (t *Topic[E]) SubscribeOnce(fn func(E), opts ...SubscribeOption) (unsubscribe func()) {
+	return t.Subscribe(fn, append(opts, Once())...)
+}
+
+func (t *Topic[E]) SubscribeN(n int, fn func(E), opts ...SubscribeOption) (unsubscribe func()) {
+	return t.Subscribe(fn, append(opts, Times(n))...)
+}
+
+func (t *Topic[E]) SubscribeUntil(ctx context.Context, fn func(E), opts ...SubscribeOption) (unsubscribe func()) {
+	return t.Subscribe(fn, append(opts, Until(ctx))...)
+}
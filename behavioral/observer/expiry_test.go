@@ -0,0 +1,165 @@
+package observer
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSubscribeOnce(t *testing.T) {
+	topic := NewTopic[int]()
+	var got []int
+	topic.SubscribeOnce(func(v int) {
+		got = append(got, v)
+	})
+	for v := 1; v <= 3; v++ {
+		topic.Publish(v)
+	}
+
+	if !slices.Equal(got, []int{1}) {
+		t.Fatalf("got %v, want [1]", got)
+	}
+	if n := topic.SubscriberCount(); n != 0 {
+		t.Fatalf("SubscriberCount() = %d after the only delivery, want 0", n)
+	}
+}
+
+func TestSubscribeN(t *testing.T) {
+	topic := NewTopic[int]()
+	var got []int
+	topic.SubscribeN(2, func(v int) {
+		got = append(got, v)
+	})
+	topic.SubscribeN(0, func(v int) {
+		t.Fatalf("SubscribeN(0) received %d", v)
+	})
+	for v := 1; v <= 4; v++ {
+		topic.Publish(v)
+	}
+
+	if !slices.Equal(got, []int{1, 2}) {
+		t.Fatalf("got %v, want [1 2]", got)
+	}
+	if n := topic.SubscriberCount(); n != 0 {
+		t.Fatalf("SubscriberCount() = %d, want 0", n)
+	}
+}
+
+func TestSubscribeUntil(t *testing.T) {
+	topic := NewTopic[int]()
+	ctx, cancel := context.WithCancel(context.Background())
+	var got []int
+	topic.SubscribeUntil(ctx, func(v int) {
+		got = append(got, v)
+	})
+	topic.Publish(1)
+	cancel()
+	topic.Publish(2)
+
+	if !slices.Equal(got, []int{1}) {
+		t.Fatalf("got %v, want [1]", got)
+	}
+	// the context callback runs on its own goroutine
+	deadline := time.Now().Add(time.Second)
+	for topic.SubscriberCount() != 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if n := topic.SubscriberCount(); n != 0 {
+		t.Fatalf("SubscriberCount() = %d after cancel, want 0", n)
+	}
+
+	topic.SubscribeUntil(ctx, func(int) {
+		t.Fatal("subscription with a done context received an event")
+	})
+	topic.Publish(3)
+}
+
+func TestExpiryComposesWithFilter(t *testing.T) {
+	topic := NewTopic[int]()
+	var got []int
+	topic.FilterSubscribe(func(v int) bool {
+		return v%2 == 0
+	}, func(v int) {
+		got = append(got, v)
+	}, Times(2))
+	for v := 1; v <= 6; v++ {
+		topic.Publish(v)
+	}
+
+	if !slices.Equal(got, []int{2, 4}) {
+		t.Fatalf("got %v, want the first two even numbers", got)
+	}
+}
+
+func TestLimitHoldsUnderConcurrentPublishers(t *testing.T) {
+	topic := NewTopic[int](Async(8))
+	defer topic.Stop()
+	var n, once atomic.Int64
+	topic.SubscribeN(5, func(int) {
+		n.Add(1)
+	})
+	topic.SubscribeOnce(func(int) {
+		once.Add(1)
+	})
+
+	var wg sync.WaitGroup
+	for g := 0; g < 10; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for v := 0; v < 50; v++ {
+				topic.Publish(v)
+			}
+		}()
+	}
+	wg.Wait()
+	topic.Flush()
+
+	if n.Load() != 5 || once.Load() != 1 {
+		t.Fatalf("deliveries = %d and %d, want 5 and 1", n.Load(), once.Load())
+	}
+	if c := topic.SubscriberCount(); c != 0 {
+		t.Fatalf("SubscriberCount() = %d, want 0", c)
+	}
+}
+
+func TestCancelDuringPublishBurst(t *testing.T) {
+	topic := NewTopic[int](Async(4))
+	defer topic.Stop()
+	ctx, cancel := context.WithCancel(context.Background())
+	var afterCancel atomic.Int64
+	var cancelled atomic.Bool
+	topic.SubscribeUntil(ctx, func(int) {
+		if cancelled.Load() {
+			afterCancel.Add(1)
+		}
+	})
+
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for v := 0; v < 200; v++ {
+				topic.Publish(v)
+			}
+		}()
+	}
+	time.Sleep(time.Millisecond)
+	cancel()
+	// deliveries that started before the context callback ran may still finish
+	for topic.SubscriberCount() != 0 {
+		time.Sleep(time.Millisecond)
+	}
+	topic.Flush()
+	cancelled.Store(true)
+	wg.Wait()
+	topic.Flush()
+
+	if n := afterCancel.Load(); n != 0 {
+		t.Fatalf("%d deliveries started after the subscription was removed", n)
+	}
+}
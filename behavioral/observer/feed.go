@@ -0,0 +1,161 @@
+package observer
+
+import "sync"
+
+// StockEvent is the stock example's event for channel subscribers.
+type StockEvent struct {
+	Item    string
+	InStock bool
+}
+
+// OverflowPolicy decides what Publish does when a subscriber's buffer is full.
+type OverflowPolicy int
+
+const (
+	// Block waits until the subscriber makes room, unsubscribes or the feed is closed.
+	// One slow consumer therefore slows every publisher down.
+	Block OverflowPolicy = iota
+	// DropNewest discards the event being published for that subscriber.
+	DropNewest
+	// DropOldest discards the oldest buffered event to make room for the new one.
+	DropOldest
+)
+
+// Feed is the channel flavoured variant of Subject: subscribers receive events
+// on their own buffered channel instead of through an Update method.
+type Feed[E any] struct {
+	mu          sync.RWMutex
+	subscribers map[*subscription[E]]struct{}
+	closed      bool
+	// done is closed first thing in Close, without taking mu, so publishers
+	// blocked on a full subscriber let go of their read lock.
+	done    chan struct{}
+	closing sync.Once
+}
+
+type subscription[E any] struct {
+	ch     chan E
+	policy OverflowPolicy
+	// done is closed before ch so a blocked publisher lets go first.
+	done chan struct{}
+	once sync.Once
+}
+
+func NewFeed[E any]() *Feed[E] {
+	return &Feed[E]{
+		subscribers: make(map[*subscription[E]]struct{}),
+		done:        make(chan struct{}),
+	}
+}
+
+// Subscribe returns the channel events arrive on and a func that stops delivery
+// and closes it. The func is safe to call more than once. Subscribing to a
+// closed feed returns an already closed channel. The drop policies need
+// somewhere to drop from, so they always get a buffer of at least one.
+func (f *Feed[E]) Subscribe(buffer int, policy OverflowPolicy) (<-chan E, func()) {
+	if buffer < 0 {
+		buffer = 0
+	}
+	if policy != Block && buffer == 0 {
+		buffer = 1
+	}
+	sub := &subscription[E]{
+		ch:     make(chan E, buffer),
+		policy: policy,
+		done:   make(chan struct{}),
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.closed {
+		close(sub.done)
+		close(sub.ch)
+		return sub.ch, func() {}
+	}
+	f.subscribers[sub] = struct{}{}
+	return sub.ch, func() {
+		f.unsubscribe(sub)
+	}
+}
+
+// Publish delivers e to every subscriber according to its overflow policy.
+func (f *Feed[E]) Publish(e E) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	for sub := range f.subscribers {
+		sub.deliver(e, f.done)
+	}
+}
+
+// Close closes every subscriber channel exactly once. Publishing after Close is a no-op.
+func (f *Feed[E]) Close() {
+	// Waiting for mu here could deadlock: a blocked publisher holds the read
+	// lock, and a Subscribe queued behind it keeps new readers out.
+	f.closing.Do(func() {
+		close(f.done)
+	})
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.closed {
+		return
+	}
+	f.closed = true
+	for sub := range f.subscribers {
+		sub.stop()
+		close(sub.ch)
+		delete(f.subscribers, sub)
+	}
+}
+
+func (f *Feed[E]) unsubscribe(sub *subscription[E]) {
+	// stop first, without the lock, so a publisher blocked on this
+	// subscriber can return and release its read lock
+	sub.stop()
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.subscribers[sub]; !ok {
+		return
+	}
+	delete(f.subscribers, sub)
+	close(sub.ch)
+}
+
+func (s *subscription[E]) stop() {
+	s.once.Do(func() {
+		close(s.done)
+	})
+}
+
+func (s *subscription[E]) deliver(e E, closed <-chan struct{}) {
+	switch s.policy {
+	case DropNewest:
+		select {
+		case s.ch <- e:
+		default:
+		}
+	case DropOldest:
+		for {
+			select {
+			case s.ch <- e:
+				return
+			case <-s.done:
+				return
+			case <-closed:
+				return
+			default:
+			}
+			select {
+			case <-s.ch:
+			default:
+			}
+		}
+	default:
+		select {
+		case s.ch <- e:
+		case <-s.done:
+		case <-closed:
+		}
+	}
+}
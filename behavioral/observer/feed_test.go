@@ -0,0 +1,184 @@
+package observer
+
+import (
+	"testing"
+	"time"
+)
+
+func drain[E any](ch <-chan E) []E {
+	got := make([]E, 0)
+	for {
+		select {
+		case e, ok := <-ch:
+			if !ok {
+				return got
+			}
+			got = append(got, e)
+		default:
+			return got
+		}
+	}
+}
+
+func items(events []StockEvent) []string {
+	names := make([]string, len(events))
+	for i, e := range events {
+		names[i] = e.Item
+	}
+	return names
+}
+
+func publishItems(f *Feed[StockEvent], names ...string) {
+	for _, name := range names {
+		f.Publish(StockEvent{Item: name, InStock: true})
+	}
+}
+
+func TestFeedDeliversToEverySubscriber(t *testing.T) {
+	f := NewFeed[StockEvent]()
+	a, _ := f.Subscribe(4, Block)
+	b, _ := f.Subscribe(4, Block)
+	publishItems(f, "shirt", "hat")
+
+	for name, ch := range map[string]<-chan StockEvent{"a": a, "b": b} {
+		if got := items(drain(ch)); len(got) != 2 || got[0] != "shirt" || got[1] != "hat" {
+			t.Fatalf("subscriber %s got %v, want [shirt hat]", name, got)
+		}
+	}
+}
+
+func TestFeedOverflowPolicies(t *testing.T) {
+	tests := []struct {
+		policy OverflowPolicy
+		buffer int
+		want   []string
+	}{
+		{DropNewest, 2, []string{"1", "2"}},
+		{DropOldest, 2, []string{"3", "4"}},
+		{DropNewest, 0, []string{"1"}},
+		{DropOldest, 0, []string{"4"}},
+	}
+	for _, tt := range tests {
+		f := NewFeed[StockEvent]()
+		ch, _ := f.Subscribe(tt.buffer, tt.policy)
+		publishItems(f, "1", "2", "3", "4")
+
+		got := items(drain(ch))
+		if len(got) != len(tt.want) {
+			t.Fatalf("policy %d buffer %d: got %v, want %v", tt.policy, tt.buffer, got, tt.want)
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Fatalf("policy %d buffer %d: got %v, want %v", tt.policy, tt.buffer, got, tt.want)
+			}
+		}
+	}
+}
+
+func TestFeedBlockWaitsForRoom(t *testing.T) {
+	f := NewFeed[StockEvent]()
+	ch, _ := f.Subscribe(1, Block)
+	publishItems(f, "1")
+
+	published := make(chan struct{})
+	go func() {
+		publishItems(f, "2")
+		close(published)
+	}()
+	select {
+	case <-published:
+		t.Fatal("Publish returned while the subscriber's buffer was full")
+	case <-time.After(10 * time.Millisecond):
+	}
+	if e := <-ch; e.Item != "1" {
+		t.Fatalf("first event = %q, want 1", e.Item)
+	}
+	<-published
+	if e := <-ch; e.Item != "2" {
+		t.Fatalf("second event = %q, want 2", e.Item)
+	}
+}
+
+func TestFeedUnsubscribe(t *testing.T) {
+	f := NewFeed[StockEvent]()
+	ch, unsubscribe := f.Subscribe(4, Block)
+	other, _ := f.Subscribe(4, Block)
+	publishItems(f, "1")
+
+	unsubscribe()
+	unsubscribe()
+	publishItems(f, "2")
+
+	if got := items(drain(ch)); len(got) != 1 || got[0] != "1" {
+		t.Fatalf("unsubscribed channel got %v, want [1]", got)
+	}
+	if _, ok := <-ch; ok {
+		t.Fatal("channel still open after unsubscribe")
+	}
+	if got := items(drain(other)); len(got) != 2 {
+		t.Fatalf("other subscriber got %v, want both events", got)
+	}
+}
+
+func TestFeedUnsubscribeReleasesBlockedPublisher(t *testing.T) {
+	f := NewFeed[StockEvent]()
+	_, unsubscribe := f.Subscribe(0, Block)
+
+	published := make(chan struct{})
+	go func() {
+		publishItems(f, "1")
+		close(published)
+	}()
+	time.Sleep(10 * time.Millisecond)
+	unsubscribe()
+
+	select {
+	case <-published:
+	case <-time.After(time.Second):
+		t.Fatal("Publish still blocked after the subscriber left")
+	}
+}
+
+func TestFeedClose(t *testing.T) {
+	f := NewFeed[StockEvent]()
+	a, unsubscribeA := f.Subscribe(1, Block)
+	b, _ := f.Subscribe(1, DropOldest)
+
+	f.Close()
+	f.Close()
+	unsubscribeA()
+	publishItems(f, "after close")
+
+	for _, ch := range []<-chan StockEvent{a, b} {
+		if _, ok := <-ch; ok {
+			t.Fatal("channel open after Close")
+		}
+	}
+	late, unsubscribe := f.Subscribe(1, Block)
+	if _, ok := <-late; ok {
+		t.Fatal("Subscribe after Close returned an open channel")
+	}
+	unsubscribe()
+}
+
+func TestFeedCloseReleasesBlockedPublisherWithWriterQueued(t *testing.T) {
+	f := NewFeed[StockEvent]()
+	f.Subscribe(0, Block)
+
+	go publishItems(f, "stuck")
+	time.Sleep(10 * time.Millisecond)
+	// queues for the write lock behind the blocked publisher's read lock
+	go f.Subscribe(0, Block)
+	time.Sleep(10 * time.Millisecond)
+
+	closed := make(chan struct{})
+	go func() {
+		f.Close()
+		close(closed)
+	}()
+	select {
+	case <-closed:
+	case <-time.After(time.Second):
+		t.Fatal("Close deadlocked")
+	}
+}
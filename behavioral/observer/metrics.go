@@ -0,0 +1,115 @@
+package observer
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// SubscriberInfo describes one subscription. LastError is the most recent
+// failed attempt, even if a retry later succeeded.
+type SubscriberInfo struct {
+	ID        int
+	Priority  int
+	Group     int
+	Filtered  bool
+	Delivered int64
+	Failed    int64
+	LastError error
+}
+
+// Metrics is a point-in-time copy of a topic's counters. Published is keyed
+// by the dynamic type of the event, which matters when E is an interface.
+type Metrics struct {
+	Published   map[string]int64
+	Delivered   int64
+	Failed      int64
+	Subscribers int
+}
+
+// subscriberStats are updated by whichever goroutine delivers, so they are
+// atomics or guarded by their own lock rather than by the topic's.
+type subscriberStats struct {
+	delivered atomic.Int64
+	failed    atomic.Int64
+
+	mu      sync.Mutex
+	lastErr error
+}
+
+type topicStats struct {
+	mu        sync.Mutex
+	published map[string]int64
+	delivered atomic.Int64
+	failed    atomic.Int64
+}
+
+// Subscribers describes the live subscriptions in delivery order. The list
+// is copied under the topic lock and the counters are read atomically, so a
+// concurrent publish may be reflected for some subscribers and not others.
+func (t *Topic[E]) Subscribers() []SubscriberInfo {
+	t.mu.Lock()
+	subscribers := make([]*subscriber[E], len(t.subscribers))
+	copy(subscribers, t.subscribers)
+	t.mu.Unlock()
+
+	infos := make([]SubscriberInfo, 0, len(subscribers))
+	for _, sub := range subscribers {
+		sub.stats.mu.Lock()
+		lastErr := sub.stats.lastErr
+		sub.stats.mu.Unlock()
+		infos = append(infos, SubscriberInfo{
+			ID:        sub.id,
+			Priority:  sub.priority,
+			Group:     sub.group,
+			Filtered:  sub.filter != nil,
+			Delivered: sub.stats.delivered.Load(),
+			Failed:    sub.stats.failed.Load(),
+			LastError: lastErr,
+		})
+	}
+	return infos
+}
+
+// Metrics returns a copy of the topic-wide counters; the caller may keep or
+// modify the Published map.
+func (t *Topic[E]) Metrics() Metrics {
+	t.stats.mu.Lock()
+	published := make(map[string]int64, len(t.stats.published))
+	for kind, n := range t.stats.published {
+		published[kind] = n
+	}
+	t.stats.mu.Unlock()
+
+	return Metrics{
+		Published:   published,
+		Delivered:   t.stats.delivered.Load(),
+		Failed:      t.stats.failed.Load(),
+		Subscribers: t.SubscriberCount(),
+	}
+}
+
+func (t *Topic[E]) countPublished(e E) {
+	kind := fmt.Sprintf("%T", e)
+	t.stats.mu.Lock()
+	defer t.stats.mu.Unlock()
+	t.stats.published[kind]++
+}
+
+// countAttempt records the outcome of one delivery attempt.
+func (t *Topic[E]) countAttempt(sub *subscriber[E], err error) {
+	if err == nil {
+		sub.stats.delivered.Add(1)
+		t.stats.delivered.Add(1)
+		return
+	}
+	sub.stats.mu.Lock()
+	sub.stats.lastErr = err
+	sub.stats.mu.Unlock()
+}
+
+// countFailure records a delivery given up on.
+func (t *Topic[E]) countFailure(sub *subscriber[E]) {
+	sub.stats.failed.Add(1)
+	t.stats.failed.Add(1)
+}
@@ -0,0 +1,109 @@
+package observer
+
+import (
+	"errors"
+	"sync"
+	"testing"
+)
+
+type restocked struct{ Item string }
+
+type soldOut struct{ Item string }
+
+func TestMetricsCountAKnownMix(t *testing.T) {
+	topic := NewTopic[any]()
+	topic.Subscribe(func(any) {}, Priority(1))
+	topic.FilterSubscribe(func(e any) bool {
+		_, ok := e.(soldOut)
+		return ok
+	}, func(any) {})
+	boom := errors.New("mailbox full")
+	topic.SubscribeErr(func(e any) error {
+		if r, ok := e.(restocked); ok && r.Item == "hat" {
+			return boom
+		}
+		return nil
+	}, Priority(2))
+
+	for _, e := range []any{restocked{"shirt"}, restocked{"hat"}, soldOut{"shirt"}} {
+		topic.Publish(e)
+	}
+
+	m := topic.Metrics()
+	if m.Published["observer.restocked"] != 2 || m.Published["observer.soldOut"] != 1 || len(m.Published) != 2 {
+		t.Fatalf("Published = %v, want 2 restocked and 1 soldOut", m.Published)
+	}
+	// 3 unfiltered + 1 filtered + 2 of 3 for the failing one
+	if m.Delivered != 6 || m.Failed != 1 || m.Subscribers != 3 {
+		t.Fatalf("Metrics() = %+v, want 6 delivered, 1 failed, 3 subscribers", m)
+	}
+
+	infos := topic.Subscribers()
+	want := []SubscriberInfo{
+		{ID: 2, Priority: 0, Filtered: true, Delivered: 1},
+		{ID: 1, Priority: 1, Delivered: 3},
+		{ID: 3, Priority: 2, Delivered: 2, Failed: 1, LastError: boom},
+	}
+	if len(infos) != len(want) {
+		t.Fatalf("Subscribers() = %+v, want %+v", infos, want)
+	}
+	for i := range want {
+		if infos[i] != want[i] {
+			t.Fatalf("Subscribers()[%d] = %+v, want %+v", i, infos[i], want[i])
+		}
+	}
+}
+
+func TestMetricsSnapshotIsACopy(t *testing.T) {
+	topic := NewTopic[int]()
+	topic.Publish(1)
+	m := topic.Metrics()
+	m.Published["int"] = 100
+
+	if n := topic.Metrics().Published["int"]; n != 1 {
+		t.Fatalf("Published[int] = %d after editing a snapshot, want 1", n)
+	}
+}
+
+func TestMetricsReadDuringPublishing(t *testing.T) {
+	const publishers, events = 4, 200
+	topic := NewTopic[int](Async(4))
+	defer topic.Stop()
+	for i := 0; i < 3; i++ {
+		topic.Subscribe(func(int) {})
+	}
+
+	var wg sync.WaitGroup
+	for p := 0; p < publishers; p++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for v := 0; v < events; v++ {
+				topic.Publish(v)
+			}
+		}()
+	}
+	stop := make(chan struct{})
+	readDone := make(chan struct{})
+	go func() {
+		defer close(readDone)
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+				topic.Metrics()
+				topic.Subscribers()
+			}
+		}
+	}()
+	wg.Wait()
+	topic.Flush()
+	close(stop)
+	<-readDone
+
+	m := topic.Metrics()
+	if m.Published["int"] != publishers*events || m.Delivered != 3*publishers*events {
+		t.Fatalf("Metrics() = %+v, want %d published and %d delivered", m, publishers*events, 3*publishers*events)
+	}
+}
@@ -0,0 +1,121 @@
+package observer
+
+import "fmt"
+
+//Observer is a behavioral design pattern that lets you define a subscription mechanism to notify multiple objects about any events that happen to the object they’re observing.
+//The object that has some interesting state is often called subject, but since it’s also going to notify other objects about the changes to its state, we’ll call it publisher.
+//All other objects that want to track changes to the publisher’s state are called subscribers.
+//The Observer pattern suggests that you add a subscription mechanism to the publisher class so individual objects can subscribe to or unsubscribe from a stream of events coming from that publisher.
+
+//How to Implement
+//
+//Look over your business logic and try to break it down into two parts: the core functionality, independent from other code, will act as the publisher; the rest will turn into a set of subscriber classes.
+//
+//Declare the subscriber interface. At a bare minimum, it should declare a single update method.
+//
+//Declare the publisher interface and describe a pair of methods for adding a subscriber object to and removing it from the list.
+//Remember that publishers must work with subscribers only via the subscriber interface.
+//
+//Decide where to put the actual subscription list and the implementation of subscription methods.
+//Usually, this code looks the same for all types of publishers, so the obvious place to put it is in an abstract class derived directly from the publisher interface.
+//Concrete publishers extend that class, inheriting the subscription behavior.
+//
+//Create concrete publisher classes. Each time something important happens inside a publisher, it must notify all its subscribers.
+//
+//Implement the update notification methods in concrete subscriber classes. Most subscribers would need some context data about the event. It can be passed as an argument of the notification method.
+//
+//The client must create all necessary subscribers and register them with proper publishers.
+
+type Subject interface {
+	Register(observer Observer)
+	Deregister(observer Observer)
+	NotifyAll() error
+}
+
+type Observer interface {
+	Update(itemName string) error
+	GetID() string
+}
+
+// Item keeps its observers on a Topic, so the classic interface-based example
+// and the generic one share the same subscription list.
+type Item struct {
+	topic         *Topic[string]
+	subscriptions map[string]func()
+	name          string
+	inStock       bool
+}
+
+// NewItem accepts the Topic options, e.g. Retry for observers that fail transiently.
+func NewItem(name string, opts ...TopicOption) *Item {
+	return &Item{
+		topic:         NewTopic[string](opts...),
+		subscriptions: make(map[string]func()),
+		name:          name,
+	}
+}
+
+func (i *Item) UpdateAvailability() error {
+	fmt.Printf("Item %s is now in stock\n", i.name)
+	i.inStock = true
+	return i.NotifyAll()
+}
+
+func (i *Item) InStock() bool {
+	return i.inStock
+}
+
+// Register subscribes o. Registering an ID that is already subscribed does nothing.
+func (i *Item) Register(o Observer) {
+	if _, ok := i.subscriptions[o.GetID()]; ok {
+		return
+	}
+	id := o.GetID()
+	i.subscriptions[id] = i.topic.SubscribeErr(func(itemName string) error {
+		if err := o.Update(itemName); err != nil {
+			return fmt.Errorf("observer %s: %w", id, err)
+		}
+		return nil
+	})
+}
+
+// Deregister is safe to call from inside Update; o receives nothing further.
+func (i *Item) Deregister(o Observer) {
+	if unsubscribe, ok := i.subscriptions[o.GetID()]; ok {
+		unsubscribe()
+		delete(i.subscriptions, o.GetID())
+	}
+}
+
+// NotifyAll updates observers in the order they registered and returns the
+// updates that still failed after any retries.
+func (i *Item) NotifyAll() error {
+	return i.topic.NotifyAll(i.name)
+}
+
+type Customer struct {
+	id string
+}
+
+func NewCustomer(id string) *Customer {
+	return &Customer{
+		id: id,
+	}
+}
+
+func (c *Customer) Update(itemName string) error {
+	fmt.Printf("Sending email to customer %s for item %s\n", c.id, itemName)
+	return nil
+}
+
+func (c *Customer) GetID() string {
+	return c.id
+}
+
+//Pros and Cons
+//
+//Open/Closed Principle. You can introduce new subscriber classes without having to change the publisher’s code (and vice versa if there’s a publisher interface).
+//You can establish relations between objects at runtime.
+//
+//Subscribers are notified in random order.
+//In this implementation they are at least notified in registration order, but callers shouldn't rely on that across implementations.
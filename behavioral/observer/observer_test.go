@@ -0,0 +1,137 @@
+package observer
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+// recorder is an Observer that logs its ID into a shared list on every update.
+type recorder struct {
+	id   string
+	log  *[]string
+	fail error
+	// onUpdate, when set, runs in the middle of the notification.
+	onUpdate func()
+}
+
+func (r *recorder) Update(itemName string) error {
+	*r.log = append(*r.log, r.id+":"+itemName)
+	if r.onUpdate != nil {
+		r.onUpdate()
+	}
+	return r.fail
+}
+
+func (r *recorder) GetID() string {
+	return r.id
+}
+
+func TestItemNotifiesInRegistrationOrder(t *testing.T) {
+	var log []string
+	item := NewItem("Nike Shirt")
+	for _, id := range []string{"c", "a", "b"} {
+		item.Register(&recorder{id: id, log: &log})
+	}
+
+	if err := item.UpdateAvailability(); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"c:Nike Shirt", "a:Nike Shirt", "b:Nike Shirt"}
+	if !slices.Equal(log, want) {
+		t.Fatalf("notified %v, want %v", log, want)
+	}
+	if !item.InStock() {
+		t.Fatal("InStock() = false after UpdateAvailability")
+	}
+}
+
+func TestItemWithoutObservers(t *testing.T) {
+	item := NewItem("Nike Shirt")
+	if err := item.NotifyAll(); err != nil {
+		t.Fatalf("NotifyAll() with no observers = %v", err)
+	}
+}
+
+func TestItemRegisterTwiceNotifiesOnce(t *testing.T) {
+	var log []string
+	item := NewItem("hat")
+	o := &recorder{id: "a", log: &log}
+	item.Register(o)
+	item.Register(o)
+
+	if err := item.NotifyAll(); err != nil {
+		t.Fatal(err)
+	}
+	if len(log) != 1 {
+		t.Fatalf("notified %v, want a single update", log)
+	}
+}
+
+func TestItemDeregister(t *testing.T) {
+	var log []string
+	item := NewItem("hat")
+	a, b := &recorder{id: "a", log: &log}, &recorder{id: "b", log: &log}
+	item.Register(a)
+	item.Register(b)
+	if err := item.NotifyAll(); err != nil {
+		t.Fatal(err)
+	}
+
+	item.Deregister(a)
+	item.Deregister(a)
+	if err := item.NotifyAll(); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"a:hat", "b:hat", "b:hat"}
+	if !slices.Equal(log, want) {
+		t.Fatalf("notified %v, want %v", log, want)
+	}
+}
+
+func TestItemDeregisterDuringNotify(t *testing.T) {
+	var log []string
+	item := NewItem("hat")
+	b := &recorder{id: "b", log: &log}
+	a := &recorder{id: "a", log: &log, onUpdate: func() {
+		item.Deregister(b)
+	}}
+	c := &recorder{id: "c", log: &log}
+	item.Register(a)
+	item.Register(b)
+	item.Register(c)
+
+	if err := item.NotifyAll(); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"a:hat", "c:hat"}
+	if !slices.Equal(log, want) {
+		t.Fatalf("notified %v, want %v", log, want)
+	}
+}
+
+func TestItemNotifyAllReportsFailures(t *testing.T) {
+	var log []string
+	item := NewItem("hat")
+	boom := errors.New("mailbox full")
+	item.Register(&recorder{id: "a", log: &log, fail: boom})
+	item.Register(&recorder{id: "b", log: &log})
+
+	err := item.NotifyAll()
+	if !errors.Is(err, boom) {
+		t.Fatalf("NotifyAll() = %v, want it to wrap %v", err, boom)
+	}
+	if len(log) != 2 {
+		t.Fatalf("notified %v, want the healthy observer notified too", log)
+	}
+}
+
+func TestCustomer(t *testing.T) {
+	c := NewCustomer("abc@gmail.com")
+	if c.GetID() != "abc@gmail.com" {
+		t.Fatalf("GetID() = %q", c.GetID())
+	}
+	if err := c.Update("hat"); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,59 @@
+package observer
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/Antonious-Stewart/15-Most-Common-Design-Patterns/creational/factoryMethod"
+)
+
+// The phone example: products from the factoryMethod package publish their
+// events through a Topic[factoryMethod.PhoneEvent] passed with
+// factoryMethod.WithPublisher, which is a second event type running on the
+// same implementation as the stock example.
+
+// LoggingObserver writes one line per phone event.
+type LoggingObserver struct {
+	w io.Writer
+}
+
+func NewLoggingObserver(w io.Writer) *LoggingObserver {
+	return &LoggingObserver{
+		w: w,
+	}
+}
+
+func (l *LoggingObserver) Notify(e factoryMethod.PhoneEvent) {
+	fmt.Fprintf(l.w, "%s: %s phone status=%s battery=%d%%\n", e.Kind, e.OS, e.Status, e.Battery)
+}
+
+// ChargeReminderObserver tops the phone up whenever it reports BatteryLow.
+// It charges from inside the notification, which works because phones
+// publish after updating their state and Topic delivers without holding a
+// lock. Subscribe it to a synchronous Topic only: phones aren't safe for
+// concurrent use, and an Async worker would charge the phone while the
+// goroutine that drained it may still be using it.
+type ChargeReminderObserver struct {
+	percent int
+	charged int
+}
+
+func NewChargeReminderObserver(percent int) *ChargeReminderObserver {
+	return &ChargeReminderObserver{
+		percent: percent,
+	}
+}
+
+func (c *ChargeReminderObserver) Notify(e factoryMethod.PhoneEvent) {
+	if e.Kind != factoryMethod.BatteryLow || e.Phone == nil {
+		return
+	}
+	if err := e.Phone.Charge(c.percent); err == nil {
+		c.charged++
+	}
+}
+
+// Charged reports how many times the observer has charged a phone.
+func (c *ChargeReminderObserver) Charged() int {
+	return c.charged
+}
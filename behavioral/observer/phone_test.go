@@ -0,0 +1,77 @@
+package observer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Antonious-Stewart/15-Most-Common-Design-Patterns/creational/factoryMethod"
+)
+
+func TestLoggingObserverLogsPhoneEvents(t *testing.T) {
+	topic := NewTopic[factoryMethod.PhoneEvent]()
+	var log strings.Builder
+	topic.Subscribe(NewLoggingObserver(&log).Notify)
+
+	phone := factoryMethod.NewAndroid(factoryMethod.WithPublisher(topic))
+	phone.TurnOn()
+	phone.TurnOn()
+	if err := phone.Drain(85); err != nil {
+		t.Fatal(err)
+	}
+	phone.TurnOff()
+
+	want := "StatusChanged: android phone status=on battery=100%\n" +
+		"BatteryLow: android phone status=on battery=15%\n" +
+		"StatusChanged: android phone status=off battery=15%\n"
+	if log.String() != want {
+		t.Fatalf("log =\n%s\nwant\n%s", log.String(), want)
+	}
+}
+
+func TestChargeReminderChargesFromInsideNotification(t *testing.T) {
+	topic := NewTopic[factoryMethod.PhoneEvent]()
+	reminder := NewChargeReminderObserver(50)
+	topic.Subscribe(reminder.Notify)
+	var log strings.Builder
+	topic.Subscribe(NewLoggingObserver(&log).Notify)
+
+	phone := factoryMethod.NewGoogle(factoryMethod.WithPublisher(topic))
+	if err := phone.Drain(85); err != nil {
+		t.Fatal(err)
+	}
+
+	if reminder.Charged() != 1 {
+		t.Fatalf("Charged() = %d, want 1", reminder.Charged())
+	}
+	if phone.BatteryLevel() != 65 {
+		t.Fatalf("BatteryLevel() = %d, want 65 after the re-entrant charge", phone.BatteryLevel())
+	}
+	// the logger runs after the charge but reports the level the event carried
+	if !strings.Contains(log.String(), "battery=15%") {
+		t.Fatalf("log = %q, want the drained level", log.String())
+	}
+
+	// above the low range again, so the next crossing reminds again
+	if err := phone.Drain(50); err != nil {
+		t.Fatal(err)
+	}
+	if reminder.Charged() != 2 || phone.BatteryLevel() != 65 {
+		t.Fatalf("Charged() = %d at %d%%, want 2 at 65%%", reminder.Charged(), phone.BatteryLevel())
+	}
+}
+
+func TestChargeReminderIgnoresOtherEvents(t *testing.T) {
+	topic := NewTopic[factoryMethod.PhoneEvent]()
+	reminder := NewChargeReminderObserver(50)
+	topic.Subscribe(reminder.Notify)
+
+	phone := factoryMethod.NewAndroid(factoryMethod.WithPublisher(topic))
+	phone.TurnOn()
+	phone.TurnOff()
+	if err := phone.Drain(10); err != nil {
+		t.Fatal(err)
+	}
+	if reminder.Charged() != 0 {
+		t.Fatalf("Charged() = %d without a BatteryLow event", reminder.Charged())
+	}
+}
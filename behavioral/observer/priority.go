@@ -0,0 +1,54 @@
+package observer
+
+// Priority orders observers within a delivery group: lower values are
+// notified first and equal priorities keep subscription order. In async mode
+// a group's observers run concurrently, so priority only sets the order they
+// are handed to workers; use separate groups when the order must be strict.
+func Priority(p int) SubscribeOption {
+	return func(c *subscribeConfig) {
+		c.priority = p
+	}
+}
+
+// Group puts the observer in delivery group k. All observers of a group,
+// retries included, finish handling an event before the next group starts,
+// in async mode as well. Lower groups go first; the default group is 0.
+func Group(k int) SubscribeOption {
+	return func(c *subscribeConfig) {
+		c.group = k
+	}
+}
+
+func (s *subscriber[E]) before(other *subscriber[E]) bool {
+	if s.group != other.group {
+		return s.group < other.group
+	}
+	return s.priority < other.priority
+}
+
+// insert keeps t.subscribers sorted, placing sub after everything that
+// doesn't sort strictly after it. Callers hold t.mu.
+func (t *Topic[E]) insert(sub *subscriber[E]) {
+	i := len(t.subscribers)
+	for i > 0 && sub.before(t.subscribers[i-1]) {
+		i--
+	}
+	subscribers := make([]*subscriber[E], 0, len(t.subscribers)+1)
+	subscribers = append(subscribers, t.subscribers[:i]...)
+	subscribers = append(subscribers, sub)
+	subscribers = append(subscribers, t.subscribers[i:]...)
+	t.subscribers = subscribers
+}
+
+// splitGroups cuts a sorted subscriber list at every change of group.
+func splitGroups[E any](subscribers []*subscriber[E]) [][]*subscriber[E] {
+	groups := make([][]*subscriber[E], 0)
+	for i, sub := range subscribers {
+		if i == 0 || sub.group != subscribers[i-1].group {
+			groups = append(groups, make([]*subscriber[E], 0))
+		}
+		last := len(groups) - 1
+		groups[last] = append(groups[last], sub)
+	}
+	return groups
+}
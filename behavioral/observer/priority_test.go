@@ -0,0 +1,134 @@
+package observer
+
+import (
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+type orderLog struct {
+	mu    sync.Mutex
+	names []string
+}
+
+func (l *orderLog) record(name string, work time.Duration) func(int) {
+	return func(int) {
+		time.Sleep(work)
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.names = append(l.names, name)
+	}
+}
+
+func (l *orderLog) take() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	names := l.names
+	l.names = nil
+	return names
+}
+
+func TestPriorityOrdersSyncDelivery(t *testing.T) {
+	topic := NewTopic[int]()
+	log := &orderLog{}
+	topic.Subscribe(log.record("metrics", 0), Priority(3))
+	topic.Subscribe(log.record("log-1", 0), Priority(2))
+	topic.Subscribe(log.record("validate", 0), Priority(1))
+	topic.Subscribe(log.record("log-2", 0), Priority(2))
+
+	topic.Publish(1)
+	want := []string{"validate", "log-1", "log-2", "metrics"}
+	if got := log.take(); !slices.Equal(got, want) {
+		t.Fatalf("delivered %v, want %v", got, want)
+	}
+}
+
+func TestGroupsRunInOrder(t *testing.T) {
+	for _, mode := range []struct {
+		name string
+		opts []TopicOption
+	}{
+		{"sync", nil},
+		{"async", []TopicOption{Async(4)}},
+	} {
+		t.Run(mode.name, func(t *testing.T) {
+			topic := NewTopic[int](mode.opts...)
+			defer topic.Stop()
+			log := &orderLog{}
+			// slower observers in earlier groups would lose a race if groups overlapped
+			topic.Subscribe(log.record("metrics", 0), Group(2))
+			topic.Subscribe(log.record("log-a", 5*time.Millisecond), Group(1))
+			topic.Subscribe(log.record("log-b", 0), Group(1))
+			topic.Subscribe(log.record("validate-a", 10*time.Millisecond), Group(0))
+			topic.Subscribe(log.record("validate-b", 5*time.Millisecond), Group(0))
+
+			for v := 0; v < 3; v++ {
+				topic.Publish(v)
+				topic.Flush()
+				got := log.take()
+				if len(got) != 5 {
+					t.Fatalf("delivered %v, want five observers", got)
+				}
+				validate, logs := got[:2], got[2:4]
+				slices.Sort(validate)
+				slices.Sort(logs)
+				if !slices.Equal(validate, []string{"validate-a", "validate-b"}) ||
+					!slices.Equal(logs, []string{"log-a", "log-b"}) || got[4] != "metrics" {
+					t.Fatalf("delivered %v, want group 0, then 1, then 2", got)
+				}
+			}
+		})
+	}
+}
+
+func TestGroupsHoldAcrossQueuedEventsAsync(t *testing.T) {
+	topic := NewTopic[int](Async(4))
+	defer topic.Stop()
+	var mu sync.Mutex
+	var seen []string
+	record := func(name string) func(int) {
+		return func(v int) {
+			mu.Lock()
+			defer mu.Unlock()
+			seen = append(seen, name)
+		}
+	}
+	topic.Subscribe(record("second"), Group(1))
+	topic.Subscribe(func(v int) {
+		time.Sleep(time.Millisecond)
+		record("first")(v)
+	}, Group(0))
+
+	for v := 0; v < 20; v++ {
+		topic.Publish(v)
+	}
+	topic.Flush()
+
+	// each event's group 1 delivery waits for its group 0 one
+	firsts := 0
+	for _, name := range seen {
+		if name == "first" {
+			firsts++
+		} else if firsts == 0 {
+			t.Fatal("group 1 ran before group 0 handled any event")
+		}
+	}
+	if len(seen) != 40 {
+		t.Fatalf("delivered %d, want 40", len(seen))
+	}
+}
+
+func TestEqualPrioritiesKeepSubscriptionOrder(t *testing.T) {
+	topic := NewTopic[int]()
+	log := &orderLog{}
+	for _, name := range []string{"a", "b", "c", "d"} {
+		topic.Subscribe(log.record(name, 0), Priority(1), Group(1))
+	}
+	topic.Subscribe(log.record("early", 0), Priority(0), Group(1))
+
+	topic.Publish(1)
+	if got, want := log.take(), []string{"early", "a", "b", "c", "d"}; !slices.Equal(got, want) {
+		t.Fatalf("delivered %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,88 @@
+package observer
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+type retryPolicy struct {
+	attempts   int
+	backoff    time.Duration
+	maxBackoff time.Duration
+	sleep      func(time.Duration)
+}
+
+// delay doubles the backoff after every failed attempt, up to maxBackoff.
+func (r retryPolicy) delay(failures int) time.Duration {
+	d := r.backoff
+	for i := 1; i < failures && (r.maxBackoff <= 0 || d < r.maxBackoff); i++ {
+		d *= 2
+	}
+	if r.maxBackoff > 0 && d > r.maxBackoff {
+		d = r.maxBackoff
+	}
+	return d
+}
+
+// Retry gives every failing delivery up to attempts tries in total, waiting
+// backoff after the first failure and doubling it each time, capped at maxBackoff.
+func Retry(attempts int, backoff, maxBackoff time.Duration) TopicOption {
+	return func(c *topicConfig) {
+		c.retry.attempts = max(attempts, 1)
+		c.retry.backoff = backoff
+		c.retry.maxBackoff = maxBackoff
+	}
+}
+
+// WithSleeper replaces time.Sleep between retries, so tests don't have to wait.
+func WithSleeper(sleep func(time.Duration)) TopicOption {
+	return func(c *topicConfig) {
+		if sleep != nil {
+			c.retry.sleep = sleep
+		}
+	}
+}
+
+// DeadLetter is a delivery that failed on its last allowed attempt.
+type DeadLetter[E any] struct {
+	Event      E
+	Subscriber int
+	Attempts   int
+	Err        error
+}
+
+type DeliveryError struct {
+	Subscriber int
+	Attempts   int
+	Err        error
+}
+
+func (e *DeliveryError) Error() string {
+	return fmt.Sprintf("observer: subscriber %d failed after %d attempt(s): %v", e.Subscriber, e.Attempts, e.Err)
+}
+
+func (e *DeliveryError) Unwrap() error {
+	return e.Err
+}
+
+type failure[E any] struct {
+	sub      *subscriber[E]
+	attempts int
+	err      error
+}
+
+// retryable is false for panics: an observer that blew up is not retried.
+func retryable(err error) bool {
+	var perr *PanicError
+	return !errors.As(err, &perr)
+}
+
+func hasRetryable[E any](failed []*failure[E]) bool {
+	for _, f := range failed {
+		if f.err != nil && retryable(f.err) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,161 @@
+package observer
+
+import (
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+// flaky fails its first failures calls, then succeeds.
+func flaky(calls *int, failures int) func(int) error {
+	return func(int) error {
+		*calls++
+		if *calls <= failures {
+			return errors.New("transient")
+		}
+		return nil
+	}
+}
+
+func TestRetryRecoversTransientFailures(t *testing.T) {
+	var sleeps []time.Duration
+	topic := NewTopic[int](
+		Retry(4, time.Millisecond, 3*time.Millisecond),
+		WithSleeper(func(d time.Duration) {
+			sleeps = append(sleeps, d)
+		}),
+	)
+	var calls int
+	topic.SubscribeErr(flaky(&calls, 2))
+
+	if err := topic.NotifyAll(1); err != nil {
+		t.Fatalf("NotifyAll() = %v, want the retries to succeed", err)
+	}
+	if calls != 3 {
+		t.Fatalf("observer called %d times, want 3", calls)
+	}
+	if want := []time.Duration{time.Millisecond, 2 * time.Millisecond}; !slices.Equal(sleeps, want) {
+		t.Fatalf("slept %v, want %v", sleeps, want)
+	}
+}
+
+func TestRetryBackoffIsCapped(t *testing.T) {
+	policy := retryPolicy{backoff: time.Millisecond, maxBackoff: 5 * time.Millisecond}
+	var got []time.Duration
+	for failures := 1; failures <= 5; failures++ {
+		got = append(got, policy.delay(failures))
+	}
+	want := []time.Duration{1, 2, 4, 5, 5}
+	for i := range want {
+		want[i] *= time.Millisecond
+	}
+	if !slices.Equal(got, want) {
+		t.Fatalf("delays = %v, want %v", got, want)
+	}
+}
+
+func TestPermanentFailureIsDeadLettered(t *testing.T) {
+	topic := NewTopic[int](Retry(3, 0, 0), WithSleeper(func(time.Duration) {}))
+	var dead []DeadLetter[int]
+	topic.OnDeadLetter(func(d DeadLetter[int]) {
+		dead = append(dead, d)
+	})
+
+	boom := errors.New("mailbox gone")
+	var brokenCalls, healthyCalls, transientCalls int
+	topic.SubscribeErr(func(int) error {
+		brokenCalls++
+		return boom
+	})
+	topic.Subscribe(func(int) {
+		healthyCalls++
+	})
+	topic.SubscribeErr(flaky(&transientCalls, 1))
+
+	err := topic.NotifyAll(42)
+
+	var delivery *DeliveryError
+	if !errors.As(err, &delivery) || !errors.Is(err, boom) {
+		t.Fatalf("NotifyAll() = %v, want a *DeliveryError wrapping %v", err, boom)
+	}
+	if delivery.Subscriber != 1 || delivery.Attempts != 3 {
+		t.Fatalf("DeliveryError = %+v, want subscriber 1 after 3 attempts", delivery)
+	}
+	if brokenCalls != 3 || healthyCalls != 1 || transientCalls != 2 {
+		t.Fatalf("calls = %d broken, %d healthy, %d transient; want 3, 1, 2", brokenCalls, healthyCalls, transientCalls)
+	}
+	if len(dead) != 1 {
+		t.Fatalf("dead letters = %+v, want one", dead)
+	}
+	if d := dead[0]; d.Event != 42 || d.Subscriber != 1 || d.Attempts != 3 || d.Err != boom {
+		t.Fatalf("dead letter = %+v", d)
+	}
+}
+
+func TestNotifyAllJoinsEveryPermanentFailure(t *testing.T) {
+	topic := NewTopic[int]()
+	first, second := errors.New("first"), errors.New("second")
+	topic.SubscribeErr(func(int) error { return first })
+	topic.SubscribeErr(func(int) error { return second })
+
+	err := topic.NotifyAll(1)
+	if !errors.Is(err, first) || !errors.Is(err, second) {
+		t.Fatalf("NotifyAll() = %v, want both failures", err)
+	}
+}
+
+func TestPanicsAreNotRetried(t *testing.T) {
+	topic := NewTopic[int](Retry(5, 0, 0), WithSleeper(func(time.Duration) {}), OnError(func(error) {}))
+	var calls int
+	topic.SubscribeErr(func(int) error {
+		calls++
+		panic("boom")
+	})
+
+	err := topic.NotifyAll(1)
+	var perr *PanicError
+	if !errors.As(err, &perr) || calls != 1 {
+		t.Fatalf("NotifyAll() = %v after %d calls, want one unretried panic", err, calls)
+	}
+}
+
+func TestAsyncRetriesDoNotHoldUpHealthyObservers(t *testing.T) {
+	release := make(chan struct{})
+	topic := NewTopic[int](Async(2), Retry(2, 0, 0), WithSleeper(func(time.Duration) {
+		<-release
+	}))
+	defer topic.Stop()
+
+	var mu sync.Mutex
+	var dead []DeadLetter[int]
+	topic.OnDeadLetter(func(d DeadLetter[int]) {
+		mu.Lock()
+		defer mu.Unlock()
+		dead = append(dead, d)
+	})
+	topic.SubscribeErr(func(int) error {
+		return errors.New("down")
+	})
+	healthy := make(chan int, 1)
+	topic.Subscribe(func(v int) {
+		healthy <- v
+	})
+
+	topic.Publish(7)
+	select {
+	case v := <-healthy:
+		if v != 7 {
+			t.Fatalf("healthy observer got %d", v)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("healthy observer waited for the failing one's backoff")
+	}
+	close(release)
+	topic.Flush()
+
+	if len(dead) != 1 || dead[0].Attempts != 2 {
+		t.Fatalf("dead letters = %+v, want one after 2 attempts", dead)
+	}
+}
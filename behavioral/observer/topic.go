@@ -0,0 +1,369 @@
+package observer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Topic is the generic counterpart of Subject: one subscription list that
+// works for any event type without interface{} casts.
+type Topic[E any] struct {
+	mu          sync.Mutex
+	subscribers []*subscriber[E]
+	nextID      int
+	onError     func(error)
+	deadLetter  func(DeadLetter[E])
+	retry       retryPolicy
+	pool        *pool[E]
+	stats       topicStats
+}
+
+type subscriber[E any] struct {
+	id       int
+	fn       func(E) error
+	filter   func(E) bool
+	priority int
+	group    int
+	active   atomic.Bool
+	// limited subscribers expire once remaining drops to zero
+	limited   bool
+	remaining atomic.Int64
+	// ctx is checked on every claim as well, since stopCtx fires asynchronously.
+	ctx     context.Context
+	stopCtx func() bool
+	stats   subscriberStats
+
+	// mailbox holds events waiting for an async worker, oldest first.
+	mu        sync.Mutex
+	mailbox   []delivery[E]
+	scheduled bool
+}
+
+// PanicError reports an observer that panicked while handling an event.
+// The panic is contained so the publisher and other observers carry on.
+type PanicError struct {
+	Subscriber int
+	Value      any
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("observer: subscriber %d panicked: %v", e.Subscriber, e.Value)
+}
+
+type topicConfig struct {
+	workers int
+	onError func(error)
+	retry   retryPolicy
+}
+
+type TopicOption func(c *topicConfig)
+
+// Async hands notifications to a pool of workers instead of running them on
+// the publisher's goroutine. Each observer still sees events in publish order.
+func Async(workers int) TopicOption {
+	return func(c *topicConfig) {
+		c.workers = workers
+	}
+}
+
+// OnError receives the failures observers can't report themselves, such as
+// panics. Without it they are logged.
+func OnError(fn func(error)) TopicOption {
+	return func(c *topicConfig) {
+		if fn != nil {
+			c.onError = fn
+		}
+	}
+}
+
+func NewTopic[E any](opts ...TopicOption) *Topic[E] {
+	cfg := topicConfig{
+		onError: func(err error) {
+			log.Println(err)
+		},
+		retry: retryPolicy{
+			attempts: 1,
+			sleep:    time.Sleep,
+		},
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	t := &Topic[E]{
+		subscribers: make([]*subscriber[E], 0),
+		onError:     cfg.onError,
+		retry:       cfg.retry,
+		stats: topicStats{
+			published: make(map[string]int64),
+		},
+	}
+	if cfg.workers > 0 {
+		t.pool = newPool(t, cfg.workers)
+	}
+	return t
+}
+
+// Subscribe calls fn for every published event until the returned func is
+// called or one of opts expires the subscription.
+func (t *Topic[E]) Subscribe(fn func(E), opts ...SubscribeOption) (unsubscribe func()) {
+	return t.subscribe(nil, ignoreError(fn), opts)
+}
+
+// FilterSubscribe calls fn only for events pred accepts. A nil pred accepts everything.
+// Filtered-out events don't count towards Once or Times.
+func (t *Topic[E]) FilterSubscribe(pred func(E) bool, fn func(E), opts ...SubscribeOption) (unsubscribe func()) {
+	return t.subscribe(pred, ignoreError(fn), opts)
+}
+
+// SubscribeErr subscribes an observer that can fail. Failed deliveries are
+// retried according to the topic's Retry policy, then dead-lettered.
+func (t *Topic[E]) SubscribeErr(fn func(E) error, opts ...SubscribeOption) (unsubscribe func()) {
+	return t.subscribe(nil, fn, opts)
+}
+
+func (t *Topic[E]) subscribe(pred func(E) bool, fn func(E) error, opts []SubscribeOption) (unsubscribe func()) {
+	cfg := subscribeConfig{}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.limited && cfg.limit <= 0 || cfg.ctx != nil && cfg.ctx.Err() != nil {
+		return func() {}
+	}
+
+	sub := &subscriber[E]{
+		fn:       fn,
+		filter:   pred,
+		priority: cfg.priority,
+		group:    cfg.group,
+		limited:  cfg.limited,
+		ctx:      cfg.ctx,
+		mailbox:  make([]delivery[E], 0),
+	}
+	sub.remaining.Store(int64(cfg.limit))
+	sub.active.Store(true)
+
+	t.mu.Lock()
+	t.nextID++
+	sub.id = t.nextID
+	t.insert(sub)
+	if cfg.ctx != nil {
+		sub.stopCtx = context.AfterFunc(cfg.ctx, func() {
+			t.remove(sub)
+		})
+	}
+	t.mu.Unlock()
+
+	return func() {
+		t.remove(sub)
+	}
+}
+
+// SubscriberCount reports how many subscriptions are live. Expired ones are
+// removed from the list, not just skipped.
+func (t *Topic[E]) SubscriberCount() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return len(t.subscribers)
+}
+
+// Publish delivers e to subscribers by group, then priority, then
+// subscription order; see Group and Priority. The list is copied
+// before delivery, so subscribers may subscribe, unsubscribe or publish from
+// inside their callback; someone unsubscribed mid-publish gets nothing further.
+// In async mode Publish only queues the event; see Flush.
+func (t *Topic[E]) Publish(e E) {
+	_ = t.NotifyAll(e)
+}
+
+// NotifyAll is Publish reporting the deliveries that failed for good, joined
+// into one error of *DeliveryError values. Every observer gets its first
+// attempt before any failed one is retried, so a failing observer doesn't
+// hold up healthy ones. In async mode failures only reach the dead-letter
+// callback and NotifyAll returns nil.
+func (t *Topic[E]) NotifyAll(e E) error {
+	t.countPublished(e)
+	groups := splitGroups(t.matching(e))
+	if t.pool != nil {
+		t.pool.publish(e, groups)
+		return nil
+	}
+
+	errs := make([]error, 0)
+	for _, group := range groups {
+		errs = append(errs, t.notifyGroup(group, e)...)
+	}
+	return errors.Join(errs...)
+}
+
+// notifyGroup delivers e to one delivery group, retries included, and
+// returns its permanent failures.
+func (t *Topic[E]) notifyGroup(subscribers []*subscriber[E], e E) []error {
+	failed := make([]*failure[E], 0)
+	for _, sub := range subscribers {
+		if !t.claim(sub) {
+			continue
+		}
+		if err := t.attempt(sub, e); err != nil {
+			failed = append(failed, &failure[E]{sub: sub, attempts: 1, err: err})
+		}
+	}
+	for attempt := 2; attempt <= t.retry.attempts && hasRetryable(failed); attempt++ {
+		t.retry.sleep(t.retry.delay(attempt - 1))
+		for _, f := range failed {
+			if f.err == nil || !retryable(f.err) {
+				continue
+			}
+			f.attempts++
+			f.err = t.attempt(f.sub, e)
+		}
+	}
+
+	errs := make([]error, 0)
+	for _, f := range failed {
+		if f.err != nil {
+			errs = append(errs, t.giveUp(f.sub, e, f.attempts, f.err))
+		}
+	}
+	return errs
+}
+
+// OnDeadLetter receives every delivery that still failed after its retries.
+func (t *Topic[E]) OnDeadLetter(fn func(DeadLetter[E])) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.deadLetter = fn
+}
+
+// Flush blocks until every queued notification has been handled. It returns
+// at once for a synchronous topic. Calling it from inside an async observer deadlocks.
+func (t *Topic[E]) Flush() {
+	if t.pool != nil {
+		t.pool.flush()
+	}
+}
+
+// Stop drains the queue and shuts the workers down. Events published after
+// Stop are dropped. It is a no-op for a synchronous topic.
+func (t *Topic[E]) Stop() {
+	if t.pool != nil {
+		t.pool.stop()
+	}
+}
+
+// matching copies the live subscribers that accept e.
+func (t *Topic[E]) matching(e E) []*subscriber[E] {
+	t.mu.Lock()
+	subscribers := make([]*subscriber[E], len(t.subscribers))
+	copy(subscribers, t.subscribers)
+	t.mu.Unlock()
+
+	matched := subscribers[:0]
+	for _, sub := range subscribers {
+		if sub.filter == nil || sub.filter(e) {
+			matched = append(matched, sub)
+		}
+	}
+	return matched
+}
+
+// deliver runs one async delivery, retrying inline; only this subscriber's
+// mailbox waits while it backs off.
+func (t *Topic[E]) deliver(sub *subscriber[E], e E) {
+	if !t.claim(sub) {
+		return
+	}
+	attempts := 1
+	err := t.attempt(sub, e)
+	for err != nil && retryable(err) && attempts < t.retry.attempts {
+		t.retry.sleep(t.retry.delay(attempts))
+		attempts++
+		err = t.attempt(sub, e)
+	}
+	if err != nil {
+		t.giveUp(sub, e, attempts, err)
+	}
+}
+
+// claim reports whether sub may receive another event, counting it against
+// Once or Times. Claiming before running means concurrent publishers can
+// never hand out more than the limit.
+func (t *Topic[E]) claim(sub *subscriber[E]) bool {
+	if !sub.active.Load() {
+		return false
+	}
+	if sub.ctx != nil && sub.ctx.Err() != nil {
+		t.remove(sub)
+		return false
+	}
+	if sub.limited {
+		left := sub.remaining.Add(-1)
+		if left < 0 {
+			return false
+		}
+		if left == 0 {
+			t.remove(sub)
+		}
+	}
+	return true
+}
+
+func (t *Topic[E]) attempt(sub *subscriber[E], e E) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			perr := &PanicError{Subscriber: sub.id, Value: r}
+			t.onError(perr)
+			err = perr
+		}
+		t.countAttempt(sub, err)
+	}()
+	return sub.fn(e)
+}
+
+func (t *Topic[E]) giveUp(sub *subscriber[E], e E, attempts int, err error) error {
+	t.countFailure(sub)
+	t.mu.Lock()
+	deadLetter := t.deadLetter
+	t.mu.Unlock()
+	if deadLetter != nil {
+		deadLetter(DeadLetter[E]{
+			Event:      e,
+			Subscriber: sub.id,
+			Attempts:   attempts,
+			Err:        err,
+		})
+	}
+	return &DeliveryError{
+		Subscriber: sub.id,
+		Attempts:   attempts,
+		Err:        err,
+	}
+}
+
+func ignoreError[E any](fn func(E)) func(E) error {
+	return func(e E) error {
+		fn(e)
+		return nil
+	}
+}
+
+func (t *Topic[E]) remove(sub *subscriber[E]) {
+	if !sub.active.Swap(false) {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if sub.stopCtx != nil {
+		sub.stopCtx()
+	}
+	for i, s := range t.subscribers {
+		if s == sub {
+			t.subscribers = append(t.subscribers[:i:i], t.subscribers[i+1:]...)
+			return
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package observer
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+type priceChanged struct {
+	Item  string
+	Price int
+}
+
+func TestTopicsOverDistinctEventTypes(t *testing.T) {
+	stock := NewTopic[StockEvent]()
+	prices := NewTopic[priceChanged]()
+
+	var restocked []string
+	var total int
+	stock.Subscribe(func(e StockEvent) {
+		restocked = append(restocked, e.Item)
+	})
+	prices.Subscribe(func(e priceChanged) {
+		total += e.Price
+	})
+
+	stock.Publish(StockEvent{Item: "shirt", InStock: true})
+	prices.Publish(priceChanged{Item: "shirt", Price: 20})
+	prices.Publish(priceChanged{Item: "hat", Price: 5})
+
+	if !slices.Equal(restocked, []string{"shirt"}) {
+		t.Fatalf("stock subscriber got %v, want [shirt]", restocked)
+	}
+	if total != 25 {
+		t.Fatalf("price subscriber summed %d, want 25", total)
+	}
+}
+
+func TestTopicFilterSubscribe(t *testing.T) {
+	topic := NewTopic[StockEvent]()
+	var inStock, all []string
+	topic.FilterSubscribe(func(e StockEvent) bool {
+		return e.InStock
+	}, func(e StockEvent) {
+		inStock = append(inStock, e.Item)
+	})
+	topic.FilterSubscribe(nil, func(e StockEvent) {
+		all = append(all, e.Item)
+	})
+
+	topic.Publish(StockEvent{Item: "shirt", InStock: true})
+	topic.Publish(StockEvent{Item: "hat", InStock: false})
+
+	if !slices.Equal(inStock, []string{"shirt"}) {
+		t.Fatalf("filtered subscriber got %v, want [shirt]", inStock)
+	}
+	if !slices.Equal(all, []string{"shirt", "hat"}) {
+		t.Fatalf("nil filter got %v, want everything", all)
+	}
+}
+
+func TestTopicUnsubscribe(t *testing.T) {
+	topic := NewTopic[int]()
+	var got []int
+	unsubscribe := topic.Subscribe(func(v int) {
+		got = append(got, v)
+	})
+	topic.Publish(1)
+	unsubscribe()
+	unsubscribe()
+	topic.Publish(2)
+
+	if !slices.Equal(got, []int{1}) {
+		t.Fatalf("got %v, want [1]", got)
+	}
+	if n := topic.SubscriberCount(); n != 0 {
+		t.Fatalf("SubscriberCount() = %d after unsubscribe, want 0", n)
+	}
+}
+
+func TestTopicCallbacksMayChangeSubscriptions(t *testing.T) {
+	topic := NewTopic[int]()
+	var log []string
+	var unsubscribeB func()
+	topic.Subscribe(func(v int) {
+		log = append(log, "a")
+		if v == 1 {
+			unsubscribeB()
+			topic.Subscribe(func(int) {
+				log = append(log, "late")
+			})
+		}
+	})
+	unsubscribeB = topic.Subscribe(func(int) {
+		log = append(log, "b")
+	})
+
+	topic.Publish(1)
+	topic.Publish(2)
+
+	// b left mid-publish and gets nothing; late joins from the next publish on
+	if want := []string{"a", "a", "late"}; !slices.Equal(log, want) {
+		t.Fatalf("delivered %v, want %v", log, want)
+	}
+}
+
+func TestTopicIsolatesPanics(t *testing.T) {
+	var reported []error
+	topic := NewTopic[int](OnError(func(err error) {
+		reported = append(reported, err)
+	}))
+	topic.Subscribe(func(int) {
+		panic("boom")
+	})
+	var after int
+	topic.Subscribe(func(v int) {
+		after = v
+	})
+
+	err := topic.NotifyAll(7)
+	var perr *PanicError
+	if !errors.As(err, &perr) || perr.Value != "boom" {
+		t.Fatalf("NotifyAll() = %v, want a *PanicError", err)
+	}
+	if len(reported) != 1 {
+		t.Fatalf("OnError got %v, want the panic once", reported)
+	}
+	if after != 7 {
+		t.Fatal("the observer after the panicking one was not notified")
+	}
+}
@@ -0,0 +1,45 @@
+package observer
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/Antonious-Stewart/15-Most-Common-Design-Patterns/structural/composite"
+)
+
+// UnitIndex is the composite example: subscribed to a Topic[composite.ChangeEvent]
+// set as a division's publisher, it keeps a path index of every unit attached
+// below it, dropping units again when their branch is removed.
+type UnitIndex struct {
+	paths map[string]struct{}
+}
+
+func NewUnitIndex() *UnitIndex {
+	return &UnitIndex{
+		paths: make(map[string]struct{}),
+	}
+}
+
+func (i *UnitIndex) Notify(e composite.ChangeEvent) {
+	path := e.ParentPath + "/" + e.Child
+	switch e.Kind {
+	case composite.ChildAdded:
+		i.paths[path] = struct{}{}
+	case composite.ChildRemoved:
+		for p := range i.paths {
+			if p == path || strings.HasPrefix(p, path+"/") {
+				delete(i.paths, p)
+			}
+		}
+	}
+}
+
+// Paths returns the indexed unit paths in sorted order.
+func (i *UnitIndex) Paths() []string {
+	paths := make([]string, 0, len(i.paths))
+	for path := range i.paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
@@ -0,0 +1,55 @@
+package observer
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/Antonious-Stewart/15-Most-Common-Design-Patterns/structural/composite"
+)
+
+func indexedDivision(name string) (*composite.Division, *UnitIndex) {
+	topic := NewTopic[composite.ChangeEvent]()
+	index := NewUnitIndex()
+	topic.Subscribe(index.Notify)
+	division := composite.NewDivision(name)
+	division.SetPublisher(topic)
+	return division, index
+}
+
+func TestUnitIndexFollowsAddsAndRemoves(t *testing.T) {
+	division, index := indexedDivision("1st")
+	alpha, bravo := composite.NewBrigade("Alpha"), composite.NewBrigade("Bravo")
+	platoon := composite.NewPlatoon("2nd")
+	division.Add(alpha, bravo)
+	alpha.Add(platoon)
+	platoon.Add(composite.NewSquad("S"))
+
+	want := []string{"1st/Alpha", "1st/Alpha/2nd", "1st/Alpha/2nd/S", "1st/Bravo"}
+	if got := index.Paths(); !slices.Equal(got, want) {
+		t.Fatalf("Paths() = %v, want %v", got, want)
+	}
+
+	bravo.Add(platoon)
+	want = []string{"1st/Alpha", "1st/Bravo", "1st/Bravo/2nd", "1st/Bravo/2nd/S"}
+	if got := index.Paths(); !slices.Equal(got, want) {
+		t.Fatalf("after move Paths() = %v, want %v", got, want)
+	}
+
+	division.Remove(bravo)
+	if got, want := index.Paths(), []string{"1st/Alpha"}; !slices.Equal(got, want) {
+		t.Fatalf("after remove Paths() = %v, want %v", got, want)
+	}
+}
+
+func TestUnitIndexSeesSubtreeBuiltBeforeAttaching(t *testing.T) {
+	division, index := indexedDivision("1st")
+	brigade, platoon := composite.NewBrigade("Alpha"), composite.NewPlatoon("2nd")
+	brigade.Add(platoon)
+	division.Add(brigade)
+	platoon.Add(composite.NewSquad("S"))
+
+	want := []string{"1st/Alpha", "1st/Alpha/2nd", "1st/Alpha/2nd/S"}
+	if got := index.Paths(); !slices.Equal(got, want) {
+		t.Fatalf("Paths() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,37 @@
+package state
+
+import (
+	"fmt"
+	"io"
+)
+
+// Demo sells an item from a vending machine and takes a document through
+// moderation, refusing an author who tries to publish it themselves.
+func Demo(w io.Writer) error {
+	machine := NewVendingMachine(1, 10)
+	fmt.Fprintf(w, "machine is in %q\n", machine.StateID())
+	for _, step := range []func() error{
+		machine.SelectItem,
+		func() error { return machine.InsertMoney(15) },
+		machine.Dispense,
+	} {
+		if err := step(); err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "machine is in %q\n", machine.StateID())
+	}
+
+	doc, err := NewDocument("Field manual")
+	if err != nil {
+		return err
+	}
+	if err := doc.Submit(Author); err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "author publishing: %v\n", doc.Publish(Author))
+	if err := doc.Publish(Moderator); err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "document is %s\n", doc.State())
+	return nil
+}
@@ -0,0 +1,125 @@
+package state
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrForbidden      = errors.New("state: forbidden")
+	ErrReasonRequired = errors.New("state: a rejection needs a reason")
+)
+
+type Role string
+
+const (
+	Author    Role = "author"
+	Moderator Role = "moderator"
+)
+
+const (
+	Draft      StateID = "draft"
+	Moderation StateID = "moderation"
+	Published  StateID = "published"
+)
+
+const (
+	SubmitEvent  Event = "submit"
+	PublishEvent Event = "publish"
+	RejectEvent  Event = "reject"
+)
+
+// Request is the payload of a document event: who is asking and, for a
+// rejection, why.
+type Request struct {
+	Role   Role
+	Reason string
+}
+
+// Document is a publishing workflow on top of the generic Machine. Authors
+// submit drafts; only moderators publish them or send them back.
+type Document struct {
+	machine *Machine[StateID, Event, Request]
+
+	title    string
+	feedback string
+}
+
+func NewDocument(title string) (*Document, error) {
+	d := &Document{
+		title: title,
+	}
+	machine, err := NewMachine(d.table(), Draft, Published)
+	if err != nil {
+		return nil, err
+	}
+	d.machine = machine
+	return d, nil
+}
+
+func (d *Document) table() TransitionTable[StateID, Event, Request] {
+	return TransitionTable[StateID, Event, Request]{
+		{Draft, SubmitEvent}: {{
+			To:    Moderation,
+			Guard: requireRole(SubmitEvent, Author),
+			Action: func(Request) {
+				fmt.Printf("Document %s submitted for moderation\n", d.title)
+				d.feedback = ""
+			},
+		}},
+		{Moderation, PublishEvent}: {{
+			To:    Published,
+			Guard: requireRole(PublishEvent, Moderator),
+			Action: func(Request) {
+				fmt.Printf("Document %s published\n", d.title)
+			},
+		}},
+		{Moderation, RejectEvent}: {{
+			To: Draft,
+			Guard: func(r Request) error {
+				if err := requireRole(RejectEvent, Moderator)(r); err != nil {
+					return err
+				}
+				if r.Reason == "" {
+					return ErrReasonRequired
+				}
+				return nil
+			},
+			Action: func(r Request) {
+				fmt.Printf("Document %s rejected: %s\n", d.title, r.Reason)
+				d.feedback = r.Reason
+			},
+		}},
+	}
+}
+
+func requireRole(event Event, role Role) func(Request) error {
+	return func(r Request) error {
+		if r.Role != role {
+			return fmt.Errorf("%w: %s requires %s, not %q", ErrForbidden, event, role, r.Role)
+		}
+		return nil
+	}
+}
+
+func (d *Document) Submit(role Role) error {
+	return d.machine.Fire(SubmitEvent, Request{Role: role})
+}
+
+func (d *Document) Publish(role Role) error {
+	return d.machine.Fire(PublishEvent, Request{Role: role})
+}
+
+func (d *Document) Reject(role Role, reason string) error {
+	return d.machine.Fire(RejectEvent, Request{Role: role, Reason: reason})
+}
+
+func (d *Document) State() StateID {
+	return d.machine.Current()
+}
+
+// Feedback is the reason the document was last sent back to its author.
+// It is cleared when the document is submitted again.
+func (d *Document) Feedback() string {
+	return d.feedback
+}
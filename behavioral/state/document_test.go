@@ -0,0 +1,98 @@
+package state
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func newDocument(t *testing.T) *Document {
+	t.Helper()
+	d, err := NewDocument("Quarterly report")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
+
+func TestDocumentHappyPath(t *testing.T) {
+	d := newDocument(t)
+	if d.State() != Draft {
+		t.Fatalf("State() = %s, want %s", d.State(), Draft)
+	}
+	if err := d.Submit(Author); err != nil {
+		t.Fatal(err)
+	}
+	if d.State() != Moderation {
+		t.Fatalf("State() = %s after Submit, want %s", d.State(), Moderation)
+	}
+	if err := d.Publish(Moderator); err != nil {
+		t.Fatal(err)
+	}
+	if d.State() != Published {
+		t.Fatalf("State() = %s after Publish, want %s", d.State(), Published)
+	}
+	if err := d.Reject(Moderator, "too late"); !errors.Is(err, ErrInvalidTransition) {
+		t.Fatalf("Reject() once published = %v, want %v", err, ErrInvalidTransition)
+	}
+}
+
+func TestDocumentForbiddenAttempts(t *testing.T) {
+	tests := []struct {
+		name     string
+		from     StateID
+		attempt  func(d *Document) error
+		required Role
+	}{
+		{"moderator submits", Draft, func(d *Document) error { return d.Submit(Moderator) }, Author},
+		{"anonymous submits", Draft, func(d *Document) error { return d.Submit("") }, Author},
+		{"author publishes", Moderation, func(d *Document) error { return d.Publish(Author) }, Moderator},
+		{"author rejects", Moderation, func(d *Document) error { return d.Reject(Author, "typo") }, Moderator},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := newDocument(t)
+			if tt.from == Moderation {
+				if err := d.Submit(Author); err != nil {
+					t.Fatal(err)
+				}
+			}
+			err := tt.attempt(d)
+			if !errors.Is(err, ErrForbidden) {
+				t.Fatalf("got %v, want %v", err, ErrForbidden)
+			}
+			if !strings.Contains(err.Error(), string(tt.required)) {
+				t.Fatalf("%q does not name the required role %s", err, tt.required)
+			}
+			if d.State() != tt.from {
+				t.Fatalf("State() = %s after a forbidden attempt, want %s", d.State(), tt.from)
+			}
+		})
+	}
+}
+
+func TestDocumentRejectionAndResubmission(t *testing.T) {
+	d := newDocument(t)
+	if err := d.Submit(Author); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.Reject(Moderator, ""); !errors.Is(err, ErrReasonRequired) {
+		t.Fatalf("Reject() without a reason = %v, want %v", err, ErrReasonRequired)
+	}
+	if err := d.Reject(Moderator, "cite your sources"); err != nil {
+		t.Fatal(err)
+	}
+	if d.State() != Draft || d.Feedback() != "cite your sources" {
+		t.Fatalf("State() = %s with feedback %q, want a draft with the reason", d.State(), d.Feedback())
+	}
+
+	if err := d.Submit(Author); err != nil {
+		t.Fatal(err)
+	}
+	if d.State() != Moderation || d.Feedback() != "" {
+		t.Fatalf("State() = %s with feedback %q, want moderation with feedback cleared", d.State(), d.Feedback())
+	}
+	if err := d.Publish(Moderator); err != nil {
+		t.Fatal(err)
+	}
+}
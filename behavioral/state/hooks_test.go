@@ -0,0 +1,148 @@
+package state
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+// hookLog records every hook call as "enter <state> on <event>" or
+// "exit <state> on <event>".
+type hookLog struct {
+	calls []string
+	// fail, when set, is returned by the hook with that exact entry.
+	fail map[string]error
+}
+
+func (h *hookLog) options() []MachineOption {
+	var opts []MachineOption
+	for _, id := range []StateID{NoItem, HasItem, ItemRequested, HasMoney} {
+		opts = append(opts,
+			WithEnterHook(id, h.hook("enter", id)),
+			WithExitHook(id, h.hook("exit", id)),
+		)
+	}
+	return opts
+}
+
+func (h *hookLog) hook(kind string, id StateID) func(Event) error {
+	return func(event Event) error {
+		call := kind + " " + string(id) + " on " + string(event)
+		h.calls = append(h.calls, call)
+		return h.fail[call]
+	}
+}
+
+func (h *hookLog) take() []string {
+	calls := h.calls
+	h.calls = nil
+	return calls
+}
+
+func TestHooksRunExitThenEnter(t *testing.T) {
+	h := &hookLog{}
+	v := NewVendingMachine(1, 10, h.options()...)
+	steps := []struct {
+		op   func() error
+		want []string
+	}{
+		{v.SelectItem, []string{"exit has item on select item", "enter item requested on select item"}},
+		{func() error { return v.InsertMoney(10) }, []string{"exit item requested on insert money", "enter has money on insert money"}},
+		{v.Dispense, []string{"exit has money on dispense", "enter no item on dispense"}},
+		{func() error { return v.AddItem(2) }, []string{"exit no item on add item", "enter has item on add item"}},
+		// staying in HasItem is not a transition
+		{func() error { return v.AddItem(2) }, nil},
+	}
+	for i, step := range steps {
+		if err := step.op(); err != nil {
+			t.Fatalf("step %d: %v", i, err)
+		}
+		if got := h.take(); !slices.Equal(got, step.want) {
+			t.Fatalf("step %d ran hooks %v, want %v", i, got, step.want)
+		}
+	}
+}
+
+func TestHooksForOneStateRunInOrder(t *testing.T) {
+	var calls []string
+	record := func(name string) func(Event) error {
+		return func(Event) error {
+			calls = append(calls, name)
+			return nil
+		}
+	}
+	v := NewVendingMachine(1, 10,
+		WithEnterHook(ItemRequested, record("first")),
+		WithEnterHook(ItemRequested, record("second")),
+	)
+	if err := v.SelectItem(); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"first", "second"}; !slices.Equal(calls, want) {
+		t.Fatalf("ran %v, want %v", calls, want)
+	}
+}
+
+func TestHookErrorAbortsTransition(t *testing.T) {
+	jammed := errors.New("coin slot jammed")
+	tests := []struct {
+		name      string
+		failing   string
+		wantCalls []string
+	}{
+		{
+			name:      "exit hook",
+			failing:   "exit item requested on insert money",
+			wantCalls: []string{"exit item requested on insert money"},
+		},
+		{
+			name:      "enter hook",
+			failing:   "enter has money on insert money",
+			wantCalls: []string{"exit item requested on insert money", "enter has money on insert money"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &hookLog{fail: map[string]error{tt.failing: jammed}}
+			v := NewVendingMachine(1, 10, h.options()...)
+			if err := v.SelectItem(); err != nil {
+				t.Fatal(err)
+			}
+			h.take()
+
+			if err := v.InsertMoney(10); !errors.Is(err, jammed) {
+				t.Fatalf("InsertMoney() = %v, want %v", err, jammed)
+			}
+			if got := h.take(); !slices.Equal(got, tt.wantCalls) {
+				t.Fatalf("ran hooks %v, want %v", got, tt.wantCalls)
+			}
+			if got := v.StateID(); got != ItemRequested {
+				t.Fatalf("StateID() = %s after a failed hook, want %s", got, ItemRequested)
+			}
+			// the money was not taken, so the customer can retry
+			delete(h.fail, tt.failing)
+			if err := v.InsertMoney(10); err != nil {
+				t.Fatalf("retrying InsertMoney() = %v", err)
+			}
+			if err := v.Dispense(); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestRejectedOperationsRunNoHooks(t *testing.T) {
+	for _, id := range []StateID{NoItem, HasItem, ItemRequested, HasMoney} {
+		for _, op := range operations() {
+			h := &hookLog{}
+			v := machineIn(t, id, h.options()...)
+			h.take()
+			if err := op.hand(v); err == nil {
+				continue
+			}
+			if got := h.take(); len(got) != 0 {
+				t.Errorf("refused %s in %s ran hooks %v", op.name, id, got)
+			}
+		}
+	}
+}
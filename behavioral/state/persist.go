@@ -0,0 +1,122 @@
+package state
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrUnknownState    = errors.New("state: unknown state")
+	ErrInvalidSnapshot = errors.New("state: saved machine is inconsistent")
+)
+
+// snapshot is what Save writes: enough to resume mid-transaction.
+type snapshot struct {
+	State     StateID `json:"state"`
+	ItemCount int     `json:"itemCount"`
+	ItemPrice int     `json:"itemPrice"`
+	Money     int     `json:"money"`
+}
+
+// Save captures the current state together with the inventory, the price
+// and any money inserted so far.
+func (v *VendingMachine) Save() ([]byte, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return json.Marshal(snapshot{
+		State:     v.stateID(),
+		ItemCount: v.itemCount,
+		ItemPrice: v.itemPrice,
+		Money:     v.money,
+	})
+}
+
+// Load resumes from data written by Save. The machine is only changed if the
+// saved state is one of its own and the saved data is possible in that
+// state, e.g. HasMoney needs items left and at least the price inserted.
+// Loading is a restore rather than a transition, so no hooks run. A pending
+// timeout is stopped and the restored state's own timeout, if it has one,
+// starts again from its full duration.
+func (v *VendingMachine) Load(data []byte) error {
+	var snap snapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidSnapshot, err)
+	}
+	s, ok := v.states()[snap.State]
+	if !ok {
+		return fmt.Errorf("%w: %q", ErrUnknownState, snap.State)
+	}
+	if err := snap.validate(); err != nil {
+		return err
+	}
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.cancelTimeout(v.pending)
+	v.pending = nil
+	v.currentState = s
+	v.itemCount = snap.ItemCount
+	v.itemPrice = snap.ItemPrice
+	v.money = snap.Money
+	if armer, ok := s.(armer); ok {
+		armer.armTimeout()
+	}
+	return nil
+}
+
+// StateID names the current state, matching the table-driven machine's IDs.
+func (v *VendingMachine) StateID() StateID {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.stateID()
+}
+
+func (v *VendingMachine) stateID() StateID {
+	return v.idOf(v.currentState)
+}
+
+func (v *VendingMachine) idOf(state State) StateID {
+	for id, s := range v.states() {
+		if s == state {
+			return id
+		}
+	}
+	return ""
+}
+
+func (v *VendingMachine) states() map[StateID]State {
+	return map[StateID]State{
+		NoItem:        v.noItem,
+		HasItem:       v.hasItem,
+		ItemRequested: v.itemRequested,
+		HasMoney:      v.hasMoney,
+	}
+}
+
+func (s snapshot) validate() error {
+	invalid := func(reason string) error {
+		return fmt.Errorf("%w: %s in state %q", ErrInvalidSnapshot, reason, s.State)
+	}
+	if s.ItemCount < 0 {
+		return invalid("negative item count")
+	}
+	if s.ItemPrice < 0 {
+		return invalid("negative item price")
+	}
+	if s.State == NoItem && s.ItemCount != 0 {
+		return invalid("items left")
+	}
+	if s.State != NoItem && s.ItemCount == 0 {
+		return invalid("no items left")
+	}
+	if s.State == HasMoney {
+		if s.Money <= 0 || s.Money < s.ItemPrice {
+			return invalid("not enough money inserted")
+		}
+		return nil
+	}
+	if s.Money != 0 {
+		return invalid("money inserted")
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package state
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSaveAndResumeInEveryState(t *testing.T) {
+	// finish takes a resumed machine in each state through to a dispense
+	finish := map[StateID][]func(v *VendingMachine) error{
+		NoItem: {
+			func(v *VendingMachine) error { return v.AddItem(1) },
+			(*VendingMachine).SelectItem,
+			func(v *VendingMachine) error { return v.InsertMoney(10) },
+			(*VendingMachine).Dispense,
+		},
+		HasItem: {
+			(*VendingMachine).SelectItem,
+			func(v *VendingMachine) error { return v.InsertMoney(10) },
+			(*VendingMachine).Dispense,
+		},
+		ItemRequested: {
+			func(v *VendingMachine) error { return v.InsertMoney(10) },
+			(*VendingMachine).Dispense,
+		},
+		HasMoney: {
+			(*VendingMachine).Dispense,
+		},
+	}
+	for id, steps := range finish {
+		t.Run(string(id), func(t *testing.T) {
+			saved, err := machineIn(t, id).Save()
+			if err != nil {
+				t.Fatal(err)
+			}
+			v := NewVendingMachine(5, 99)
+			if err := v.Load(saved); err != nil {
+				t.Fatalf("Load(%s) = %v", saved, err)
+			}
+			if v.StateID() != id {
+				t.Fatalf("StateID() = %s after Load, want %s", v.StateID(), id)
+			}
+			count := v.ItemCount()
+			for i, step := range steps {
+				if err := step(v); err != nil {
+					t.Fatalf("step %d after resuming: %v", i, err)
+				}
+			}
+			if id == NoItem {
+				count++
+			}
+			if v.ItemCount() != count-1 {
+				t.Fatalf("ItemCount() = %d, want %d after one sale", v.ItemCount(), count-1)
+			}
+		})
+	}
+}
+
+func TestFreeItemRoundTrips(t *testing.T) {
+	v := NewVendingMachine(1, 0)
+	if err := v.SelectItem(); err != nil {
+		t.Fatal(err)
+	}
+	if err := v.InsertMoney(0); !errors.Is(err, ErrInvalidMoney) {
+		t.Fatalf("InsertMoney(0) = %v, want %v", err, ErrInvalidMoney)
+	}
+	if err := v.InsertMoney(1); err != nil {
+		t.Fatal(err)
+	}
+	saved, err := v.Save()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := NewVendingMachine(0, 0).Load(saved); err != nil {
+		t.Fatalf("Load(%s) = %v, want the saved machine back", saved, err)
+	}
+}
+
+func TestLoadRejectsInvalidPayloads(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want error
+	}{
+		{"not json", `{"state":`, ErrInvalidSnapshot},
+		{"unknown state", `{"state":"broken","itemCount":1,"itemPrice":10}`, ErrUnknownState},
+		{"negative count", `{"state":"has item","itemCount":-1,"itemPrice":10}`, ErrInvalidSnapshot},
+		{"negative price", `{"state":"has item","itemCount":1,"itemPrice":-1}`, ErrInvalidSnapshot},
+		{"stock while empty", `{"state":"no item","itemCount":3,"itemPrice":10}`, ErrInvalidSnapshot},
+		{"no stock while selling", `{"state":"item requested","itemCount":0,"itemPrice":10}`, ErrInvalidSnapshot},
+		{"no money", `{"state":"has money","itemCount":1,"itemPrice":0,"money":0}`, ErrInvalidSnapshot},
+		{"too little money", `{"state":"has money","itemCount":1,"itemPrice":10,"money":5}`, ErrInvalidSnapshot},
+		{"money before paying", `{"state":"item requested","itemCount":1,"itemPrice":10,"money":5}`, ErrInvalidSnapshot},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := NewVendingMachine(2, 10)
+			if err := v.SelectItem(); err != nil {
+				t.Fatal(err)
+			}
+			if err := v.Load([]byte(tt.data)); !errors.Is(err, tt.want) {
+				t.Fatalf("Load() = %v, want %v", err, tt.want)
+			}
+			// a rejected payload leaves the machine as it was
+			if v.StateID() != ItemRequested || v.ItemCount() != 2 {
+				t.Fatalf("machine is %s with %d items after a failed Load", v.StateID(), v.ItemCount())
+			}
+			if err := v.InsertMoney(10); err != nil {
+				t.Fatalf("InsertMoney() after a failed Load = %v", err)
+			}
+		})
+	}
+}
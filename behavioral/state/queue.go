@@ -0,0 +1,136 @@
+package state
+
+import (
+	"errors"
+	"log"
+	"sync"
+)
+
+var (
+	ErrQueueFull = errors.New("state: event queue is full")
+	ErrStopped   = errors.New("state: machine is stopped")
+)
+
+// Runner makes a Machine usable from several goroutines. Events are queued
+// and applied one at a time by a single loop, so guards and actions never
+// run concurrently and events from one sender are applied in the order
+// they were sent.
+//
+// The queue is bounded. When it is full, Send and SendSync fail with
+// ErrQueueFull instead of waiting, so a stuck machine pushes back on its
+// callers rather than piling up work.
+type Runner[S, E comparable, P any] struct {
+	machine *Machine[S, E, P]
+	queue   chan envelope[E, P]
+	done    chan struct{}
+
+	// mu guards stopped and onError, and keeps Send from racing Stop's close of queue.
+	mu      sync.RWMutex
+	stopped bool
+	onError func(error)
+
+	// state guards the machine so Current can read it while the loop runs.
+	state sync.Mutex
+}
+
+type envelope[E any, P any] struct {
+	event   E
+	payload P
+	// result is set for SendSync and receives the outcome of Fire.
+	result chan error
+}
+
+// Run starts a loop applying events to m. Nothing else should fire m directly
+// from then on. capacity is how many events may wait; it is at least one.
+func Run[S, E comparable, P any](m *Machine[S, E, P], capacity int) *Runner[S, E, P] {
+	if capacity < 1 {
+		capacity = 1
+	}
+	r := &Runner[S, E, P]{
+		machine: m,
+		queue:   make(chan envelope[E, P], capacity),
+		done:    make(chan struct{}),
+		onError: func(err error) {
+			log.Println(err)
+		},
+	}
+	go r.loop()
+	return r
+}
+
+// OnError replaces the default of logging the errors of events queued with
+// Send; nobody is waiting to be told about those.
+func (r *Runner[S, E, P]) OnError(fn func(error)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if fn != nil {
+		r.onError = fn
+	}
+}
+
+// Send queues event and returns without waiting for it to be applied.
+func (r *Runner[S, E, P]) Send(event E, payload P) error {
+	return r.enqueue(envelope[E, P]{event: event, payload: payload})
+}
+
+// SendSync queues event and waits for the machine's answer to it. Calling it
+// from a guard or action deadlocks, since the loop is the one running them.
+func (r *Runner[S, E, P]) SendSync(event E, payload P) error {
+	result := make(chan error, 1)
+	if err := r.enqueue(envelope[E, P]{event: event, payload: payload, result: result}); err != nil {
+		return err
+	}
+	return <-result
+}
+
+func (r *Runner[S, E, P]) enqueue(env envelope[E, P]) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.stopped {
+		return ErrStopped
+	}
+	select {
+	case r.queue <- env:
+		return nil
+	default:
+		return ErrQueueFull
+	}
+}
+
+func (r *Runner[S, E, P]) Current() S {
+	r.state.Lock()
+	defer r.state.Unlock()
+	return r.machine.Current()
+}
+
+// Stop refuses further events, applies the ones already queued and waits for
+// the loop to exit. It is safe to call more than once.
+func (r *Runner[S, E, P]) Stop() {
+	r.mu.Lock()
+	if !r.stopped {
+		r.stopped = true
+		close(r.queue)
+	}
+	r.mu.Unlock()
+	<-r.done
+}
+
+func (r *Runner[S, E, P]) loop() {
+	defer close(r.done)
+	for env := range r.queue {
+		r.state.Lock()
+		err := r.machine.Fire(env.event, env.payload)
+		r.state.Unlock()
+
+		if env.result != nil {
+			env.result <- err
+			continue
+		}
+		if err != nil {
+			r.mu.RLock()
+			onError := r.onError
+			r.mu.RUnlock()
+			onError(err)
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package state
+
+import (
+	"errors"
+	"sync"
+	"testing"
+)
+
+type sent struct {
+	sender int
+	seq    int
+}
+
+func counterTable(record func(sent)) TransitionTable[string, string, sent] {
+	return TransitionTable[string, string, sent]{
+		{"idle", "tick"}: {{To: "idle", Action: record}},
+	}
+}
+
+func TestRunnerSerializesEventsPerSender(t *testing.T) {
+	const senders, perSender = 8, 200
+
+	// no lock: the runner must never run two actions at once, and -race checks that
+	seen := make(map[int][]int)
+	m, err := NewMachine(counterTable(func(s sent) {
+		seen[s.sender] = append(seen[s.sender], s.seq)
+	}), "idle")
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := Run(m, senders*perSender)
+
+	var wg sync.WaitGroup
+	for sender := 0; sender < senders; sender++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for seq := 0; seq < perSender; seq++ {
+				send := r.Send
+				if seq%20 == 0 {
+					send = r.SendSync
+				}
+				if err := send("tick", sent{sender: sender, seq: seq}); err != nil {
+					t.Error(err)
+				}
+				_ = r.Current()
+			}
+		}()
+	}
+	wg.Wait()
+	r.Stop()
+
+	for sender := 0; sender < senders; sender++ {
+		got := seen[sender]
+		if len(got) != perSender {
+			t.Fatalf("sender %d: applied %d events, want %d", sender, len(got), perSender)
+		}
+		for i, seq := range got {
+			if seq != i {
+				t.Fatalf("sender %d: event %d applied as #%d", sender, seq, i)
+			}
+		}
+	}
+}
+
+func TestRunnerSendSyncReturnsFireResult(t *testing.T) {
+	m, err := NewMachine(counterTable(func(sent) {}), "idle")
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := Run(m, 1)
+	defer r.Stop()
+
+	if err := r.SendSync("tick", sent{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.SendSync("boom", sent{}); !errors.Is(err, ErrInvalidTransition) {
+		t.Fatalf("SendSync(boom) = %v, want ErrInvalidTransition", err)
+	}
+}
+
+func TestRunnerStopDrainsQueue(t *testing.T) {
+	release := make(chan struct{})
+	var applied []int
+	m, err := NewMachine(counterTable(func(s sent) {
+		<-release
+		applied = append(applied, s.seq)
+	}), "idle")
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := Run(m, 10)
+	for seq := 0; seq < 5; seq++ {
+		if err := r.Send("tick", sent{seq: seq}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		r.Stop()
+		close(stopped)
+	}()
+	close(release)
+	<-stopped
+
+	if len(applied) != 5 {
+		t.Fatalf("Stop applied %d queued events, want 5", len(applied))
+	}
+	if err := r.Send("tick", sent{}); !errors.Is(err, ErrStopped) {
+		t.Fatalf("Send after Stop = %v, want ErrStopped", err)
+	}
+	if err := r.SendSync("tick", sent{}); !errors.Is(err, ErrStopped) {
+		t.Fatalf("SendSync after Stop = %v, want ErrStopped", err)
+	}
+	r.Stop()
+}
+
+func TestRunnerRejectsWhenQueueFull(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	m, err := NewMachine(counterTable(func(s sent) {
+		if s.seq == 0 {
+			close(started)
+			<-release
+		}
+	}), "idle")
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := Run(m, 1)
+
+	if err := r.Send("tick", sent{seq: 0}); err != nil {
+		t.Fatal(err)
+	}
+	<-started
+	// the loop is busy with the first event, so this one fills the queue
+	if err := r.Send("tick", sent{seq: 1}); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Send("tick", sent{seq: 2}); !errors.Is(err, ErrQueueFull) {
+		t.Fatalf("Send on a full queue = %v, want ErrQueueFull", err)
+	}
+	if err := r.SendSync("tick", sent{seq: 2}); !errors.Is(err, ErrQueueFull) {
+		t.Fatalf("SendSync on a full queue = %v, want ErrQueueFull", err)
+	}
+	close(release)
+	r.Stop()
+}
+
+func TestRunnerReportsAsyncErrors(t *testing.T) {
+	m, err := NewMachine(counterTable(func(sent) {}), "idle")
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := Run(m, 4)
+
+	var mu sync.Mutex
+	var got []error
+	r.OnError(func(err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		got = append(got, err)
+	})
+	if err := r.Send("boom", sent{}); err != nil {
+		t.Fatal(err)
+	}
+	r.Stop()
+
+	if len(got) != 1 || !errors.Is(got[0], ErrInvalidTransition) {
+		t.Fatalf("OnError got %v, want one ErrInvalidTransition", got)
+	}
+}
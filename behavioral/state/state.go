@@ -0,0 +1,369 @@
+package state
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+//State is a behavioral design pattern that lets an object alter its behavior when its internal state changes. It appears as if the object changed its class.
+//The State pattern suggests that you create new classes for all possible states of an object and extract all state-specific behaviors into these classes.
+//Instead of implementing all behaviors on its own, the original object, called context, stores a reference to one of the state objects that represents its current state, and delegates all the state-related work to that object.
+//To transition the context into another state, replace the active state object with another object that represents that new state.
+
+//How to Implement
+//
+//Decide what class will act as the context. It could be an existing class which already has the state-dependent code; or a new class, if the state-specific code is distributed across multiple classes.
+//
+//Declare the state interface. Although it may mirror all the methods declared in the context, aim only for those that may contain state-specific behavior.
+//
+//For every actual state, create a class that derives from the state interface. Then go over the methods of the context and extract all code related to that state into your newly created class.
+//
+//While moving the code to the state class, you might discover that it depends on private members of the context. There are several workarounds:
+//Make these fields or methods public.
+//Turn the behavior you’re extracting into a public method in the context and call it from the state class. This way is ugly but quick, and you can always fix it later.
+//Nest the state classes into the context class, but only if your programming language supports nesting classes.
+//In Go the states simply live in the same package as the context, so unexported fields are enough.
+//
+//In the context class, add a reference field of the state interface type and a public setter that allows overriding the value of that field.
+//
+//Go over the method of the context again and replace empty state conditionals with calls to corresponding methods of the state object.
+//
+//To switch the state of the context, create an instance of one of the state classes and pass it to the context.
+//You can do this within the context itself, or in various states, or in the client. Wherever this is done, the class becomes dependent on the concrete state class that it instantiates.
+
+var (
+	ErrOutOfStock          = errors.New("state: item out of stock")
+	ErrSelectItemFirst     = errors.New("state: please select an item first")
+	ErrAlreadyRequested    = errors.New("state: item already requested")
+	ErrDispenseInProgress  = errors.New("state: item dispense in progress")
+	ErrInsertMoneyFirst    = errors.New("state: please insert money first")
+	ErrInsufficientMoney   = errors.New("state: inserted money is less than the item price")
+	ErrMoneyAlreadyPresent = errors.New("state: money already inserted")
+	ErrInvalidCount        = errors.New("state: item count must be positive")
+	ErrInvalidMoney        = errors.New("state: inserted money must be positive")
+)
+
+type State interface {
+	AddItem(count int) error
+	SelectItem() error
+	InsertMoney(money int) error
+	Dispense() error
+}
+
+// Enterer is implemented by states with work to do when they become current,
+// like starting a timeout. An error keeps the machine where it was.
+type Enterer interface {
+	OnEnter(event Event) error
+}
+
+// Exiter is implemented by states with work to undo when they are left,
+// like cancelling that timeout. An error keeps the machine where it was.
+type Exiter interface {
+	OnExit(event Event) error
+}
+
+// WithEnterHook runs fn whenever the machine enters the state id, after the
+// state's own OnEnter. Like OnEnter it runs with the machine locked, and an
+// error keeps the machine where it was. Hooks for one state run in the order
+// they were added.
+func WithEnterHook(id StateID, fn func(event Event) error) MachineOption {
+	return func(v *VendingMachine) {
+		v.enterHooks[id] = append(v.enterHooks[id], fn)
+	}
+}
+
+// WithExitHook runs fn whenever the machine leaves the state id, after the
+// state's own OnExit, on the same terms as WithEnterHook.
+func WithExitHook(id StateID, fn func(event Event) error) MachineOption {
+	return func(v *VendingMachine) {
+		v.exitHooks[id] = append(v.exitHooks[id], fn)
+	}
+}
+
+// VendingMachine is safe to use from several goroutines because timeouts fire
+// on their own. State methods and hooks run with the machine locked, so
+// they must not call its exported methods.
+type VendingMachine struct {
+	mu sync.Mutex
+
+	noItem        State
+	hasItem       State
+	itemRequested State
+	hasMoney      State
+
+	currentState State
+
+	itemCount int
+	itemPrice int
+	money     int
+
+	clock       Clock
+	refundAfter time.Duration
+	pending     *timeout
+
+	enterHooks map[StateID][]func(Event) error
+	exitHooks  map[StateID][]func(Event) error
+}
+
+func NewVendingMachine(itemCount, itemPrice int, opts ...MachineOption) *VendingMachine {
+	v := &VendingMachine{
+		itemCount:  itemCount,
+		itemPrice:  itemPrice,
+		clock:      realClock{},
+		enterHooks: make(map[StateID][]func(Event) error),
+		exitHooks:  make(map[StateID][]func(Event) error),
+	}
+	for _, opt := range opts {
+		opt(v)
+	}
+	v.noItem = &NoItemState{machine: v}
+	v.hasItem = &HasItemState{machine: v}
+	v.itemRequested = &ItemRequestedState{machine: v}
+	v.hasMoney = &HasMoneyState{machine: v}
+
+	v.currentState = v.hasItem
+	if itemCount <= 0 {
+		v.itemCount = 0
+		v.currentState = v.noItem
+	}
+	return v
+}
+
+func (v *VendingMachine) AddItem(count int) error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.currentState.AddItem(count)
+}
+
+func (v *VendingMachine) SelectItem() error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.currentState.SelectItem()
+}
+
+func (v *VendingMachine) InsertMoney(money int) error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.currentState.InsertMoney(money)
+}
+
+func (v *VendingMachine) Dispense() error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.currentState.Dispense()
+}
+
+func (v *VendingMachine) CurrentState() State {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.currentState
+}
+
+func (v *VendingMachine) ItemCount() int {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.itemCount
+}
+
+// setState makes s current in response to event. The old state's OnExit and
+// exit hooks run first, then the new state's OnEnter and enter hooks; if any
+// of them fails the machine stays in the old state. An enter failure comes
+// after the exit side has already run, so exit hooks shouldn't do anything
+// they can't do twice. Once the transition succeeds the old state's timeout,
+// if any, is stopped.
+func (v *VendingMachine) setState(s State, event Event) error {
+	if s == v.currentState {
+		return nil
+	}
+	prev := v.pending
+	v.pending = nil
+	if err := v.exit(v.currentState, event); err != nil {
+		v.pending = prev
+		return err
+	}
+	if err := v.enter(s, event); err != nil {
+		v.cancelTimeout(v.pending)
+		v.pending = prev
+		return err
+	}
+	v.cancelTimeout(prev)
+	v.currentState = s
+	return nil
+}
+
+func (v *VendingMachine) exit(s State, event Event) error {
+	if exiter, ok := s.(Exiter); ok {
+		if err := exiter.OnExit(event); err != nil {
+			return err
+		}
+	}
+	return runHooks(v.exitHooks[v.idOf(s)], event)
+}
+
+func (v *VendingMachine) enter(s State, event Event) error {
+	if enterer, ok := s.(Enterer); ok {
+		if err := enterer.OnEnter(event); err != nil {
+			return err
+		}
+	}
+	return runHooks(v.enterHooks[v.idOf(s)], event)
+}
+
+func runHooks(hooks []func(Event) error, event Event) error {
+	for _, hook := range hooks {
+		if err := hook(event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func checkCount(count int) error {
+	if count <= 0 {
+		return fmt.Errorf("%w: %d", ErrInvalidCount, count)
+	}
+	return nil
+}
+
+// checkMoney keeps a free item from being paid for with nothing, which would
+// leave HasMoney with no money in it.
+func checkMoney(money int) error {
+	if money <= 0 {
+		return fmt.Errorf("%w: %d", ErrInvalidMoney, money)
+	}
+	return nil
+}
+
+func (v *VendingMachine) incrementItemCount(count int) {
+	fmt.Printf("Adding %d items\n", count)
+	v.itemCount += count
+}
+
+type NoItemState struct {
+	machine *VendingMachine
+}
+
+func (s *NoItemState) AddItem(count int) error {
+	if err := checkCount(count); err != nil {
+		return err
+	}
+	if err := s.machine.setState(s.machine.hasItem, AddItemEvent); err != nil {
+		return err
+	}
+	s.machine.incrementItemCount(count)
+	return nil
+}
+
+func (s *NoItemState) SelectItem() error {
+	return ErrOutOfStock
+}
+
+func (s *NoItemState) InsertMoney(money int) error {
+	return ErrOutOfStock
+}
+
+func (s *NoItemState) Dispense() error {
+	return ErrOutOfStock
+}
+
+type HasItemState struct {
+	machine *VendingMachine
+}
+
+func (s *HasItemState) AddItem(count int) error {
+	if err := checkCount(count); err != nil {
+		return err
+	}
+	s.machine.incrementItemCount(count)
+	return nil
+}
+
+func (s *HasItemState) SelectItem() error {
+	if err := s.machine.setState(s.machine.itemRequested, SelectItemEvent); err != nil {
+		return err
+	}
+	fmt.Println("Item requested")
+	return nil
+}
+
+func (s *HasItemState) InsertMoney(money int) error {
+	return ErrSelectItemFirst
+}
+
+func (s *HasItemState) Dispense() error {
+	return ErrSelectItemFirst
+}
+
+type ItemRequestedState struct {
+	machine *VendingMachine
+}
+
+func (s *ItemRequestedState) AddItem(count int) error {
+	return ErrDispenseInProgress
+}
+
+func (s *ItemRequestedState) SelectItem() error {
+	return ErrAlreadyRequested
+}
+
+func (s *ItemRequestedState) InsertMoney(money int) error {
+	if err := checkMoney(money); err != nil {
+		return err
+	}
+	if money < s.machine.itemPrice {
+		return fmt.Errorf("%w: inserted %d, please insert %d", ErrInsufficientMoney, money, s.machine.itemPrice)
+	}
+	if err := s.machine.setState(s.machine.hasMoney, InsertMoneyEvent); err != nil {
+		return err
+	}
+	fmt.Println("Money entered is ok")
+	s.machine.money = money
+	return nil
+}
+
+func (s *ItemRequestedState) Dispense() error {
+	return ErrInsertMoneyFirst
+}
+
+type HasMoneyState struct {
+	machine *VendingMachine
+}
+
+func (s *HasMoneyState) AddItem(count int) error {
+	return ErrDispenseInProgress
+}
+
+func (s *HasMoneyState) SelectItem() error {
+	return ErrDispenseInProgress
+}
+
+func (s *HasMoneyState) InsertMoney(money int) error {
+	return ErrMoneyAlreadyPresent
+}
+
+func (s *HasMoneyState) Dispense() error {
+	next := s.machine.hasItem
+	if s.machine.itemCount <= 1 {
+		next = s.machine.noItem
+	}
+	if err := s.machine.setState(next, DispenseEvent); err != nil {
+		return err
+	}
+	fmt.Println("Dispensing item")
+	if change := s.machine.money - s.machine.itemPrice; change > 0 {
+		fmt.Printf("Returning change %d\n", change)
+	}
+	s.machine.money = 0
+	s.machine.itemCount--
+	return nil
+}
+
+//Pros and Cons
+//
+//Single Responsibility Principle. Organize the code related to particular states into separate classes.
+//Open/Closed Principle. Introduce new states without changing existing state classes or the context.
+//Simplify the code of the context by eliminating bulky state machine conditionals.
+//
+//Applying the pattern can be overkill if a state machine has only a few states or rarely changes.
@@ -0,0 +1,184 @@
+package state
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// machineIn returns a machine stocked with two items at price 10, driven
+// into the named state.
+func machineIn(t *testing.T, id StateID, opts ...MachineOption) *VendingMachine {
+	t.Helper()
+	if id == NoItem {
+		return NewVendingMachine(0, 10, opts...)
+	}
+	v := NewVendingMachine(2, 10, opts...)
+	steps := map[StateID][]func() error{
+		HasItem:       nil,
+		ItemRequested: {v.SelectItem},
+		HasMoney:      {v.SelectItem, func() error { return v.InsertMoney(10) }},
+	}[id]
+	for _, step := range steps {
+		if err := step(); err != nil {
+			t.Fatalf("reaching %s: %v", id, err)
+		}
+	}
+	return v
+}
+
+func currentID(v *VendingMachine) StateID {
+	switch v.CurrentState().(type) {
+	case *NoItemState:
+		return NoItem
+	case *HasItemState:
+		return HasItem
+	case *ItemRequestedState:
+		return ItemRequested
+	case *HasMoneyState:
+		return HasMoney
+	}
+	return ""
+}
+
+func TestLegalFlows(t *testing.T) {
+	tests := []struct {
+		name      string
+		itemCount int
+		steps     func(v *VendingMachine) []error
+		want      StateID
+		wantCount int
+	}{
+		{
+			name:      "purchase with change",
+			itemCount: 2,
+			steps: func(v *VendingMachine) []error {
+				return []error{v.SelectItem(), v.InsertMoney(15), v.Dispense()}
+			},
+			want:      HasItem,
+			wantCount: 1,
+		},
+		{
+			name:      "last item empties the machine",
+			itemCount: 1,
+			steps: func(v *VendingMachine) []error {
+				return []error{v.SelectItem(), v.InsertMoney(10), v.Dispense()}
+			},
+			want:      NoItem,
+			wantCount: 0,
+		},
+		{
+			name:      "restock an empty machine and buy",
+			itemCount: 0,
+			steps: func(v *VendingMachine) []error {
+				return []error{v.AddItem(3), v.SelectItem(), v.InsertMoney(10), v.Dispense()}
+			},
+			want:      HasItem,
+			wantCount: 2,
+		},
+		{
+			name:      "top up a stocked machine",
+			itemCount: 1,
+			steps: func(v *VendingMachine) []error {
+				return []error{v.AddItem(4)}
+			},
+			want:      HasItem,
+			wantCount: 5,
+		},
+		{
+			name:      "two purchases in a row",
+			itemCount: 2,
+			steps: func(v *VendingMachine) []error {
+				return []error{
+					v.SelectItem(), v.InsertMoney(10), v.Dispense(),
+					v.SelectItem(), v.InsertMoney(20), v.Dispense(),
+				}
+			},
+			want:      NoItem,
+			wantCount: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := NewVendingMachine(tt.itemCount, 10)
+			for i, err := range tt.steps(v) {
+				if err != nil {
+					t.Fatalf("step %d: %v", i, err)
+				}
+			}
+			if got := currentID(v); got != tt.want {
+				t.Fatalf("state = %s, want %s", got, tt.want)
+			}
+			if got := v.ItemCount(); got != tt.wantCount {
+				t.Fatalf("ItemCount() = %d, want %d", got, tt.wantCount)
+			}
+		})
+	}
+}
+
+func TestIllegalOperations(t *testing.T) {
+	ops := map[string]func(v *VendingMachine) error{
+		"AddItem":     func(v *VendingMachine) error { return v.AddItem(1) },
+		"AddItem(0)":  func(v *VendingMachine) error { return v.AddItem(0) },
+		"SelectItem":  func(v *VendingMachine) error { return v.SelectItem() },
+		"InsertMoney": func(v *VendingMachine) error { return v.InsertMoney(10) },
+		"InsertMoney(5)": func(v *VendingMachine) error {
+			return v.InsertMoney(5)
+		},
+		"InsertMoney(0)": func(v *VendingMachine) error {
+			return v.InsertMoney(0)
+		},
+		"Dispense": func(v *VendingMachine) error { return v.Dispense() },
+	}
+	tests := []struct {
+		state StateID
+		op    string
+		want  error
+	}{
+		{NoItem, "AddItem(0)", ErrInvalidCount},
+		{NoItem, "SelectItem", ErrOutOfStock},
+		{NoItem, "InsertMoney", ErrOutOfStock},
+		{NoItem, "Dispense", ErrOutOfStock},
+		{HasItem, "AddItem(0)", ErrInvalidCount},
+		{HasItem, "InsertMoney", ErrSelectItemFirst},
+		{HasItem, "Dispense", ErrSelectItemFirst},
+		{ItemRequested, "AddItem", ErrDispenseInProgress},
+		{ItemRequested, "SelectItem", ErrAlreadyRequested},
+		{ItemRequested, "InsertMoney(5)", ErrInsufficientMoney},
+		{ItemRequested, "InsertMoney(0)", ErrInvalidMoney},
+		{ItemRequested, "Dispense", ErrInsertMoneyFirst},
+		{HasMoney, "AddItem", ErrDispenseInProgress},
+		{HasMoney, "SelectItem", ErrDispenseInProgress},
+		{HasMoney, "InsertMoney", ErrMoneyAlreadyPresent},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.state)+"/"+tt.op, func(t *testing.T) {
+			v := machineIn(t, tt.state)
+			count := v.ItemCount()
+			err := ops[tt.op](v)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("%s() = %v, want %v", tt.op, err, tt.want)
+			}
+			if got := currentID(v); got != tt.state {
+				t.Fatalf("state = %s after a refused %s, want %s", got, tt.op, tt.state)
+			}
+			if v.ItemCount() != count {
+				t.Fatalf("ItemCount() = %d after a refused %s, want %d", v.ItemCount(), tt.op, count)
+			}
+		})
+	}
+}
+
+func TestErrorsNameThePackage(t *testing.T) {
+	for _, err := range []error{
+		ErrOutOfStock, ErrSelectItemFirst, ErrAlreadyRequested, ErrDispenseInProgress,
+		ErrInsertMoneyFirst, ErrInsufficientMoney, ErrMoneyAlreadyPresent, ErrInvalidCount, ErrInvalidMoney,
+		ErrInvalidTransition, ErrUnreachableState, ErrDeadEnd,
+		ErrUnknownState, ErrInvalidSnapshot, ErrForbidden, ErrReasonRequired,
+		ErrQueueFull, ErrStopped,
+	} {
+		if !strings.HasPrefix(err.Error(), "state: ") {
+			t.Errorf("%q is not prefixed with \"state: \"", err)
+		}
+	}
+}
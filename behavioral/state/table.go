@@ -0,0 +1,156 @@
+package state
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+var (
+	ErrInvalidTransition = errors.New("state: invalid transition")
+	ErrUnreachableState  = errors.New("state: state is unreachable")
+	ErrDeadEnd           = errors.New("state: state has no way out")
+)
+
+// Key identifies a row of a TransitionTable: an event arriving in a state.
+type Key[S, E comparable] struct {
+	State S
+	Event E
+}
+
+// Transition is one possible outcome of a Key. Guard may reject it by
+// returning an error; Action runs once it is taken, before the state changes.
+// Both receive the payload the event was fired with and may be nil.
+//
+// A transition with Refuse set is never taken: it rejects the event with that
+// error, so a table can say why an event is wrong in a state instead of
+// falling back to ErrInvalidTransition. To is ignored for it.
+type Transition[S comparable, P any] struct {
+	To     S
+	Guard  func(payload P) error
+	Action func(payload P)
+	Refuse error
+}
+
+// TransitionTable keeps every rule of a machine in one place instead of
+// scattering them over state methods. A key may list several transitions;
+// they are tried in order and the first whose guard passes wins.
+type TransitionTable[S, E comparable, P any] map[Key[S, E]][]Transition[S, P]
+
+// Validate checks that every state in the table can be reached from initial
+// and that every state except the terminal ones has a transition out of it.
+// Guards are ignored, so a state only reachable through a guard that never
+// passes is not reported. Refusals are not ways out. All findings are joined
+// into one error.
+func (t TransitionTable[S, E, P]) Validate(initial S, terminal ...S) error {
+	outgoing := make(map[S][]S)
+	states := map[S]bool{initial: true}
+	for key, transitions := range t {
+		states[key.State] = true
+		for _, tr := range transitions {
+			if tr.Refuse != nil {
+				continue
+			}
+			states[tr.To] = true
+			outgoing[key.State] = append(outgoing[key.State], tr.To)
+		}
+	}
+
+	reached := map[S]bool{initial: true}
+	queue := []S{initial}
+	for len(queue) > 0 {
+		s := queue[0]
+		queue = queue[1:]
+		for _, next := range outgoing[s] {
+			if !reached[next] {
+				reached[next] = true
+				queue = append(queue, next)
+			}
+		}
+	}
+
+	final := make(map[S]bool, len(terminal))
+	for _, s := range terminal {
+		final[s] = true
+	}
+
+	errs := make([]error, 0)
+	for _, s := range sorted(states) {
+		if !reached[s] {
+			errs = append(errs, fmt.Errorf("%w: %v", ErrUnreachableState, s))
+		}
+		if len(outgoing[s]) == 0 && !final[s] {
+			errs = append(errs, fmt.Errorf("%w: %v", ErrDeadEnd, s))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// sorted orders states by their printed form so findings come out the same way every time.
+func sorted[S comparable](states map[S]bool) []S {
+	list := make([]S, 0, len(states))
+	for s := range states {
+		list = append(list, s)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return fmt.Sprint(list[i]) < fmt.Sprint(list[j])
+	})
+	return list
+}
+
+// Machine is the generic driver for a TransitionTable. It holds nothing but
+// the current state; whatever data the guards and actions need lives in
+// their closures.
+type Machine[S, E comparable, P any] struct {
+	table   TransitionTable[S, E, P]
+	current S
+}
+
+// NewMachine validates table before using it; see TransitionTable.Validate.
+func NewMachine[S, E comparable, P any](table TransitionTable[S, E, P], initial S, terminal ...S) (*Machine[S, E, P], error) {
+	if err := table.Validate(initial, terminal...); err != nil {
+		return nil, err
+	}
+	return &Machine[S, E, P]{
+		table:   table,
+		current: initial,
+	}, nil
+}
+
+func (m *Machine[S, E, P]) Current() S {
+	return m.current
+}
+
+// Fire takes the first transition for event whose guard accepts payload.
+// An event the table doesn't declare for the current state fails with
+// ErrInvalidTransition; if every guard or refusal rejects, the first
+// rejection is returned. Either way the state is left alone.
+func (m *Machine[S, E, P]) Fire(event E, payload P) error {
+	transitions, ok := m.table[Key[S, E]{State: m.current, Event: event}]
+	if !ok || len(transitions) == 0 {
+		return fmt.Errorf("%w: %v in state %v", ErrInvalidTransition, event, m.current)
+	}
+	var rejected error
+	for _, tr := range transitions {
+		if tr.Refuse != nil {
+			if rejected == nil {
+				rejected = tr.Refuse
+			}
+			continue
+		}
+		if tr.Guard != nil {
+			if err := tr.Guard(payload); err != nil {
+				if rejected == nil {
+					rejected = err
+				}
+				continue
+			}
+		}
+		if tr.Action != nil {
+			tr.Action(payload)
+		}
+		m.current = tr.To
+		return nil
+	}
+	return rejected
+}
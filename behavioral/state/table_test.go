@@ -0,0 +1,153 @@
+package state
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+type light string
+
+type press string
+
+func TestValidateFindings(t *testing.T) {
+	refused := errors.New("refused")
+	tests := []struct {
+		name     string
+		table    TransitionTable[light, press, int]
+		terminal []light
+		want     []error
+		wantText []string
+	}{
+		{
+			name: "cycle is valid",
+			table: TransitionTable[light, press, int]{
+				{"off", "toggle"}: {{To: "on"}},
+				{"on", "toggle"}:  {{To: "off"}},
+			},
+		},
+		{
+			name: "unreachable state",
+			table: TransitionTable[light, press, int]{
+				{"off", "toggle"}:    {{To: "on"}},
+				{"on", "toggle"}:     {{To: "off"}},
+				{"broken", "repair"}: {{To: "off"}},
+			},
+			want:     []error{ErrUnreachableState},
+			wantText: []string{"broken"},
+		},
+		{
+			name: "dead end",
+			table: TransitionTable[light, press, int]{
+				{"off", "toggle"}: {{To: "on"}},
+				{"on", "smash"}:   {{To: "broken"}},
+			},
+			want:     []error{ErrDeadEnd},
+			wantText: []string{"broken"},
+		},
+		{
+			name: "terminal states may be dead ends",
+			table: TransitionTable[light, press, int]{
+				{"off", "toggle"}: {{To: "on"}},
+				{"on", "smash"}:   {{To: "broken"}},
+			},
+			terminal: []light{"broken"},
+		},
+		{
+			name: "refusals are not ways out",
+			table: TransitionTable[light, press, int]{
+				{"off", "toggle"}: {{To: "on"}},
+				{"on", "toggle"}:  {{Refuse: refused, To: "off"}},
+			},
+			want:     []error{ErrDeadEnd},
+			wantText: []string{"on"},
+		},
+		{
+			name: "every finding is reported",
+			table: TransitionTable[light, press, int]{
+				{"off", "smash"}:     {{To: "broken"}},
+				{"dimmed", "toggle"}: {{To: "off"}},
+			},
+			want:     []error{ErrUnreachableState, ErrDeadEnd},
+			wantText: []string{"dimmed", "broken"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.table.Validate("off", tt.terminal...)
+			if len(tt.want) == 0 {
+				if err != nil {
+					t.Fatalf("Validate() = %v, want nil", err)
+				}
+				return
+			}
+			for _, want := range tt.want {
+				if !errors.Is(err, want) {
+					t.Fatalf("Validate() = %v, want it to wrap %v", err, want)
+				}
+			}
+			for _, text := range tt.wantText {
+				if err == nil || !strings.Contains(err.Error(), text) {
+					t.Fatalf("Validate() = %v, want it to name %q", err, text)
+				}
+			}
+			if _, err := NewMachine(tt.table, "off", tt.terminal...); err == nil {
+				t.Fatal("NewMachine accepted a table Validate rejects")
+			}
+		})
+	}
+}
+
+func TestFire(t *testing.T) {
+	tooHeavy := errors.New("too heavy")
+	refused := errors.New("already lit")
+	var log []string
+	table := TransitionTable[light, press, int]{
+		{"off", "toggle"}: {
+			{To: "dimmed", Guard: func(level int) error {
+				if level > 5 {
+					return tooHeavy
+				}
+				return errors.New("second guard")
+			}},
+			{To: "on", Guard: func(level int) error {
+				if level > 9 {
+					return errors.New("first rejection wins")
+				}
+				return nil
+			}, Action: func(level int) {
+				log = append(log, "lighting")
+			}},
+		},
+		{"on", "toggle"}:     {{To: "off"}},
+		{"on", "light"}:      {{Refuse: refused}},
+		{"dimmed", "toggle"}: {{To: "off"}},
+	}
+	m, err := NewMachine(table, "off")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := m.Fire("light", 1); !errors.Is(err, ErrInvalidTransition) ||
+		!strings.Contains(err.Error(), "light") || !strings.Contains(err.Error(), "off") {
+		t.Fatalf("Fire(light) = %v, want ErrInvalidTransition naming the event and state", err)
+	}
+	if err := m.Fire("toggle", 10); !errors.Is(err, tooHeavy) {
+		t.Fatalf("Fire(toggle, 10) = %v, want the first rejection", err)
+	}
+	if m.Current() != "off" {
+		t.Fatalf("Current() = %s after a rejection, want off", m.Current())
+	}
+	if err := m.Fire("toggle", 1); err != nil {
+		t.Fatal(err)
+	}
+	if m.Current() != "on" || len(log) != 1 {
+		t.Fatalf("Current() = %s with actions %v, want on after lighting", m.Current(), log)
+	}
+	if err := m.Fire("light", 1); err != refused {
+		t.Fatalf("Fire(light) in on = %v, want the refusal", err)
+	}
+	if m.Current() != "on" {
+		t.Fatalf("Current() = %s after a refusal, want on", m.Current())
+	}
+}
@@ -0,0 +1,110 @@
+package state
+
+import (
+	"fmt"
+	"log"
+	"time"
+)
+
+// Clock schedules the machine's timeout events. Tests swap in a fake one to
+// trigger timeouts without waiting.
+type Clock interface {
+	AfterFunc(d time.Duration, f func()) Timer
+}
+
+type Timer interface {
+	Stop() bool
+}
+
+type realClock struct{}
+
+func (realClock) AfterFunc(d time.Duration, f func()) Timer {
+	return time.AfterFunc(d, f)
+}
+
+// Timeouter is implemented by states that schedule a timeout on entry. The
+// machine calls OnTimeout when it fires before anything else moved it on.
+type Timeouter interface {
+	OnTimeout() error
+}
+
+type MachineOption func(v *VendingMachine)
+
+func WithClock(c Clock) MachineOption {
+	return func(v *VendingMachine) {
+		v.clock = c
+	}
+}
+
+// WithRefundAfter gives the customer d to take their item once the money is
+// in. After that the money is returned and the item has to be selected again.
+func WithRefundAfter(d time.Duration) MachineOption {
+	return func(v *VendingMachine) {
+		v.refundAfter = d
+	}
+}
+
+// timeout is one scheduled timeout. Its identity tells a timer that fired
+// just as it was being stopped that it is stale.
+type timeout struct {
+	timer Timer
+}
+
+// scheduleTimeout is meant for OnEnter. The timeout belongs to the state being
+// entered and is stopped as soon as the machine leaves it.
+func (v *VendingMachine) scheduleTimeout(d time.Duration) {
+	t := &timeout{}
+	t.timer = v.clock.AfterFunc(d, func() {
+		v.fireTimeout(t)
+	})
+	v.pending = t
+}
+
+func (v *VendingMachine) fireTimeout(t *timeout) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if v.pending != t {
+		return
+	}
+	v.pending = nil
+	timeouter, ok := v.currentState.(Timeouter)
+	if !ok {
+		return
+	}
+	if err := timeouter.OnTimeout(); err != nil {
+		log.Println(err)
+	}
+}
+
+func (v *VendingMachine) cancelTimeout(t *timeout) {
+	if t != nil {
+		t.timer.Stop()
+	}
+}
+
+// armer is implemented by states whose timeout runs for as long as they are
+// current. Load uses it to start the timeout of a restored state without
+// running any hooks.
+type armer interface {
+	armTimeout()
+}
+
+func (s *HasMoneyState) OnEnter(event Event) error {
+	s.armTimeout()
+	return nil
+}
+
+func (s *HasMoneyState) armTimeout() {
+	if s.machine.refundAfter > 0 {
+		s.machine.scheduleTimeout(s.machine.refundAfter)
+	}
+}
+
+func (s *HasMoneyState) OnTimeout() error {
+	if err := s.machine.setState(s.machine.hasItem, TimeoutEvent); err != nil {
+		return err
+	}
+	fmt.Printf("Returning money %d\n", s.machine.money)
+	s.machine.money = 0
+	return nil
+}
@@ -0,0 +1,224 @@
+package state
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock fires timers only when the test advances it.
+type fakeClock struct {
+	mu     sync.Mutex
+	now    time.Duration
+	timers []*fakeTimer
+}
+
+type fakeTimer struct {
+	clock   *fakeClock
+	at      time.Duration
+	f       func()
+	stopped bool
+	fired   bool
+}
+
+func (c *fakeClock) AfterFunc(d time.Duration, f func()) Timer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	t := &fakeTimer{clock: c, at: c.now + d, f: f}
+	c.timers = append(c.timers, t)
+	return t
+}
+
+func (t *fakeTimer) Stop() bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	active := !t.stopped && !t.fired
+	t.stopped = true
+	return active
+}
+
+// Advance moves time on by d and runs every timer that came due, outside the
+// clock's lock since they call back into the machine.
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	c.now += d
+	var due []func()
+	for _, t := range c.timers {
+		if !t.stopped && !t.fired && t.at <= c.now {
+			t.fired = true
+			due = append(due, t.f)
+		}
+	}
+	c.mu.Unlock()
+	for _, f := range due {
+		f()
+	}
+}
+
+// active counts timers that are neither stopped nor fired.
+func (c *fakeClock) active() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	n := 0
+	for _, t := range c.timers {
+		if !t.stopped && !t.fired {
+			n++
+		}
+	}
+	return n
+}
+
+func refundingMachine(clock *fakeClock, opts ...MachineOption) *VendingMachine {
+	opts = append([]MachineOption{WithClock(clock), WithRefundAfter(time.Minute)}, opts...)
+	return NewVendingMachine(3, 10, opts...)
+}
+
+func TestTimeoutRefunds(t *testing.T) {
+	clock := &fakeClock{}
+	var events []Event
+	v := refundingMachine(clock, WithEnterHook(HasItem, func(e Event) error {
+		events = append(events, e)
+		return nil
+	}))
+	if err := v.SelectItem(); err != nil {
+		t.Fatal(err)
+	}
+	if err := v.InsertMoney(10); err != nil {
+		t.Fatal(err)
+	}
+
+	clock.Advance(59 * time.Second)
+	if v.StateID() != HasMoney {
+		t.Fatalf("StateID() = %s before the timeout, want %s", v.StateID(), HasMoney)
+	}
+	clock.Advance(time.Second)
+	if v.StateID() != HasItem {
+		t.Fatalf("StateID() = %s after the timeout, want %s", v.StateID(), HasItem)
+	}
+	if len(events) != 1 || events[0] != TimeoutEvent {
+		t.Fatalf("entered HasItem on %v, want the timeout event", events)
+	}
+	// the money went back, so the next customer pays in full
+	if err := v.SelectItem(); err != nil {
+		t.Fatal(err)
+	}
+	if err := v.Dispense(); err == nil {
+		t.Fatal("Dispense() succeeded with the refunded money")
+	}
+	if v.ItemCount() != 3 {
+		t.Fatalf("ItemCount() = %d after a refund, want 3", v.ItemCount())
+	}
+}
+
+func TestTimeoutCancelledWhenCustomerActs(t *testing.T) {
+	clock := &fakeClock{}
+	v := refundingMachine(clock)
+	if err := v.SelectItem(); err != nil {
+		t.Fatal(err)
+	}
+	if err := v.InsertMoney(10); err != nil {
+		t.Fatal(err)
+	}
+	clock.Advance(30 * time.Second)
+	if err := v.Dispense(); err != nil {
+		t.Fatal(err)
+	}
+	if n := clock.active(); n != 0 {
+		t.Fatalf("%d timers still running after Dispense", n)
+	}
+	clock.Advance(time.Hour)
+	if v.StateID() != HasItem || v.ItemCount() != 2 {
+		t.Fatalf("machine is %s with %d items, want the sale to stand", v.StateID(), v.ItemCount())
+	}
+}
+
+func TestNoTimersLeakAcrossCycles(t *testing.T) {
+	clock := &fakeClock{}
+	v := NewVendingMachine(1000, 10, WithClock(clock), WithRefundAfter(time.Minute))
+	for i := 0; i < 500; i++ {
+		if err := v.SelectItem(); err != nil {
+			t.Fatal(err)
+		}
+		if err := v.InsertMoney(10); err != nil {
+			t.Fatal(err)
+		}
+		if i%2 == 0 {
+			clock.Advance(time.Minute)
+		} else if err := v.Dispense(); err != nil {
+			t.Fatal(err)
+		}
+		if n := clock.active(); n != 0 {
+			t.Fatalf("cycle %d left %d timers running", i, n)
+		}
+	}
+	if v.ItemCount() != 750 {
+		t.Fatalf("ItemCount() = %d, want 750 after 250 sales", v.ItemCount())
+	}
+}
+
+func TestFailedEntryStopsItsTimeout(t *testing.T) {
+	clock := &fakeClock{}
+	v := refundingMachine(clock, WithEnterHook(HasMoney, func(Event) error {
+		return ErrDispenseInProgress
+	}))
+	if err := v.SelectItem(); err != nil {
+		t.Fatal(err)
+	}
+	if err := v.InsertMoney(10); err == nil {
+		t.Fatal("InsertMoney() succeeded with a failing enter hook")
+	}
+	if n := clock.active(); n != 0 {
+		t.Fatalf("%d timers running after the transition was aborted", n)
+	}
+}
+
+func TestLoadRearmsTimeout(t *testing.T) {
+	clock := &fakeClock{}
+	v := refundingMachine(clock)
+	if err := v.SelectItem(); err != nil {
+		t.Fatal(err)
+	}
+	if err := v.InsertMoney(10); err != nil {
+		t.Fatal(err)
+	}
+	saved, err := v.Save()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	restored := refundingMachine(clock)
+	if err := restored.Load(saved); err != nil {
+		t.Fatal(err)
+	}
+	if n := clock.active(); n != 2 {
+		t.Fatalf("%d timers running, want one per machine", n)
+	}
+	// loading again replaces the timer instead of adding one
+	if err := restored.Load(saved); err != nil {
+		t.Fatal(err)
+	}
+	if n := clock.active(); n != 2 {
+		t.Fatalf("%d timers running after a second Load, want 2", n)
+	}
+	clock.Advance(time.Minute)
+	if restored.StateID() != HasItem {
+		t.Fatalf("restored machine is %s after the timeout, want %s", restored.StateID(), HasItem)
+	}
+
+	// loading a state without a timeout stops the pending one
+	if err := v.Load([]byte(`{"state":"has item","itemCount":1,"itemPrice":10}`)); err != nil {
+		t.Fatal(err)
+	}
+	if err := v.SelectItem(); err != nil {
+		t.Fatal(err)
+	}
+	if err := v.InsertMoney(10); err != nil {
+		t.Fatal(err)
+	}
+	if err := v.Load([]byte(`{"state":"has item","itemCount":1,"itemPrice":10}`)); err != nil {
+		t.Fatal(err)
+	}
+	if n := clock.active(); n != 0 {
+		t.Fatalf("%d timers running after loading a state without one", n)
+	}
+}
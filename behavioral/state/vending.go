@@ -0,0 +1,167 @@
+package state
+
+import (
+	"errors"
+	"fmt"
+)
+
+// StateID names a state of the table-driven vending machine.
+type StateID string
+
+const (
+	NoItem        StateID = "no item"
+	HasItem       StateID = "has item"
+	ItemRequested StateID = "item requested"
+	HasMoney      StateID = "has money"
+)
+
+// Event is what a table-driven machine reacts to.
+type Event string
+
+const (
+	AddItemEvent     Event = "add item"
+	SelectItemEvent  Event = "select item"
+	InsertMoneyEvent Event = "insert money"
+	DispenseEvent    Event = "dispense"
+	// TimeoutEvent is fed by the machine itself when a state's timeout fires.
+	TimeoutEvent Event = "timeout"
+)
+
+// errLastItem sends the last dispense to NoItem; callers never see it.
+var errLastItem = errors.New("state: last item")
+
+// TableVendingMachine behaves like VendingMachine but its rules are one
+// TransitionTable instead of four state types. The payload of an event is
+// the item count or amount of money it carries, zero when there is none.
+// Operations the hand-written states refuse are refusal rows in the table
+// and fail with the same errors.
+type TableVendingMachine struct {
+	machine *Machine[StateID, Event, int]
+
+	itemCount int
+	itemPrice int
+	money     int
+}
+
+func NewTableVendingMachine(itemCount, itemPrice int) (*TableVendingMachine, error) {
+	v := &TableVendingMachine{
+		itemCount: itemCount,
+		itemPrice: itemPrice,
+	}
+	initial := HasItem
+	if itemCount <= 0 {
+		v.itemCount = 0
+		initial = NoItem
+	}
+	machine, err := NewMachine(v.table(), initial)
+	if err != nil {
+		return nil, err
+	}
+	v.machine = machine
+	return v, nil
+}
+
+func (v *TableVendingMachine) table() TransitionTable[StateID, Event, int] {
+	addItem := Transition[StateID, int]{
+		To: HasItem,
+		Guard: func(count int) error {
+			if count <= 0 {
+				return fmt.Errorf("%w: %d", ErrInvalidCount, count)
+			}
+			return nil
+		},
+		Action: func(count int) {
+			fmt.Printf("Adding %d items\n", count)
+			v.itemCount += count
+		},
+	}
+	refuse := func(err error) []Transition[StateID, int] {
+		return []Transition[StateID, int]{{Refuse: err}}
+	}
+	dispense := func(int) {
+		fmt.Println("Dispensing item")
+		if change := v.money - v.itemPrice; change > 0 {
+			fmt.Printf("Returning change %d\n", change)
+		}
+		v.money = 0
+		v.itemCount--
+	}
+
+	return TransitionTable[StateID, Event, int]{
+		{NoItem, AddItemEvent}:     {addItem},
+		{NoItem, SelectItemEvent}:  refuse(ErrOutOfStock),
+		{NoItem, InsertMoneyEvent}: refuse(ErrOutOfStock),
+		{NoItem, DispenseEvent}:    refuse(ErrOutOfStock),
+
+		{HasItem, AddItemEvent}:     {addItem},
+		{HasItem, InsertMoneyEvent}: refuse(ErrSelectItemFirst),
+		{HasItem, DispenseEvent}:    refuse(ErrSelectItemFirst),
+		{HasItem, SelectItemEvent}: {{
+			To: ItemRequested,
+			Action: func(int) {
+				fmt.Println("Item requested")
+			},
+		}},
+
+		{ItemRequested, AddItemEvent}:    refuse(ErrDispenseInProgress),
+		{ItemRequested, SelectItemEvent}: refuse(ErrAlreadyRequested),
+		{ItemRequested, DispenseEvent}:   refuse(ErrInsertMoneyFirst),
+		{ItemRequested, InsertMoneyEvent}: {{
+			To: HasMoney,
+			Guard: func(money int) error {
+				if err := checkMoney(money); err != nil {
+					return err
+				}
+				if money < v.itemPrice {
+					return fmt.Errorf("%w: inserted %d, please insert %d", ErrInsufficientMoney, money, v.itemPrice)
+				}
+				return nil
+			},
+			Action: func(money int) {
+				fmt.Println("Money entered is ok")
+				v.money = money
+			},
+		}},
+
+		{HasMoney, AddItemEvent}:     refuse(ErrDispenseInProgress),
+		{HasMoney, SelectItemEvent}:  refuse(ErrDispenseInProgress),
+		{HasMoney, InsertMoneyEvent}: refuse(ErrMoneyAlreadyPresent),
+		{HasMoney, DispenseEvent}: {
+			{
+				To: HasItem,
+				Guard: func(int) error {
+					if v.itemCount <= 1 {
+						return errLastItem
+					}
+					return nil
+				},
+				Action: dispense,
+			},
+			{To: NoItem, Action: dispense},
+		},
+	}
+}
+
+func (v *TableVendingMachine) AddItem(count int) error {
+	return v.machine.Fire(AddItemEvent, count)
+}
+
+func (v *TableVendingMachine) SelectItem() error {
+	return v.machine.Fire(SelectItemEvent, 0)
+}
+
+func (v *TableVendingMachine) InsertMoney(money int) error {
+	return v.machine.Fire(InsertMoneyEvent, money)
+}
+
+func (v *TableVendingMachine) Dispense() error {
+	return v.machine.Fire(DispenseEvent, 0)
+}
+
+func (v *TableVendingMachine) CurrentState() StateID {
+	return v.machine.Current()
+}
+
+func (v *TableVendingMachine) ItemCount() int {
+	return v.itemCount
+}
@@ -0,0 +1,101 @@
+package state
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// operation applies the same call to both vending machines.
+type operation struct {
+	name  string
+	hand  func(v *VendingMachine) error
+	table func(v *TableVendingMachine) error
+}
+
+func operations() []operation {
+	return []operation{
+		{"AddItem(2)", func(v *VendingMachine) error { return v.AddItem(2) }, func(v *TableVendingMachine) error { return v.AddItem(2) }},
+		{"AddItem(0)", func(v *VendingMachine) error { return v.AddItem(0) }, func(v *TableVendingMachine) error { return v.AddItem(0) }},
+		{"SelectItem", (*VendingMachine).SelectItem, (*TableVendingMachine).SelectItem},
+		{"InsertMoney(0)", func(v *VendingMachine) error { return v.InsertMoney(0) }, func(v *TableVendingMachine) error { return v.InsertMoney(0) }},
+		{"InsertMoney(5)", func(v *VendingMachine) error { return v.InsertMoney(5) }, func(v *TableVendingMachine) error { return v.InsertMoney(5) }},
+		{"InsertMoney(10)", func(v *VendingMachine) error { return v.InsertMoney(10) }, func(v *TableVendingMachine) error { return v.InsertMoney(10) }},
+		{"InsertMoney(25)", func(v *VendingMachine) error { return v.InsertMoney(25) }, func(v *TableVendingMachine) error { return v.InsertMoney(25) }},
+		{"Dispense", (*VendingMachine).Dispense, (*TableVendingMachine).Dispense},
+	}
+}
+
+func errText(err error) string {
+	if err == nil {
+		return "<nil>"
+	}
+	return err.Error()
+}
+
+func TestTableMachineMatchesHandWritten(t *testing.T) {
+	ops := operations()
+	rng := rand.New(rand.NewSource(1))
+	for run := 0; run < 50; run++ {
+		itemCount := rng.Intn(3)
+		hand := NewVendingMachine(itemCount, 10)
+		table, err := NewTableVendingMachine(itemCount, 10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var trace []string
+		for step := 0; step < 40; step++ {
+			op := ops[rng.Intn(len(ops))]
+			trace = append(trace, op.name)
+			handErr, tableErr := op.hand(hand), op.table(table)
+			if errText(handErr) != errText(tableErr) {
+				t.Fatalf("after %v: hand-written = %v, table = %v", trace, handErr, tableErr)
+			}
+			if currentID(hand) != table.CurrentState() || hand.ItemCount() != table.ItemCount() {
+				t.Fatalf("after %v: hand-written is %s with %d items, table is %s with %d",
+					trace, currentID(hand), hand.ItemCount(), table.CurrentState(), table.ItemCount())
+			}
+		}
+	}
+}
+
+func TestTableMachineRefusesLikeHandWritten(t *testing.T) {
+	for _, id := range []StateID{NoItem, HasItem, ItemRequested, HasMoney} {
+		for _, op := range operations() {
+			hand := machineIn(t, id)
+			table := tableMachineIn(t, id)
+			handErr, tableErr := op.hand(hand), op.table(table)
+			if errText(handErr) != errText(tableErr) {
+				t.Errorf("%s in %s: hand-written = %v, table = %v", op.name, id, handErr, tableErr)
+			}
+		}
+	}
+}
+
+func tableMachineIn(t *testing.T, id StateID) *TableVendingMachine {
+	t.Helper()
+	itemCount := 2
+	if id == NoItem {
+		itemCount = 0
+	}
+	v, err := NewTableVendingMachine(itemCount, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	switch id {
+	case HasMoney:
+		if err := v.SelectItem(); err != nil {
+			t.Fatal(err)
+		}
+		if err := v.InsertMoney(10); err != nil {
+			t.Fatal(err)
+		}
+	case ItemRequested:
+		if err := v.SelectItem(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if v.CurrentState() != id {
+		t.Fatalf("reached %s, want %s", v.CurrentState(), id)
+	}
+	return v
+}
@@ -0,0 +1,240 @@
+package strategy
+
+import (
+	"math"
+	"math/rand/v2"
+	"sync"
+	"time"
+)
+
+// StrategyStats is what an AdaptiveContext has observed of one strategy.
+type StrategyStats struct {
+	Runs    int
+	Errors  int
+	Latency time.Duration
+}
+
+func (s StrategyStats) MeanLatency() time.Duration {
+	if s.Runs == 0 {
+		return 0
+	}
+	return s.Latency / time.Duration(s.Runs)
+}
+
+func (s StrategyStats) ErrorRate() float64 {
+	if s.Runs == 0 {
+		return 0
+	}
+	return float64(s.Errors) / float64(s.Runs)
+}
+
+// cost is the expected time spent per successful run, so a fast strategy
+// that fails half the time loses to one twice as slow that never does.
+func (s StrategyStats) cost() float64 {
+	if s.Errors == s.Runs {
+		return math.Inf(1)
+	}
+	return float64(s.MeanLatency()) / (1 - s.ErrorRate())
+}
+
+// Decision is reported every time an AdaptiveContext picks a strategy,
+// whether or not it changed. Explored says the pick was a random one
+// rather than the best so far.
+type Decision struct {
+	Op       int
+	From, To string
+	Explored bool
+	Stats    map[string]StrategyStats
+}
+
+type adaptiveConfig struct {
+	every      int
+	epsilon    float64
+	random     func() float64
+	now        func() time.Time
+	onDecision func(Decision)
+}
+
+type AdaptiveOption func(c *adaptiveConfig)
+
+// SwitchEvery sets how many operations run between decisions. The default is 100.
+func SwitchEvery(n int) AdaptiveOption {
+	return func(c *adaptiveConfig) {
+		if n > 0 {
+			c.every = n
+		}
+	}
+}
+
+// Epsilon is the chance that a decision tries a random other strategy
+// instead of the best one, so a strategy that got better is noticed. The
+// default is 0.1.
+func Epsilon(e float64) AdaptiveOption {
+	return func(c *adaptiveConfig) {
+		c.epsilon = e
+	}
+}
+
+// WithRandom replaces rand.Float64 as the source of exploration.
+func WithRandom(fn func() float64) AdaptiveOption {
+	return func(c *adaptiveConfig) {
+		if fn != nil {
+			c.random = fn
+		}
+	}
+}
+
+// WithNow replaces time.Now for measuring latency.
+func WithNow(fn func() time.Time) AdaptiveOption {
+	return func(c *adaptiveConfig) {
+		if fn != nil {
+			c.now = fn
+		}
+	}
+}
+
+func OnDecision(fn func(Decision)) AdaptiveOption {
+	return func(c *adaptiveConfig) {
+		c.onDecision = fn
+	}
+}
+
+// AdaptiveContext picks its own strategy from a registry instead of being
+// told which one to use. It measures every run and, every few operations,
+// switches to the strategy with the lowest cost so far using an
+// epsilon-greedy rule. Strategies never run yet are tried before any
+// comparison is made. It is safe for concurrent use.
+type AdaptiveContext[In, Out any] struct {
+	registry *Registry[Fallible[In, Out]]
+	cfg      adaptiveConfig
+
+	mu      sync.Mutex
+	current string
+	ops     int
+	stats   map[string]StrategyStats
+}
+
+// NewAdaptiveContext starts on the registry's default strategy; see
+// Registry.SetDefault. Strategies registered later take part in the next
+// decision.
+func NewAdaptiveContext[In, Out any](registry *Registry[Fallible[In, Out]], opts ...AdaptiveOption) (*AdaptiveContext[In, Out], error) {
+	cfg := adaptiveConfig{
+		every:   100,
+		epsilon: 0.1,
+		random:  rand.Float64,
+		now:     time.Now,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	registry.mu.RLock()
+	current := registry.defaultTo
+	_, ok := registry.strategies[current]
+	registry.mu.RUnlock()
+	if !ok {
+		return nil, ErrNoDefault
+	}
+	return &AdaptiveContext[In, Out]{
+		registry: registry,
+		cfg:      cfg,
+		current:  current,
+		stats:    make(map[string]StrategyStats),
+	}, nil
+}
+
+// Execute runs the current strategy and records how it did.
+func (a *AdaptiveContext[In, Out]) Execute(in In) (Out, error) {
+	a.mu.Lock()
+	name := a.current
+	a.mu.Unlock()
+	s, err := a.registry.Get(name)
+	if err != nil {
+		var zero Out
+		return zero, err
+	}
+
+	start := a.cfg.now()
+	out, err := s.Try(in)
+	elapsed := a.cfg.now().Sub(start)
+
+	a.mu.Lock()
+	stats := a.stats[name]
+	stats.Runs++
+	stats.Latency += elapsed
+	if err != nil {
+		stats.Errors++
+	}
+	a.stats[name] = stats
+	a.ops++
+	var decision *Decision
+	if a.ops%a.cfg.every == 0 {
+		decision = a.decide()
+	}
+	a.mu.Unlock()
+
+	if decision != nil && a.cfg.onDecision != nil {
+		a.cfg.onDecision(*decision)
+	}
+	return out, err
+}
+
+// decide picks the next strategy. The caller holds a.mu.
+func (a *AdaptiveContext[In, Out]) decide() *Decision {
+	names := a.registry.Names()
+	d := &Decision{
+		Op:    a.ops,
+		From:  a.current,
+		To:    a.current,
+		Stats: a.snapshot(),
+	}
+	others := make([]string, 0, len(names))
+	for _, name := range names {
+		if name != a.current {
+			others = append(others, name)
+		}
+	}
+	if len(others) > 0 && a.cfg.random() < a.cfg.epsilon {
+		d.To = others[int(a.cfg.random()*float64(len(others)))%len(others)]
+		d.Explored = true
+	} else {
+		d.To = a.best(names)
+	}
+	a.current = d.To
+	return d
+}
+
+func (a *AdaptiveContext[In, Out]) best(names []string) string {
+	best, cost := a.current, a.stats[a.current].cost()
+	for _, name := range names {
+		stats, ok := a.stats[name]
+		if !ok {
+			return name
+		}
+		if c := stats.cost(); c < cost {
+			best, cost = name, c
+		}
+	}
+	return best
+}
+
+// Current names the strategy the next operation will use.
+func (a *AdaptiveContext[In, Out]) Current() string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.current
+}
+
+// Stats returns a copy of what has been observed so far, per strategy.
+func (a *AdaptiveContext[In, Out]) Stats() map[string]StrategyStats {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.snapshot()
+}
+
+func (a *AdaptiveContext[In, Out]) snapshot() map[string]StrategyStats {
+	stats := make(map[string]StrategyStats, len(a.stats))
+	for name, s := range a.stats {
+		stats[name] = s
+	}
+	return stats
+}
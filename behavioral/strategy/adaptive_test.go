@@ -0,0 +1,175 @@
+package strategy
+
+import (
+	"errors"
+	"math/rand/v2"
+	"sync"
+	"testing"
+	"time"
+)
+
+// profile is a fake strategy that takes latency on the shared fake clock
+// and fails once every failEvery runs, if failEvery is set.
+type profile struct {
+	clock     *fakeNow
+	latency   time.Duration
+	failEvery int
+	runs      int
+}
+
+func (p *profile) Try(int) (int, error) {
+	p.runs++
+	p.clock.advance(p.latency)
+	if p.failEvery > 0 && p.runs%p.failEvery == 0 {
+		return 0, errDeclined
+	}
+	return p.runs, nil
+}
+
+type fakeNow struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *fakeNow) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeNow) advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func adaptiveRegistry(t *testing.T, profiles map[string]*profile, start string) *Registry[Fallible[int, int]] {
+	t.Helper()
+	r := NewRegistry[Fallible[int, int]]()
+	for name, p := range profiles {
+		if err := r.Register(name, p); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := r.SetDefault(start); err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
+
+func TestAdaptiveConvergesOnFasterStrategy(t *testing.T) {
+	clock := &fakeNow{}
+	profiles := map[string]*profile{
+		"slow": {clock: clock, latency: 10 * time.Millisecond},
+		"fast": {clock: clock, latency: time.Millisecond},
+	}
+	var decisions []Decision
+	rng := rand.New(rand.NewPCG(1, 2))
+	a, err := NewAdaptiveContext(adaptiveRegistry(t, profiles, "slow"),
+		SwitchEvery(10), Epsilon(0.2), WithRandom(rng.Float64), WithNow(clock.Now),
+		OnDecision(func(d Decision) {
+			decisions = append(decisions, d)
+		}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 1000; i++ {
+		if _, err := a.Execute(i); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if len(decisions) != 100 {
+		t.Fatalf("%d decisions, want one every 10 operations", len(decisions))
+	}
+	if d := decisions[0]; d.To != "fast" || d.Explored {
+		t.Fatalf("first decision = %+v, want to try the untried strategy", d)
+	}
+	explored := 0
+	for _, d := range decisions[1:] {
+		if d.Explored {
+			explored++
+			if d.To != "slow" && d.From == "fast" {
+				t.Fatalf("exploring from fast picked %q", d.To)
+			}
+		} else if d.To != "fast" {
+			t.Fatalf("greedy decision %+v did not pick the faster strategy", d)
+		}
+	}
+	if explored == 0 {
+		t.Fatal("never explored")
+	}
+	if profiles["fast"].runs < 4*profiles["slow"].runs {
+		t.Fatalf("fast ran %d times, slow %d; want fast to dominate", profiles["fast"].runs, profiles["slow"].runs)
+	}
+	stats := a.Stats()
+	if got := stats["fast"].MeanLatency(); got != time.Millisecond {
+		t.Fatalf("fast MeanLatency() = %v, want 1ms", got)
+	}
+}
+
+func TestAdaptiveWeighsErrors(t *testing.T) {
+	clock := &fakeNow{}
+	profiles := map[string]*profile{
+		// 1ms per run, but every other run fails: 2ms per success
+		"flaky":    {clock: clock, latency: time.Millisecond, failEvery: 2},
+		"reliable": {clock: clock, latency: 1500 * time.Microsecond},
+	}
+	a, err := NewAdaptiveContext(adaptiveRegistry(t, profiles, "flaky"),
+		SwitchEvery(10), Epsilon(0), WithNow(clock.Now))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 100; i++ {
+		_, _ = a.Execute(i)
+	}
+
+	if got := a.Current(); got != "reliable" {
+		t.Fatalf("Current() = %q, want reliable", got)
+	}
+	stats := a.Stats()
+	if got := stats["flaky"].ErrorRate(); got != 0.5 {
+		t.Fatalf("flaky ErrorRate() = %v, want 0.5", got)
+	}
+	if stats["flaky"].Runs != 10 {
+		t.Fatalf("flaky ran %d times without exploration, want only its first 10", stats["flaky"].Runs)
+	}
+}
+
+func TestAdaptiveNeedsADefault(t *testing.T) {
+	_, err := NewAdaptiveContext(NewRegistry[Fallible[int, int]]())
+	if !errors.Is(err, ErrNoDefault) {
+		t.Fatalf("NewAdaptiveContext() error = %v, want %v", err, ErrNoDefault)
+	}
+}
+
+func TestAdaptiveConcurrentUse(t *testing.T) {
+	r := NewRegistry[Fallible[int, int]]()
+	_ = r.Register("a", TryFunc[int, int](func(v int) (int, error) { return v, nil }))
+	_ = r.Register("b", TryFunc[int, int](func(v int) (int, error) { return -v, nil }))
+	_ = r.SetDefault("a")
+	a, err := NewAdaptiveContext(r, SwitchEvery(3))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				_, _ = a.Execute(i)
+				_ = a.Stats()
+			}
+		}()
+	}
+	wg.Wait()
+
+	runs := 0
+	for _, s := range a.Stats() {
+		runs += s.Runs
+	}
+	if runs != 400 {
+		t.Fatalf("recorded %d runs, want 400", runs)
+	}
+}
@@ -0,0 +1,116 @@
+package strategy
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrNoStrategies = errors.New("strategy: composite has no strategies")
+
+// Fallible is a strategy that can fail, which is what a CompositeStrategy
+// chains.
+type Fallible[In, Out any] interface {
+	Try(in In) (Out, error)
+}
+
+// TryFunc lets a function be a Fallible strategy.
+type TryFunc[In, Out any] func(in In) (Out, error)
+
+func (f TryFunc[In, Out]) Try(in In) (Out, error) {
+	return f(in)
+}
+
+// Attempt is one strategy's turn in a CompositeStrategy: its position in
+// the chain and the error it failed with, nil for the one that succeeded.
+type Attempt struct {
+	Index int
+	Err   error
+}
+
+// ChainError is returned when every strategy in a chain failed with an
+// error worth falling through on.
+type ChainError struct {
+	Attempts []Attempt
+}
+
+func (e *ChainError) Error() string {
+	return fmt.Sprintf("strategy: all %d strategies failed: %v", len(e.Attempts), errors.Join(e.Unwrap()...))
+}
+
+func (e *ChainError) Unwrap() []error {
+	errs := make([]error, len(e.Attempts))
+	for i, a := range e.Attempts {
+		errs[i] = a.Err
+	}
+	return errs
+}
+
+// CompositeStrategy tries its strategies in order until one succeeds. An
+// error that retryable rejects stops the chain and is returned as it is;
+// a nil retryable falls through on every error. The composite is Fallible
+// itself, so chains nest.
+type CompositeStrategy[In, Out any] struct {
+	strategies []Fallible[In, Out]
+	retryable  func(err error) bool
+}
+
+func NewCompositeStrategy[In, Out any](retryable func(err error) bool, strategies ...Fallible[In, Out]) *CompositeStrategy[In, Out] {
+	return &CompositeStrategy[In, Out]{
+		strategies: strategies,
+		retryable:  retryable,
+	}
+}
+
+// Run returns the first success along with a record of every attempt made.
+func (c *CompositeStrategy[In, Out]) Run(in In) (Out, []Attempt, error) {
+	var zero Out
+	attempts := make([]Attempt, 0, len(c.strategies))
+	if len(c.strategies) == 0 {
+		return zero, attempts, ErrNoStrategies
+	}
+	for i, s := range c.strategies {
+		out, err := s.Try(in)
+		attempts = append(attempts, Attempt{Index: i, Err: err})
+		if err == nil {
+			return out, attempts, nil
+		}
+		if c.retryable != nil && !c.retryable(err) {
+			return zero, attempts, err
+		}
+	}
+	return zero, attempts, &ChainError{Attempts: attempts}
+}
+
+func (c *CompositeStrategy[In, Out]) Try(in In) (Out, error) {
+	out, _, err := c.Run(in)
+	return out, err
+}
+
+// Payment is the input of a payment chain.
+type Payment struct {
+	Amount   int
+	Currency string
+}
+
+// PaymentAttempt puts a PaymentStrategy in a chain. A currency it doesn't
+// support fails the attempt with a CurrencyError, so the chain can move on
+// to a method that does.
+func PaymentAttempt(s PaymentStrategy) Fallible[Payment, Receipt] {
+	return TryFunc[Payment, Receipt](func(p Payment) (Receipt, error) {
+		if !s.Supports(p.Currency) {
+			return Receipt{}, &CurrencyError{Currency: p.Currency}
+		}
+		receipt, err := s.Pay(p.Amount)
+		if err != nil {
+			return Receipt{}, err
+		}
+		receipt.Currency = p.Currency
+		return receipt, nil
+	})
+}
+
+// RetryablePayment falls through on anything but an invalid amount, which
+// no other payment method would accept either.
+func RetryablePayment(err error) bool {
+	return !errors.Is(err, ErrInvalidAmount)
+}
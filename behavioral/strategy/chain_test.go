@@ -0,0 +1,146 @@
+package strategy
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+var (
+	errDeclined = errors.New("declined")
+	errFraud    = errors.New("fraud suspected")
+)
+
+// scripted returns a strategy that fails with err, or succeeds with name
+// when err is nil, and counts its calls.
+func scripted(name string, err error, calls *[]string) Fallible[int, string] {
+	return TryFunc[int, string](func(int) (string, error) {
+		*calls = append(*calls, name)
+		return name, err
+	})
+}
+
+func notFraud(err error) bool {
+	return !errors.Is(err, errFraud)
+}
+
+func TestCompositeFallsThrough(t *testing.T) {
+	tests := []struct {
+		name         string
+		errs         []error
+		want         string
+		wantAttempts []Attempt
+		wantErr      error
+	}{
+		{
+			name:         "first succeeds",
+			errs:         []error{nil, nil},
+			want:         "s0",
+			wantAttempts: []Attempt{{0, nil}},
+		},
+		{
+			name:         "last succeeds",
+			errs:         []error{errDeclined, errDeclined, nil},
+			want:         "s2",
+			wantAttempts: []Attempt{{0, errDeclined}, {1, errDeclined}, {2, nil}},
+		},
+		{
+			name:         "fatal error stops the chain",
+			errs:         []error{errDeclined, errFraud, nil},
+			wantAttempts: []Attempt{{0, errDeclined}, {1, errFraud}},
+			wantErr:      errFraud,
+		},
+		{
+			name:         "all fail",
+			errs:         []error{errDeclined, errDeclined},
+			wantAttempts: []Attempt{{0, errDeclined}, {1, errDeclined}},
+			wantErr:      errDeclined,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls []string
+			strategies := make([]Fallible[int, string], len(tt.errs))
+			for i, err := range tt.errs {
+				strategies[i] = scripted("s"+string(rune('0'+i)), err, &calls)
+			}
+			got, attempts, err := NewCompositeStrategy(notFraud, strategies...).Run(1)
+			if !errors.Is(err, tt.wantErr) || (err == nil) != (tt.wantErr == nil) {
+				t.Fatalf("Run() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Fatalf("Run() = %q, want %q", got, tt.want)
+			}
+			if !slices.Equal(attempts, tt.wantAttempts) {
+				t.Fatalf("attempts = %v, want %v", attempts, tt.wantAttempts)
+			}
+			if len(calls) != len(tt.wantAttempts) {
+				t.Fatalf("called %v, want one call per attempt", calls)
+			}
+		})
+	}
+}
+
+func TestAllFailedIsAChainError(t *testing.T) {
+	var calls []string
+	other := errors.New("timeout")
+	_, _, err := NewCompositeStrategy(nil,
+		scripted("a", errDeclined, &calls),
+		scripted("b", errFraud, &calls),
+		scripted("c", other, &calls),
+	).Run(1)
+
+	var chain *ChainError
+	if !errors.As(err, &chain) || len(chain.Attempts) != 3 {
+		t.Fatalf("Run() = %v, want a ChainError with three attempts", err)
+	}
+	for _, want := range []error{errDeclined, errFraud, other} {
+		if !errors.Is(err, want) {
+			t.Fatalf("%v does not wrap %v", err, want)
+		}
+	}
+	if _, _, err := NewCompositeStrategy[int, string](nil).Run(1); !errors.Is(err, ErrNoStrategies) {
+		t.Fatalf("empty chain = %v, want %v", err, ErrNoStrategies)
+	}
+}
+
+func TestCompositesNest(t *testing.T) {
+	var calls []string
+	inner := NewCompositeStrategy(nil, scripted("a", errDeclined, &calls), scripted("b", errDeclined, &calls))
+	outer := NewCompositeStrategy[int, string](nil, inner, scripted("c", nil, &calls))
+	got, attempts, err := outer.Run(1)
+	if err != nil || got != "c" {
+		t.Fatalf("Run() = %q, %v", got, err)
+	}
+	if len(attempts) != 2 || !errors.As(attempts[0].Err, new(*ChainError)) {
+		t.Fatalf("attempts = %v, want the inner chain's failure then success", attempts)
+	}
+	if !slices.Equal(calls, []string{"a", "b", "c"}) {
+		t.Fatalf("called %v", calls)
+	}
+}
+
+func TestPaymentChain(t *testing.T) {
+	chain := NewCompositeStrategy(RetryablePayment,
+		PaymentAttempt(NewCreditCard("4111 1111 1111 1112")),
+		PaymentAttempt(NewCrypto(genesis)),
+		PaymentAttempt(NewPayPal("jane@example.com")),
+	)
+	receipt, attempts, err := chain.Run(Payment{Amount: 30, Currency: "USD"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if receipt.Method != "PayPal" || receipt.Currency != "USD" {
+		t.Fatalf("receipt = %+v, want PayPal in USD", receipt)
+	}
+	var card *CardError
+	var currency *CurrencyError
+	if len(attempts) != 3 || !errors.As(attempts[0].Err, &card) || !errors.As(attempts[1].Err, &currency) {
+		t.Fatalf("attempts = %v, want the bad card, then crypto refusing USD", attempts)
+	}
+
+	_, attempts, err = chain.Run(Payment{Amount: 0, Currency: "USD"})
+	if !errors.Is(err, ErrInvalidAmount) || len(attempts) != 1 {
+		t.Fatalf("Run(0) = %v after %d attempts, want ErrInvalidAmount after one", err, len(attempts))
+	}
+}
@@ -0,0 +1,192 @@
+package strategy
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Antonious-Stewart/15-Most-Common-Design-Patterns/structural/composite"
+)
+
+var (
+	ErrNoOrders     = errors.New("strategy: no orders to issue")
+	ErrNoStrategy   = errors.New("strategy: commander has no delivery strategy")
+	ErrNoRecipients = errors.New("strategy: no soldiers to deliver to")
+)
+
+// container is how the delivery strategies see the composite's units: a
+// soldier with Children is relayed through, anything else is a recipient.
+type container interface {
+	Children() []composite.Soldier
+}
+
+// DeliveryStrategy gets orders from the root of a composite tree to the
+// soldiers at its leaves. Swapping strategies changes how they travel, never
+// the tree. Every soldier is briefed even if some fail; their errors are
+// joined.
+type DeliveryStrategy interface {
+	Deliver(s composite.Soldier, orders string) error
+}
+
+// Clock lets the timed strategies be measured without waiting.
+type Clock interface {
+	Sleep(d time.Duration)
+}
+
+type realClock struct{}
+
+func (realClock) Sleep(d time.Duration) {
+	time.Sleep(d)
+}
+
+func clockOrReal(c Clock) Clock {
+	if c == nil {
+		return realClock{}
+	}
+	return c
+}
+
+// RadioDelivery relays the orders down the chain of command one hop at a
+// time, depth-first, waiting hopDelay on every hop from a unit to one of its
+// children. Soldiers early in the tree hear the orders long before the last.
+type RadioDelivery struct {
+	hopDelay time.Duration
+	clock    Clock
+}
+
+// NewRadioDelivery uses real time when clock is nil.
+func NewRadioDelivery(hopDelay time.Duration, clock Clock) *RadioDelivery {
+	return &RadioDelivery{
+		hopDelay: hopDelay,
+		clock:    clockOrReal(clock),
+	}
+}
+
+func (r *RadioDelivery) Deliver(s composite.Soldier, orders string) error {
+	failed := make([]error, 0)
+	if r.relay(s, orders, &failed) == 0 {
+		return ErrNoRecipients
+	}
+	return errors.Join(failed...)
+}
+
+func (r *RadioDelivery) relay(s composite.Soldier, orders string, failed *[]error) int {
+	c, ok := s.(container)
+	if !ok {
+		if err := s.Brief(orders); err != nil {
+			*failed = append(*failed, err)
+		}
+		return 1
+	}
+	delivered := 0
+	for _, child := range c.Children() {
+		r.clock.Sleep(r.hopDelay)
+		delivered += r.relay(child, orders, failed)
+	}
+	return delivered
+}
+
+// CourierDelivery skips the chain of command and carries the orders straight
+// to the soldiers, batchSize of them per trip of tripDelay. A batchSize
+// below 1 takes everyone in one trip.
+type CourierDelivery struct {
+	batchSize int
+	tripDelay time.Duration
+	clock     Clock
+}
+
+// NewCourierDelivery uses real time when clock is nil.
+func NewCourierDelivery(batchSize int, tripDelay time.Duration, clock Clock) *CourierDelivery {
+	return &CourierDelivery{
+		batchSize: batchSize,
+		tripDelay: tripDelay,
+		clock:     clockOrReal(clock),
+	}
+}
+
+func (c *CourierDelivery) Deliver(s composite.Soldier, orders string) error {
+	recipients := leaves(s, make([]composite.Soldier, 0))
+	if len(recipients) == 0 {
+		return ErrNoRecipients
+	}
+	size := c.batchSize
+	if size < 1 {
+		size = len(recipients)
+	}
+	failed := make([]error, 0)
+	for start := 0; start < len(recipients); start += size {
+		c.clock.Sleep(c.tripDelay)
+		for _, soldier := range recipients[start:min(start+size, len(recipients))] {
+			if err := soldier.Brief(orders); err != nil {
+				failed = append(failed, err)
+			}
+		}
+	}
+	return errors.Join(failed...)
+}
+
+// BroadcastDelivery reaches every soldier at once, each on its own goroutine,
+// so the order in which they are briefed is not defined.
+type BroadcastDelivery struct{}
+
+func NewBroadcastDelivery() *BroadcastDelivery {
+	return &BroadcastDelivery{}
+}
+
+func (b *BroadcastDelivery) Deliver(s composite.Soldier, orders string) error {
+	recipients := leaves(s, make([]composite.Soldier, 0))
+	if len(recipients) == 0 {
+		return ErrNoRecipients
+	}
+	failed := make([]error, len(recipients))
+	var wg sync.WaitGroup
+	for i, soldier := range recipients {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			failed[i] = soldier.Brief(orders)
+		}()
+	}
+	wg.Wait()
+	return errors.Join(failed...)
+}
+
+// leaves appends the soldiers below s in depth-first order.
+func leaves(s composite.Soldier, found []composite.Soldier) []composite.Soldier {
+	c, ok := s.(container)
+	if !ok {
+		return append(found, s)
+	}
+	for _, child := range c.Children() {
+		found = leaves(child, found)
+	}
+	return found
+}
+
+// Commander is the context for the delivery strategies: it issues orders to
+// whatever tree it is given, using the strategy it currently holds.
+type Commander struct {
+	strategy DeliveryStrategy
+}
+
+func NewCommander(strategy DeliveryStrategy) *Commander {
+	return &Commander{strategy: strategy}
+}
+
+func (c *Commander) SetStrategy(strategy DeliveryStrategy) {
+	c.strategy = strategy
+}
+
+func (c *Commander) IssueOrders(root composite.Soldier, orders string) error {
+	if orders == "" {
+		return ErrNoOrders
+	}
+	if c.strategy == nil {
+		return ErrNoStrategy
+	}
+	if err := c.strategy.Deliver(root, orders); err != nil {
+		return fmt.Errorf("issuing %q: %w", orders, err)
+	}
+	return nil
+}
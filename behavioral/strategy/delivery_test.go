@@ -0,0 +1,275 @@
+package strategy
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Antonious-Stewart/15-Most-Common-Design-Patterns/structural/composite"
+)
+
+// fakeClock adds up the time slept instead of waiting.
+type fakeClock struct {
+	mu      sync.Mutex
+	elapsed time.Duration
+}
+
+func (c *fakeClock) Sleep(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.elapsed += d
+}
+
+func (c *fakeClock) now() time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.elapsed
+}
+
+// briefing is one soldier hearing orders at a point in fake time.
+type briefing struct {
+	name string
+	at   time.Duration
+}
+
+// radioLog collects briefings from every listener in a tree.
+type radioLog struct {
+	mu        sync.Mutex
+	clock     *fakeClock
+	briefings []briefing
+}
+
+// listener is a leaf soldier that logs when it is briefed.
+// A listener with err set is logged and then fails the briefing.
+type listener struct {
+	name string
+	log  *radioLog
+	err  error
+}
+
+func (l *listener) Brief(orders string) error {
+	l.log.mu.Lock()
+	defer l.log.mu.Unlock()
+	l.log.briefings = append(l.log.briefings, briefing{l.name, l.log.clock.now()})
+	return l.err
+}
+
+func (l *listener) Add(...composite.Soldier) error {
+	return nil
+}
+
+func (l *listener) Count() int {
+	return 1
+}
+
+func (l *listener) Name() string {
+	return l.name
+}
+
+func (l *listener) Parent() composite.Soldier {
+	return nil
+}
+
+func (l *listener) Rank() composite.Rank {
+	return composite.Private
+}
+
+func (l *listener) Report() composite.Readiness {
+	return composite.Readiness{Ready: 1, Total: 1}
+}
+
+func (l *listener) Clone() composite.Soldier {
+	c := *l
+	return &c
+}
+
+func (r *radioLog) names() []string {
+	names := make([]string, len(r.briefings))
+	for i, b := range r.briefings {
+		names[i] = b.name
+	}
+	return names
+}
+
+// army builds
+//
+//	1st: Alpha: 1: A: a1 a2
+//	               B: b1
+//	     Bravo: 2: C: c1
+func army(log *radioLog) *composite.Division {
+	listen := func(name string) composite.Soldier {
+		return &listener{name: name, log: log}
+	}
+	a, b, c := composite.NewSquad("A"), composite.NewSquad("B"), composite.NewSquad("C")
+	a.Add(listen("a1"), listen("a2"))
+	b.Add(listen("b1"))
+	c.Add(listen("c1"))
+	first, second := composite.NewPlatoon("1"), composite.NewPlatoon("2")
+	first.Add(a, b)
+	second.Add(c)
+	alpha, bravo := composite.NewBrigade("Alpha"), composite.NewBrigade("Bravo")
+	alpha.Add(first)
+	bravo.Add(second)
+	division := composite.NewDivision("1st")
+	division.Add(alpha, bravo)
+	return division
+}
+
+func TestDeliveryStrategiesOnTheSameTree(t *testing.T) {
+	const ms = time.Millisecond
+	tests := []struct {
+		name     string
+		strategy func(*fakeClock) DeliveryStrategy
+		want     []briefing
+		ordered  bool
+		elapsed  time.Duration
+	}{
+		{
+			name: "radio relays every hop",
+			strategy: func(c *fakeClock) DeliveryStrategy {
+				return NewRadioDelivery(ms, c)
+			},
+			want:    []briefing{{"a1", 4 * ms}, {"a2", 5 * ms}, {"b1", 7 * ms}, {"c1", 11 * ms}},
+			ordered: true,
+			elapsed: 11 * ms,
+		},
+		{
+			name: "courier carries batches",
+			strategy: func(c *fakeClock) DeliveryStrategy {
+				return NewCourierDelivery(2, 5*ms, c)
+			},
+			want:    []briefing{{"a1", 5 * ms}, {"a2", 5 * ms}, {"b1", 10 * ms}, {"c1", 10 * ms}},
+			ordered: true,
+			elapsed: 10 * ms,
+		},
+		{
+			name: "courier takes everyone at once",
+			strategy: func(c *fakeClock) DeliveryStrategy {
+				return NewCourierDelivery(0, 5*ms, c)
+			},
+			want:    []briefing{{"a1", 5 * ms}, {"a2", 5 * ms}, {"b1", 5 * ms}, {"c1", 5 * ms}},
+			ordered: true,
+			elapsed: 5 * ms,
+		},
+		{
+			name: "broadcast reaches everyone concurrently",
+			strategy: func(*fakeClock) DeliveryStrategy {
+				return NewBroadcastDelivery()
+			},
+			want: []briefing{{"a1", 0}, {"a2", 0}, {"b1", 0}, {"c1", 0}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := &fakeClock{}
+			log := &radioLog{clock: clock}
+			commander := NewCommander(tt.strategy(clock))
+			if err := commander.IssueOrders(army(log), "hold the line"); err != nil {
+				t.Fatal(err)
+			}
+
+			got := log.briefings
+			if !tt.ordered {
+				slices.SortFunc(got, func(a, b briefing) int {
+					return strings.Compare(a.name, b.name)
+				})
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Fatalf("briefed %v, want %v", got, tt.want)
+			}
+			if clock.now() != tt.elapsed {
+				t.Fatalf("took %v, want %v", clock.now(), tt.elapsed)
+			}
+		})
+	}
+}
+
+func TestSwappingStrategyLeavesTreeAlone(t *testing.T) {
+	clock := &fakeClock{}
+	log := &radioLog{clock: clock}
+	division := army(log)
+	commander := NewCommander(NewRadioDelivery(time.Millisecond, clock))
+
+	if err := commander.IssueOrders(division, "advance"); err != nil {
+		t.Fatal(err)
+	}
+	commander.SetStrategy(NewCourierDelivery(1, time.Millisecond, clock))
+	if err := commander.IssueOrders(division, "retreat"); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"a1", "a2", "b1", "c1", "a1", "a2", "b1", "c1"}
+	if got := log.names(); !slices.Equal(got, want) {
+		t.Fatalf("briefed %v, want everyone twice", got)
+	}
+	if n := len(division.Children()); n != 2 {
+		t.Fatalf("division has %d brigades after two deliveries, want 2", n)
+	}
+}
+
+func TestIssueOrdersErrors(t *testing.T) {
+	empty := composite.NewDivision("empty")
+	empty.Add(composite.NewBrigade("Alpha"))
+	for _, strategy := range []DeliveryStrategy{
+		NewRadioDelivery(0, nil),
+		NewCourierDelivery(1, 0, nil),
+		NewBroadcastDelivery(),
+	} {
+		if err := NewCommander(strategy).IssueOrders(empty, "advance"); !errors.Is(err, ErrNoRecipients) {
+			t.Fatalf("%T on an empty division = %v, want %v", strategy, err, ErrNoRecipients)
+		}
+	}
+	if err := NewCommander(NewBroadcastDelivery()).IssueOrders(empty, ""); !errors.Is(err, ErrNoOrders) {
+		t.Fatalf("IssueOrders with no orders = %v, want %v", err, ErrNoOrders)
+	}
+	if err := NewCommander(nil).IssueOrders(empty, "advance"); !errors.Is(err, ErrNoStrategy) {
+		t.Fatalf("IssueOrders without a strategy = %v, want %v", err, ErrNoStrategy)
+	}
+}
+
+func TestEnlistedAreRecipients(t *testing.T) {
+	squad := composite.NewSquad("A")
+	squad.Add(composite.NewEnlisted("Jones"))
+	if err := NewCommander(NewRadioDelivery(0, nil)).IssueOrders(squad, "dig in"); err != nil {
+		t.Fatalf("IssueOrders to a squad of one = %v", err)
+	}
+}
+
+func TestFailedBriefingsAreJoined(t *testing.T) {
+	unreachable := errors.New("out of range")
+	for _, strategy := range []func(*fakeClock) DeliveryStrategy{
+		func(c *fakeClock) DeliveryStrategy { return NewRadioDelivery(0, c) },
+		func(c *fakeClock) DeliveryStrategy { return NewCourierDelivery(2, 0, c) },
+		func(c *fakeClock) DeliveryStrategy { return NewBroadcastDelivery() },
+	} {
+		log := &radioLog{clock: &fakeClock{}}
+		division := army(log)
+		for _, s := range leaves(division, make([]composite.Soldier, 0)) {
+			if l := s.(*listener); l.name == "a2" || l.name == "c1" {
+				l.err = fmt.Errorf("%s: %w", l.name, unreachable)
+			}
+		}
+		s := strategy(log.clock)
+		err := NewCommander(s).IssueOrders(division, "advance")
+		if !errors.Is(err, unreachable) || !strings.Contains(err.Error(), "a2: out of range\nc1: out of range") {
+			t.Fatalf("%T: IssueOrders() = %v, want a2 and c1 out of range", s, err)
+		}
+		if len(log.briefings) != 4 {
+			t.Fatalf("%T briefed %d soldiers, want all 4", s, len(log.briefings))
+		}
+	}
+}
+
+func TestEnlistedBriefingErrors(t *testing.T) {
+	squad := composite.NewSquad("A")
+	jones := composite.NewEnlisted("Jones")
+	jones.SetAvailable(false)
+	squad.Add(jones)
+	err := NewCommander(NewCourierDelivery(0, 0, nil)).IssueOrders(squad, "dig in")
+	if !errors.Is(err, composite.ErrUnavailable) {
+		t.Fatalf("IssueOrders to an unavailable soldier = %v, want %v", err, composite.ErrUnavailable)
+	}
+}
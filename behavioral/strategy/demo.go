@@ -0,0 +1,39 @@
+package strategy
+
+import (
+	"fmt"
+	"io"
+)
+
+// Demo fills a cache under two eviction algorithms and pays for one order
+// with whichever method goes through first.
+func Demo(w io.Writer) error {
+	for _, algo := range []struct {
+		name string
+		algo EvictionAlgo
+	}{{"fifo", NewFIFO()}, {"lru", NewLRU()}} {
+		cache := NewCache(2, algo.algo)
+		cache.Add("a", "1")
+		cache.Add("b", "2")
+		cache.Get("a")
+		evicted, _ := cache.Add("c", "3")
+		fmt.Fprintf(w, "%s evicts %s\n", algo.name, evicted)
+	}
+
+	pay := NewCompositeStrategy(RetryablePayment,
+		PaymentAttempt(NewCrypto("1BoatSLRHtKNngkdXEeobR76b53LETtpyT")),
+		PaymentAttempt(NewCreditCard("4111 1111 1111 1112")),
+		PaymentAttempt(NewPayPal("jane@example.com")),
+	)
+	receipt, attempts, err := pay.Run(Payment{Amount: 40, Currency: "USD"})
+	if err != nil {
+		return err
+	}
+	for _, a := range attempts {
+		if a.Err != nil {
+			fmt.Fprintf(w, "attempt %d failed: %v\n", a.Index, a.Err)
+		}
+	}
+	fmt.Fprintf(w, "paid %d %s by %s (%s)\n", receipt.Amount, receipt.Currency, receipt.Method, receipt.Account)
+	return nil
+}
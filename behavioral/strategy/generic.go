@@ -0,0 +1,136 @@
+package strategy
+
+import (
+	"context"
+
+	"github.com/Antonious-Stewart/15-Most-Common-Design-Patterns/structural/composite"
+)
+
+// Strategy is what every strategy in this package comes down to: one
+// operation from In to Out that can be swapped for another.
+type Strategy[In, Out any] interface {
+	Execute(in In) Out
+}
+
+// Func lets a plain function be a Strategy.
+type Func[In, Out any] func(in In) Out
+
+func (f Func[In, Out]) Execute(in In) Out {
+	return f(in)
+}
+
+// Context holds the current strategy and runs it. The Cache and Sorter
+// contexts are built on it instead of each keeping their own field and
+// setter.
+type Context[In, Out any] struct {
+	strategy Strategy[In, Out]
+	overrides[Strategy[In, Out]]
+}
+
+func NewContext[In, Out any](strategy Strategy[In, Out]) *Context[In, Out] {
+	return &Context[In, Out]{strategy: strategy}
+}
+
+func (c *Context[In, Out]) SetStrategy(strategy Strategy[In, Out]) {
+	c.strategy = strategy
+}
+
+func (c *Context[In, Out]) Execute(in In) Out {
+	return c.strategy.Execute(in)
+}
+
+// ExecuteContext is Execute with the strategy ctx asks for; see WithStrategy.
+func (c *Context[In, Out]) ExecuteContext(ctx context.Context, in In) (Out, error) {
+	s, err := c.pick(ctx, c.strategy)
+	if err != nil {
+		var zero Out
+		return zero, err
+	}
+	return s.Execute(in), nil
+}
+
+// CacheEventKind says what a CacheEvent reports.
+type CacheEventKind int
+
+const (
+	KeyAdded CacheEventKind = iota
+	KeyAccessed
+	// EvictKey asks for a victim; it is the only event whose result is used.
+	EvictKey
+)
+
+// CacheEvent is the generic form of the EvictionAlgo calls. Key is empty
+// for EvictKey.
+type CacheEvent struct {
+	Kind CacheEventKind
+	Key  string
+}
+
+// evictionStrategy turns an EvictionAlgo into the strategy the Cache runs.
+func evictionStrategy(algo EvictionAlgo) Strategy[CacheEvent, string] {
+	return Func[CacheEvent, string](func(e CacheEvent) string {
+		switch e.Kind {
+		case KeyAdded:
+			algo.Added(e.Key)
+		case KeyAccessed:
+			algo.Accessed(e.Key)
+		case EvictKey:
+			return algo.Evict()
+		}
+		return ""
+	})
+}
+
+// SortJob is the generic form of a SortStrategy call.
+type SortJob struct {
+	Records []Record
+	Less    func(a, b Record) bool
+}
+
+func sortStrategy(s SortStrategy) Strategy[SortJob, struct{}] {
+	return Func[SortJob, struct{}](func(job SortJob) struct{} {
+		s.Sort(job.Records, job.Less)
+		return struct{}{}
+	})
+}
+
+// EvictionFunc lets one closure be an EvictionAlgo. It sees every event and
+// only its answer to EvictKey matters.
+type EvictionFunc func(e CacheEvent) string
+
+func (f EvictionFunc) Added(key string) {
+	f(CacheEvent{Kind: KeyAdded, Key: key})
+}
+
+func (f EvictionFunc) Accessed(key string) {
+	f(CacheEvent{Kind: KeyAccessed, Key: key})
+}
+
+func (f EvictionFunc) Evict() string {
+	return f(CacheEvent{Kind: EvictKey})
+}
+
+// SortFunc lets a function be a SortStrategy.
+type SortFunc func(records []Record, less func(a, b Record) bool)
+
+func (f SortFunc) Sort(records []Record, less func(a, b Record) bool) {
+	f(records, less)
+}
+
+// DeliveryFunc lets a function be a DeliveryStrategy.
+type DeliveryFunc func(s composite.Soldier, orders string) error
+
+func (f DeliveryFunc) Deliver(s composite.Soldier, orders string) error {
+	return f(s, orders)
+}
+
+// PayFunc lets a function be a PaymentStrategy that takes every currency.
+type PayFunc func(amount int) (Receipt, error)
+
+func (f PayFunc) Pay(amount int) (Receipt, error) {
+	return f(amount)
+}
+
+func (f PayFunc) Supports(currency string) bool {
+	return true
+}
@@ -0,0 +1,123 @@
+package strategy
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/Antonious-Stewart/15-Most-Common-Design-Patterns/structural/composite"
+)
+
+func TestFuncIsAStrategy(t *testing.T) {
+	shout := NewContext[string, string](Func[string, string](strings.ToUpper))
+	if got := shout.Execute("advance"); got != "ADVANCE" {
+		t.Fatalf("Execute() = %q, want ADVANCE", got)
+	}
+	shout.SetStrategy(Func[string, string](func(s string) string { return s + "!" }))
+	if got := shout.Execute("advance"); got != "advance!" {
+		t.Fatalf("Execute() after SetStrategy = %q, want advance!", got)
+	}
+}
+
+// newestFirst is a closure-based eviction strategy that drops the key added
+// most recently.
+func newestFirst() EvictionFunc {
+	stack := make([]string, 0)
+	return func(e CacheEvent) string {
+		switch e.Kind {
+		case KeyAdded:
+			stack = append(stack, e.Key)
+		case EvictKey:
+			victim := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			return victim
+		}
+		return ""
+	}
+}
+
+func TestClosuresAsRegisteredStrategies(t *testing.T) {
+	evictions := NewEvictionRegistry()
+	if err := evictions.Register("lifo", func() EvictionAlgo { return newestFirst() }); err != nil {
+		t.Fatal(err)
+	}
+	newAlgo, err := evictions.Get("lifo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := run(NewCache(2, newAlgo()), "+a +b +c +d"); !slices.Equal(got, []string{"b", "c"}) {
+		t.Fatalf("lifo closure evicted %v, want [b c]", got)
+	}
+
+	sorts := NewSortRegistry()
+	byName := SortFunc(func(records []Record, _ func(a, b Record) bool) {
+		slices.SortStableFunc(records, func(a, b Record) int { return strings.Compare(a.Name, b.Name) })
+	})
+	if err := sorts.Register("by-name", byName); err != nil {
+		t.Fatal(err)
+	}
+	s, err := sorts.Get("by-name")
+	if err != nil {
+		t.Fatal(err)
+	}
+	records := []Record{{1, "c"}, {2, "a"}, {3, "b"}}
+	NewSorter(s, ByKey).Sort(records)
+	if got := names(records); !slices.Equal(got, []string{"a", "b", "c"}) {
+		t.Fatalf("by-name closure sorted %v", got)
+	}
+}
+
+func TestDeliveryAndPaymentFuncs(t *testing.T) {
+	var briefed []string
+	whisper := DeliveryFunc(func(s composite.Soldier, orders string) error {
+		for _, soldier := range leaves(s, nil) {
+			briefed = append(briefed, soldier.(*composite.Enlisted).Name())
+		}
+		return nil
+	})
+	squad := composite.NewSquad("A")
+	squad.Add(composite.NewEnlisted("Jones"), composite.NewEnlisted("Smith"))
+	if err := NewCommander(whisper).IssueOrders(squad, "hold"); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(briefed, []string{"Jones", "Smith"}) {
+		t.Fatalf("briefed %v", briefed)
+	}
+
+	declined := errors.New("declined")
+	checkout := NewCheckout()
+	checkout.SetPaymentStrategy(PayFunc(func(amount int) (Receipt, error) {
+		if amount > 100 {
+			return Receipt{}, declined
+		}
+		return Receipt{Method: "voucher", Amount: amount}, nil
+	}))
+	if receipt, err := checkout.Checkout(50, "XTS"); err != nil || receipt.Currency != "XTS" {
+		t.Fatalf("Checkout(50) = %+v, %v", receipt, err)
+	}
+	if _, err := checkout.Checkout(500, "XTS"); !errors.Is(err, declined) {
+		t.Fatalf("Checkout(500) = %v, want %v", err, declined)
+	}
+}
+
+// TestExamplesOnGenericCore drives the built-in strategies through a bare
+// Context, bypassing Cache and Sorter, and expects what their own tests do.
+func TestExamplesOnGenericCore(t *testing.T) {
+	lru := NewContext(evictionStrategy(NewLRU()))
+	for _, key := range []string{"a", "b", "c"} {
+		lru.Execute(CacheEvent{Kind: KeyAdded, Key: key})
+	}
+	lru.Execute(CacheEvent{Kind: KeyAccessed, Key: "a"})
+	if got := lru.Execute(CacheEvent{Kind: EvictKey}); got != "b" {
+		t.Fatalf("LRU through the generic core evicted %q, want b", got)
+	}
+
+	for name, strategy := range strategies() {
+		records := inputs(200)["reversed"]
+		NewContext(sortStrategy(strategy)).Execute(SortJob{Records: records, Less: ByKey})
+		if !slices.IsSortedFunc(records, func(a, b Record) int { return a.Key - b.Key }) {
+			t.Fatalf("%s through the generic core did not sort", name)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package strategy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+var ErrOverrideForbidden = errors.New("strategy: strategy cannot be overridden")
+
+type overrideKey struct{}
+
+// WithStrategy asks the strategy contexts handling this request to use the
+// strategy registered under name instead of their configured one. A nested
+// call replaces the outer name; an empty name goes back to the configured
+// strategy.
+func WithStrategy(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, overrideKey{}, name)
+}
+
+// StrategyFromContext returns the name set by WithStrategy, if any.
+func StrategyFromContext(ctx context.Context) (string, bool) {
+	name, ok := ctx.Value(overrideKey{}).(string)
+	return name, ok && name != ""
+}
+
+// overrides is embedded by the contexts that accept per-request strategies.
+// Overrides are looked up in the registry given to AllowOverrides; without
+// one, any override fails as unknown.
+type overrides[S any] struct {
+	registry  *Registry[S]
+	forbidden bool
+}
+
+// AllowOverrides names the registry that WithStrategy names are looked up in.
+func (o *overrides[S]) AllowOverrides(registry *Registry[S]) {
+	o.registry = registry
+}
+
+// ForbidOverrides makes a request that asks for another strategy fail with
+// ErrOverrideForbidden, for strategies that must not be bypassed. Requests
+// without an override are unaffected.
+func (o *overrides[S]) ForbidOverrides() {
+	o.forbidden = true
+}
+
+// pick returns the strategy ctx asks for, or configured if it asks for none.
+func (o *overrides[S]) pick(ctx context.Context, configured S) (S, error) {
+	name, ok := StrategyFromContext(ctx)
+	if !ok {
+		return configured, nil
+	}
+	if o.forbidden {
+		return configured, fmt.Errorf("%w: asked for %q", ErrOverrideForbidden, name)
+	}
+	if o.registry == nil {
+		return configured, fmt.Errorf("%w: %q", ErrUnknownStrategy, name)
+	}
+	return o.registry.Get(name)
+}
@@ -0,0 +1,108 @@
+package strategy
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func paymentRoutes(t *testing.T) *Registry[PaymentStrategy] {
+	t.Helper()
+	r := NewRegistry[PaymentStrategy]()
+	for name, s := range map[string]PaymentStrategy{
+		"card":   NewCreditCard(visa),
+		"paypal": NewPayPal("jane@example.com"),
+	} {
+		if err := r.Register(name, s); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return r
+}
+
+func TestCheckoutOverride(t *testing.T) {
+	checkout := NewCheckout()
+	checkout.SetPaymentStrategy(NewCreditCard(visa))
+	checkout.AllowOverrides(paymentRoutes(t))
+
+	vip := WithStrategy(context.Background(), "paypal")
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want string
+	}{
+		{"no override", context.Background(), "credit card"},
+		{"override", vip, "PayPal"},
+		{"nested override wins", WithStrategy(vip, "card"), "credit card"},
+		{"empty name clears it", WithStrategy(vip, ""), "credit card"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			receipt, err := checkout.CheckoutWithContext(tt.ctx, 10, "USD")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if receipt.Method != tt.want {
+				t.Fatalf("paid with %s, want %s", receipt.Method, tt.want)
+			}
+		})
+	}
+	if n := len(checkout.Receipts()); n != len(tests) {
+		t.Fatalf("%d receipts, want %d", n, len(tests))
+	}
+}
+
+func TestCheckoutOverrideErrors(t *testing.T) {
+	vip := WithStrategy(context.Background(), "paypal")
+
+	checkout := NewCheckout()
+	checkout.SetPaymentStrategy(NewCreditCard(visa))
+	if _, err := checkout.CheckoutWithContext(vip, 10, "USD"); !errors.Is(err, ErrUnknownStrategy) {
+		t.Fatalf("override without a registry = %v, want %v", err, ErrUnknownStrategy)
+	}
+	checkout.AllowOverrides(paymentRoutes(t))
+	if _, err := checkout.CheckoutWithContext(WithStrategy(vip, "cash"), 10, "USD"); !errors.Is(err, ErrUnknownStrategy) {
+		t.Fatalf("unknown override = %v, want %v", err, ErrUnknownStrategy)
+	}
+
+	checkout.ForbidOverrides()
+	if _, err := checkout.CheckoutWithContext(vip, 10, "USD"); !errors.Is(err, ErrOverrideForbidden) {
+		t.Fatalf("forbidden override = %v, want %v", err, ErrOverrideForbidden)
+	}
+	if _, err := checkout.CheckoutWithContext(context.Background(), 10, "USD"); err != nil {
+		t.Fatalf("plain request on a locked checkout: %v", err)
+	}
+	if n := len(checkout.Receipts()); n != 1 {
+		t.Fatalf("%d receipts, want only the plain request's", n)
+	}
+}
+
+func TestContextOverride(t *testing.T) {
+	double := Func[int, int](func(v int) int { return 2 * v })
+	square := Func[int, int](func(v int) int { return v * v })
+	r := NewRegistry[Strategy[int, int]]()
+	_ = r.Register("square", square)
+
+	c := NewContext[int, int](double)
+	c.AllowOverrides(r)
+	ctx := WithStrategy(context.Background(), "square")
+	for _, tt := range []struct {
+		ctx  context.Context
+		want int
+	}{
+		{context.Background(), 6},
+		{ctx, 9},
+	} {
+		got, err := c.ExecuteContext(tt.ctx, 3)
+		if err != nil || got != tt.want {
+			t.Fatalf("ExecuteContext() = %d, %v, want %d", got, err, tt.want)
+		}
+	}
+	c.ForbidOverrides()
+	if _, err := c.ExecuteContext(ctx, 3); !errors.Is(err, ErrOverrideForbidden) {
+		t.Fatalf("ExecuteContext() error = %v, want %v", err, ErrOverrideForbidden)
+	}
+	if name, ok := StrategyFromContext(ctx); !ok || name != "square" {
+		t.Fatalf("StrategyFromContext() = %q, %v", name, ok)
+	}
+}
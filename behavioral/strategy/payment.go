@@ -0,0 +1,299 @@
+package strategy
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/mail"
+	"strings"
+)
+
+var (
+	ErrInvalidAmount   = errors.New("strategy: amount must be positive")
+	ErrNoPaymentMethod = errors.New("strategy: no payment method selected")
+)
+
+// CardError says why a card number was refused.
+type CardError struct {
+	Reason string
+}
+
+func (e *CardError) Error() string {
+	return "strategy: invalid card number: " + e.Reason
+}
+
+// EmailError is a PayPal account that isn't a plain email address.
+type EmailError struct {
+	Email string
+}
+
+func (e *EmailError) Error() string {
+	return fmt.Sprintf("strategy: invalid PayPal email %q", e.Email)
+}
+
+// AddressError says why a crypto address was refused.
+type AddressError struct {
+	Address string
+	Reason  string
+}
+
+func (e *AddressError) Error() string {
+	return fmt.Sprintf("strategy: invalid address %q: %s", e.Address, e.Reason)
+}
+
+// CurrencyError is a checkout in a currency the chosen method doesn't take.
+type CurrencyError struct {
+	Currency string
+}
+
+func (e *CurrencyError) Error() string {
+	return fmt.Sprintf("strategy: payment method does not support %s", e.Currency)
+}
+
+// Receipt records a payment. Account is masked so receipts can be shown or
+// logged without giving away the card number or the full email address.
+type Receipt struct {
+	Method   string
+	Account  string
+	Amount   int
+	Currency string
+}
+
+// PaymentStrategy is one way of paying. Pay validates the payment details
+// on every call, so a strategy built from bad input fails when used.
+type PaymentStrategy interface {
+	Pay(amount int) (Receipt, error)
+	Supports(currency string) bool
+}
+
+type CreditCard struct {
+	number string
+}
+
+// NewCreditCard accepts spaces and dashes between the digits.
+func NewCreditCard(number string) *CreditCard {
+	return &CreditCard{number: number}
+}
+
+func (c *CreditCard) Pay(amount int) (Receipt, error) {
+	if amount <= 0 {
+		return Receipt{}, fmt.Errorf("%w: %d", ErrInvalidAmount, amount)
+	}
+	digits, err := cardDigits(c.number)
+	if err != nil {
+		return Receipt{}, err
+	}
+	return Receipt{
+		Method:  "credit card",
+		Account: "**** " + digits[len(digits)-4:],
+		Amount:  amount,
+	}, nil
+}
+
+func (c *CreditCard) Supports(currency string) bool {
+	switch currency {
+	case "USD", "EUR", "GBP", "JPY":
+		return true
+	}
+	return false
+}
+
+// cardDigits strips separators and checks the length and the Luhn check
+// digit.
+func cardDigits(number string) (string, error) {
+	digits := strings.Map(func(r rune) rune {
+		if r == ' ' || r == '-' {
+			return -1
+		}
+		return r
+	}, number)
+	for _, r := range digits {
+		if r < '0' || r > '9' {
+			return "", &CardError{Reason: fmt.Sprintf("unexpected %q", r)}
+		}
+	}
+	if len(digits) < 12 || len(digits) > 19 {
+		return "", &CardError{Reason: fmt.Sprintf("%d digits", len(digits))}
+	}
+	if !luhn(digits) {
+		return "", &CardError{Reason: "check digit does not match"}
+	}
+	return digits, nil
+}
+
+// luhn doubles every second digit from the right; the sum of the digits of
+// the results must be a multiple of ten.
+func luhn(digits string) bool {
+	sum := 0
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := int(digits[i] - '0')
+		if (len(digits)-i)%2 == 0 {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+	}
+	return sum%10 == 0
+}
+
+type PayPal struct {
+	email string
+}
+
+func NewPayPal(email string) *PayPal {
+	return &PayPal{email: email}
+}
+
+func (p *PayPal) Pay(amount int) (Receipt, error) {
+	if amount <= 0 {
+		return Receipt{}, fmt.Errorf("%w: %d", ErrInvalidAmount, amount)
+	}
+	local, domain, err := splitEmail(p.email)
+	if err != nil {
+		return Receipt{}, err
+	}
+	return Receipt{
+		Method:  "PayPal",
+		Account: local[:1] + "***@" + domain,
+		Amount:  amount,
+	}, nil
+}
+
+func (p *PayPal) Supports(currency string) bool {
+	switch currency {
+	case "USD", "EUR", "GBP", "CAD", "AUD":
+		return true
+	}
+	return false
+}
+
+// splitEmail accepts a bare address with a dotted domain, nothing more.
+func splitEmail(email string) (local, domain string, err error) {
+	addr, err := mail.ParseAddress(email)
+	if err != nil || addr.Address != email || addr.Name != "" {
+		return "", "", &EmailError{Email: email}
+	}
+	at := strings.LastIndex(email, "@")
+	local, domain = email[:at], email[at+1:]
+	if !strings.Contains(domain, ".") || strings.HasPrefix(domain, ".") || strings.HasSuffix(domain, ".") {
+		return "", "", &EmailError{Email: email}
+	}
+	return local, domain, nil
+}
+
+// Crypto pays in bitcoin to a legacy Base58Check address.
+type Crypto struct {
+	address string
+}
+
+func NewCrypto(address string) *Crypto {
+	return &Crypto{address: address}
+}
+
+func (c *Crypto) Pay(amount int) (Receipt, error) {
+	if amount <= 0 {
+		return Receipt{}, fmt.Errorf("%w: %d", ErrInvalidAmount, amount)
+	}
+	if err := checkAddress(c.address); err != nil {
+		return Receipt{}, err
+	}
+	return Receipt{
+		Method:  "crypto",
+		Account: c.address,
+		Amount:  amount,
+	}, nil
+}
+
+func (c *Crypto) Supports(currency string) bool {
+	return currency == "BTC"
+}
+
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// checkAddress decodes a Base58Check address: a version byte and a 20 byte
+// hash, followed by the first four bytes of their double SHA-256.
+func checkAddress(address string) error {
+	n := new(big.Int)
+	for _, r := range address {
+		i := strings.IndexRune(base58Alphabet, r)
+		if i < 0 {
+			return &AddressError{Address: address, Reason: fmt.Sprintf("%q is not base58", r)}
+		}
+		n.Mul(n, big.NewInt(58))
+		n.Add(n, big.NewInt(int64(i)))
+	}
+	// every leading '1' stands for a leading zero byte
+	zeros := len(address) - len(strings.TrimLeft(address, "1"))
+	decoded := append(make([]byte, zeros), n.Bytes()...)
+	if len(decoded) != 25 {
+		return &AddressError{Address: address, Reason: fmt.Sprintf("decodes to %d bytes, want 25", len(decoded))}
+	}
+	payload, checksum := decoded[:21], decoded[21:]
+	first := sha256.Sum256(payload)
+	second := sha256.Sum256(first[:])
+	if !bytes.Equal(second[:4], checksum) {
+		return &AddressError{Address: address, Reason: "checksum does not match"}
+	}
+	return nil
+}
+
+// CheckoutContext takes payments with whichever strategy the customer picked
+// and keeps the receipts of the ones that went through.
+type CheckoutContext struct {
+	strategy PaymentStrategy
+	receipts []Receipt
+	overrides[PaymentStrategy]
+}
+
+func NewCheckout() *CheckoutContext {
+	return &CheckoutContext{
+		receipts: make([]Receipt, 0),
+	}
+}
+
+func (c *CheckoutContext) SetPaymentStrategy(strategy PaymentStrategy) {
+	c.strategy = strategy
+}
+
+// Checkout refuses a currency the strategy doesn't support before asking it
+// to pay.
+func (c *CheckoutContext) Checkout(amount int, currency string) (Receipt, error) {
+	return c.checkout(c.strategy, amount, currency)
+}
+
+// CheckoutWithContext is Checkout with the payment method ctx asks for, such
+// as a VIP route; see WithStrategy.
+func (c *CheckoutContext) CheckoutWithContext(ctx context.Context, amount int, currency string) (Receipt, error) {
+	strategy, err := c.pick(ctx, c.strategy)
+	if err != nil {
+		return Receipt{}, err
+	}
+	return c.checkout(strategy, amount, currency)
+}
+
+func (c *CheckoutContext) checkout(strategy PaymentStrategy, amount int, currency string) (Receipt, error) {
+	if strategy == nil {
+		return Receipt{}, ErrNoPaymentMethod
+	}
+	if !strategy.Supports(currency) {
+		return Receipt{}, &CurrencyError{Currency: currency}
+	}
+	receipt, err := strategy.Pay(amount)
+	if err != nil {
+		return Receipt{}, err
+	}
+	receipt.Currency = currency
+	c.receipts = append(c.receipts, receipt)
+	return receipt, nil
+}
+
+func (c *CheckoutContext) Receipts() []Receipt {
+	receipts := make([]Receipt, len(c.receipts))
+	copy(receipts, c.receipts)
+	return receipts
+}
@@ -0,0 +1,116 @@
+package strategy
+
+import (
+	"errors"
+	"testing"
+)
+
+const (
+	visa    = "4111 1111 1111 1111"
+	genesis = "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa"
+)
+
+func TestSuccessfulPayments(t *testing.T) {
+	tests := []struct {
+		strategy PaymentStrategy
+		currency string
+		want     Receipt
+	}{
+		{NewCreditCard(visa), "USD", Receipt{Method: "credit card", Account: "**** 1111", Amount: 25, Currency: "USD"}},
+		{NewCreditCard("5500-0000-0000-0004"), "EUR", Receipt{Method: "credit card", Account: "**** 0004", Amount: 25, Currency: "EUR"}},
+		{NewPayPal("jane.doe@example.com"), "GBP", Receipt{Method: "PayPal", Account: "j***@example.com", Amount: 25, Currency: "GBP"}},
+		{NewCrypto(genesis), "BTC", Receipt{Method: "crypto", Account: genesis, Amount: 25, Currency: "BTC"}},
+	}
+	checkout := NewCheckout()
+	for _, tt := range tests {
+		checkout.SetPaymentStrategy(tt.strategy)
+		got, err := checkout.Checkout(25, tt.currency)
+		if err != nil {
+			t.Fatalf("%T: %v", tt.strategy, err)
+		}
+		if got != tt.want {
+			t.Fatalf("%T receipt = %+v, want %+v", tt.strategy, got, tt.want)
+		}
+	}
+	if n := len(checkout.Receipts()); n != len(tests) {
+		t.Fatalf("recorded %d receipts, want %d", n, len(tests))
+	}
+}
+
+func TestReceiptsMaskCardNumbers(t *testing.T) {
+	checkout := NewCheckout()
+	checkout.SetPaymentStrategy(NewCreditCard("4012888888881881"))
+	receipt, err := checkout.Checkout(10, "USD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if receipt.Account != "**** 1881" {
+		t.Fatalf("Account = %q, want only the last four digits", receipt.Account)
+	}
+}
+
+func TestValidationFailures(t *testing.T) {
+	var card *CardError
+	var email *EmailError
+	var address *AddressError
+	tests := []struct {
+		name     string
+		strategy PaymentStrategy
+		currency string
+		want     any
+	}{
+		{"bad check digit", NewCreditCard("4111 1111 1111 1112"), "USD", &card},
+		{"letters in card", NewCreditCard("4111 1111 abcd 1111"), "USD", &card},
+		{"short card", NewCreditCard("4111"), "USD", &card},
+		{"no at sign", NewPayPal("jane.example.com"), "USD", &email},
+		{"display name", NewPayPal("Jane <jane@example.com>"), "USD", &email},
+		{"undotted domain", NewPayPal("jane@localhost"), "USD", &email},
+		{"not base58", NewCrypto("0OIl" + genesis[4:]), "BTC", &address},
+		{"bad checksum", NewCrypto(genesis[:len(genesis)-1] + "b"), "BTC", &address},
+		{"truncated", NewCrypto(genesis[:20]), "BTC", &address},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkout := NewCheckout()
+			checkout.SetPaymentStrategy(tt.strategy)
+			_, err := checkout.Checkout(10, tt.currency)
+			if !errors.As(err, tt.want) {
+				t.Fatalf("Checkout() = %v, want a %T", err, tt.want)
+			}
+			if n := len(checkout.Receipts()); n != 0 {
+				t.Fatalf("recorded %d receipts for a failed payment", n)
+			}
+		})
+	}
+}
+
+func TestUnsupportedCurrency(t *testing.T) {
+	tests := []struct {
+		strategy PaymentStrategy
+		currency string
+	}{
+		{NewCreditCard(visa), "BTC"},
+		{NewPayPal("jane@example.com"), "JPY"},
+		{NewCrypto(genesis), "USD"},
+	}
+	for _, tt := range tests {
+		checkout := NewCheckout()
+		checkout.SetPaymentStrategy(tt.strategy)
+		_, err := checkout.Checkout(10, tt.currency)
+		var currency *CurrencyError
+		if !errors.As(err, &currency) || currency.Currency != tt.currency {
+			t.Fatalf("%T in %s = %v, want a *CurrencyError", tt.strategy, tt.currency, err)
+		}
+	}
+}
+
+func TestCheckoutErrors(t *testing.T) {
+	checkout := NewCheckout()
+	if _, err := checkout.Checkout(10, "USD"); !errors.Is(err, ErrNoPaymentMethod) {
+		t.Fatalf("Checkout() without a method = %v, want %v", err, ErrNoPaymentMethod)
+	}
+	checkout.SetPaymentStrategy(NewCreditCard(visa))
+	if _, err := checkout.Checkout(0, "USD"); !errors.Is(err, ErrInvalidAmount) {
+		t.Fatalf("Checkout(0) = %v, want %v", err, ErrInvalidAmount)
+	}
+}
@@ -0,0 +1,131 @@
+package strategy
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+var (
+	ErrDuplicateStrategy = errors.New("strategy: name already registered")
+	ErrUnknownStrategy   = errors.New("strategy: no strategy registered under that name")
+	ErrNoDefault         = errors.New("strategy: no default strategy set")
+)
+
+// Registry maps configuration names to strategies of one family. It is safe
+// for concurrent use. Stateful strategies, like the eviction algorithms,
+// are registered as constructors so every user gets its own.
+type Registry[S any] struct {
+	mu         sync.RWMutex
+	strategies map[string]S
+	defaultTo  string
+	fallbacks  map[string]int
+}
+
+func NewRegistry[S any]() *Registry[S] {
+	return &Registry[S]{
+		strategies: make(map[string]S),
+		fallbacks:  make(map[string]int),
+	}
+}
+
+// NewSortRegistry holds the built-in sorting strategies, defaulting to "auto".
+func NewSortRegistry() *Registry[SortStrategy] {
+	r := NewRegistry[SortStrategy]()
+	r.strategies["insertion"] = InsertionSort{}
+	r.strategies["std"] = StdSort{}
+	r.strategies["parallel"] = NewParallelMergeSort(0)
+	r.strategies["auto"] = AutoSort{}
+	r.defaultTo = "auto"
+	return r
+}
+
+// NewEvictionRegistry holds constructors for the built-in eviction
+// algorithms, defaulting to "lru".
+func NewEvictionRegistry() *Registry[func() EvictionAlgo] {
+	r := NewRegistry[func() EvictionAlgo]()
+	r.strategies["fifo"] = func() EvictionAlgo { return NewFIFO() }
+	r.strategies["lru"] = func() EvictionAlgo { return NewLRU() }
+	r.strategies["lfu"] = func() EvictionAlgo { return NewLFU() }
+	r.defaultTo = "lru"
+	return r
+}
+
+// Register adds s under name. Names are taken for good: registering one
+// again fails rather than replacing what others may already be using.
+func (r *Registry[S]) Register(name string, s S) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.strategies[name]; ok {
+		return fmt.Errorf("%w: %q", ErrDuplicateStrategy, name)
+	}
+	r.strategies[name] = s
+	return nil
+}
+
+func (r *Registry[S]) Get(name string) (S, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	s, ok := r.strategies[name]
+	if !ok {
+		return s, fmt.Errorf("%w: %q", ErrUnknownStrategy, name)
+	}
+	return s, nil
+}
+
+// SetDefault picks the strategy Resolve falls back to. It must already be
+// registered.
+func (r *Registry[S]) SetDefault(name string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.strategies[name]; !ok {
+		return fmt.Errorf("%w: %q", ErrUnknownStrategy, name)
+	}
+	r.defaultTo = name
+	return nil
+}
+
+// Resolve is Get for configuration values: an unknown name gets the default
+// strategy instead of an error, and the miss is counted in Fallbacks so a
+// typo in a config file doesn't go unnoticed.
+func (r *Registry[S]) Resolve(name string) (S, error) {
+	if s, err := r.Get(name); err == nil {
+		return s, nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	// registered between the two locks
+	if s, ok := r.strategies[name]; ok {
+		return s, nil
+	}
+	s, ok := r.strategies[r.defaultTo]
+	if !ok {
+		return s, fmt.Errorf("%w for unknown %q", ErrNoDefault, name)
+	}
+	r.fallbacks[name]++
+	return s, nil
+}
+
+// Fallbacks counts, per requested name, how often Resolve fell back.
+func (r *Registry[S]) Fallbacks() map[string]int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	fallbacks := make(map[string]int, len(r.fallbacks))
+	for name, n := range r.fallbacks {
+		fallbacks[name] = n
+	}
+	return fallbacks
+}
+
+// Names lists the registered names in sorted order.
+func (r *Registry[S]) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := make([]string, 0, len(r.strategies))
+	for name := range r.strategies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
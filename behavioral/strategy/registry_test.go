@@ -0,0 +1,122 @@
+package strategy
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"testing"
+)
+
+func TestBuiltInRegistries(t *testing.T) {
+	sorts := NewSortRegistry()
+	if got, want := sorts.Names(), []string{"auto", "insertion", "parallel", "std"}; !slices.Equal(got, want) {
+		t.Fatalf("sort registry has %v, want %v", got, want)
+	}
+	s, err := sorts.Get("insertion")
+	if err != nil || s != (InsertionSort{}) {
+		t.Fatalf("Get(insertion) = %T, %v", s, err)
+	}
+
+	evictions := NewEvictionRegistry()
+	newAlgo, err := evictions.Get("lfu")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if newAlgo() == newAlgo() {
+		t.Fatal("two caches would share one LFU")
+	}
+	cache := NewCache(2, newAlgo())
+	if got := run(cache, "+a +b ?a +c"); !slices.Equal(got, []string{"b"}) {
+		t.Fatalf("registered LFU evicted %v, want [b]", got)
+	}
+}
+
+func TestRegistryLookup(t *testing.T) {
+	r := NewRegistry[SortStrategy]()
+	if err := r.Register("mine", InsertionSort{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Get("mine"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Get("missing"); !errors.Is(err, ErrUnknownStrategy) {
+		t.Fatalf("Get(missing) = %v, want %v", err, ErrUnknownStrategy)
+	}
+	if err := r.Register("mine", StdSort{}); !errors.Is(err, ErrDuplicateStrategy) {
+		t.Fatalf("second Register(mine) = %v, want %v", err, ErrDuplicateStrategy)
+	}
+	if s, _ := r.Get("mine"); s != (InsertionSort{}) {
+		t.Fatalf("duplicate Register replaced the strategy with %T", s)
+	}
+	if err := r.SetDefault("missing"); !errors.Is(err, ErrUnknownStrategy) {
+		t.Fatalf("SetDefault(missing) = %v, want %v", err, ErrUnknownStrategy)
+	}
+}
+
+func TestResolveFallsBackAndCounts(t *testing.T) {
+	r := NewSortRegistry()
+	for _, name := range []string{"std", "quick", "quick", "", "insertion"} {
+		if _, err := r.Resolve(name); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if s, _ := r.Resolve("std"); s != (StdSort{}) {
+		t.Fatalf("Resolve(std) = %T, want StdSort", s)
+	}
+	if s, _ := r.Resolve("bogo"); s != (AutoSort{}) {
+		t.Fatalf("Resolve(bogo) = %T, want the default AutoSort", s)
+	}
+	want := map[string]int{"quick": 2, "": 1, "bogo": 1}
+	if got := r.Fallbacks(); !maps.Equal(got, want) {
+		t.Fatalf("Fallbacks() = %v, want %v", got, want)
+	}
+
+	if err := r.SetDefault("std"); err != nil {
+		t.Fatal(err)
+	}
+	if s, _ := r.Resolve("quick"); s != (StdSort{}) {
+		t.Fatalf("Resolve(quick) after SetDefault(std) = %T", s)
+	}
+	if _, err := NewRegistry[SortStrategy]().Resolve("quick"); !errors.Is(err, ErrNoDefault) {
+		t.Fatalf("Resolve without a default = %v, want %v", err, ErrNoDefault)
+	}
+}
+
+func TestRegistryConcurrentUse(t *testing.T) {
+	r := NewSortRegistry()
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				name := fmt.Sprintf("custom-%d-%d", g, i)
+				if err := r.Register(name, InsertionSort{}); err != nil {
+					t.Error(err)
+					return
+				}
+				if _, err := r.Get(name); err != nil {
+					t.Error(err)
+					return
+				}
+				if _, err := r.Resolve(fmt.Sprint("unknown-", g)); err != nil {
+					t.Error(err)
+					return
+				}
+				r.Names()
+				r.Fallbacks()
+			}
+		}()
+	}
+	wg.Wait()
+	if n := len(r.Names()); n != 4+8*100 {
+		t.Fatalf("%d strategies registered, want %d", n, 4+8*100)
+	}
+	for g := 0; g < 8; g++ {
+		if n := r.Fallbacks()[fmt.Sprint("unknown-", g)]; n != 100 {
+			t.Fatalf("unknown-%d fell back %d times, want 100", g, n)
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package strategy
+
+import (
+	"sort"
+	"sync"
+)
+
+// Record is what the Sorter example sorts.
+type Record struct {
+	Key  int
+	Name string
+}
+
+// ByKey orders records by ascending Key.
+func ByKey(a, b Record) bool {
+	return a.Key < b.Key
+}
+
+// SortStrategy sorts records in place by less.
+type SortStrategy interface {
+	Sort(records []Record, less func(a, b Record) bool)
+}
+
+// Sorter is the context for the sorting strategies.
+type Sorter struct {
+	sorting *Context[SortJob, struct{}]
+	less    func(a, b Record) bool
+}
+
+func NewSorter(strategy SortStrategy, less func(a, b Record) bool) *Sorter {
+	return &Sorter{
+		sorting: NewContext(sortStrategy(strategy)),
+		less:    less,
+	}
+}
+
+func (s *Sorter) SetStrategy(strategy SortStrategy) {
+	s.sorting.SetStrategy(sortStrategy(strategy))
+}
+
+func (s *Sorter) Sort(records []Record) {
+	s.sorting.Execute(SortJob{Records: records, Less: s.less})
+}
+
+// InsertionSort is stable and quadratic, and the fastest on a handful of
+// records or input that is already nearly sorted.
+type InsertionSort struct{}
+
+func (InsertionSort) Sort(records []Record, less func(a, b Record) bool) {
+	insertionSort(records, less)
+}
+
+func insertionSort(records []Record, less func(a, b Record) bool) {
+	for i := 1; i < len(records); i++ {
+		for j := i; j > 0 && less(records[j], records[j-1]); j-- {
+			records[j], records[j-1] = records[j-1], records[j]
+		}
+	}
+}
+
+// StdSort hands the work to sort.Slice, so equal records may be reordered.
+type StdSort struct{}
+
+func (StdSort) Sort(records []Record, less func(a, b Record) bool) {
+	sort.Slice(records, func(i, j int) bool {
+		return less(records[i], records[j])
+	})
+}
+
+// defaultParallelThreshold keeps every goroutine busy with a few thousand
+// records; on smaller ranges starting one costs more than it saves.
+const defaultParallelThreshold = 4096
+
+// mergeCutoff is the length under which merge sort switches to insertion sort.
+const mergeCutoff = 16
+
+// ParallelMergeSort is a stable merge sort that sorts both halves of any
+// range longer than threshold on separate goroutines. Shorter ranges are
+// merge sorted on the current goroutine.
+type ParallelMergeSort struct {
+	threshold int
+}
+
+// NewParallelMergeSort uses defaultParallelThreshold when threshold is below 1.
+func NewParallelMergeSort(threshold int) *ParallelMergeSort {
+	if threshold < 1 {
+		threshold = defaultParallelThreshold
+	}
+	return &ParallelMergeSort{threshold: threshold}
+}
+
+func (p *ParallelMergeSort) Sort(records []Record, less func(a, b Record) bool) {
+	buf := make([]Record, len(records))
+	p.sort(records, buf, less)
+}
+
+func (p *ParallelMergeSort) sort(records, buf []Record, less func(a, b Record) bool) {
+	if len(records) <= p.threshold {
+		mergeSort(records, buf, less)
+		return
+	}
+	mid := len(records) / 2
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		p.sort(records[:mid], buf[:mid], less)
+	}()
+	p.sort(records[mid:], buf[mid:], less)
+	wg.Wait()
+	merge(records, mid, buf, less)
+}
+
+func mergeSort(records, buf []Record, less func(a, b Record) bool) {
+	if len(records) <= mergeCutoff {
+		insertionSort(records, less)
+		return
+	}
+	mid := len(records) / 2
+	mergeSort(records[:mid], buf[:mid], less)
+	mergeSort(records[mid:], buf[mid:], less)
+	merge(records, mid, buf, less)
+}
+
+// merge combines the sorted halves records[:mid] and records[mid:], using
+// buf, which is as long as records, as scratch space. Ties take from the left
+// half, which keeps the sort stable.
+func merge(records []Record, mid int, buf []Record, less func(a, b Record) bool) {
+	if !less(records[mid], records[mid-1]) {
+		return
+	}
+	copy(buf, records)
+	i, j := 0, mid
+	for k := range records {
+		if j == len(records) || i < mid && !less(buf[j], buf[i]) {
+			records[k] = buf[i]
+			i++
+		} else {
+			records[k] = buf[j]
+			j++
+		}
+	}
+}
+
+// autoInsertionMax is where insertion sort stops beating the merge sort in
+// BenchmarkSortStrategies. sort.Slice lost to the merge sort at every size
+// measured, its reflection-based swaps costing more than the merge's
+// copying, so AutoSort never picks StdSort.
+const autoInsertionMax = 32
+
+// AutoSort picks a strategy by input size: insertion sort for a few records,
+// ParallelMergeSort otherwise, which itself only splits large inputs.
+type AutoSort struct{}
+
+func (AutoSort) Sort(records []Record, less func(a, b Record) bool) {
+	pick(len(records)).Sort(records, less)
+}
+
+func pick(n int) SortStrategy {
+	if n <= autoInsertionMax {
+		return InsertionSort{}
+	}
+	return NewParallelMergeSort(0)
+}
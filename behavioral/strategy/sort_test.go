@@ -0,0 +1,151 @@
+package strategy
+
+import (
+	"fmt"
+	"math/rand"
+	"runtime"
+	"slices"
+	"sort"
+	"sync/atomic"
+	"testing"
+)
+
+func inputs(n int) map[string][]Record {
+	rng := rand.New(rand.NewSource(int64(n)))
+	random, sorted, reversed, duplicates := make([]Record, n), make([]Record, n), make([]Record, n), make([]Record, n)
+	for i := range random {
+		random[i] = Record{Key: rng.Intn(n * 10), Name: fmt.Sprint("r", i)}
+		sorted[i] = Record{Key: i, Name: fmt.Sprint("s", i)}
+		reversed[i] = Record{Key: n - i, Name: fmt.Sprint("v", i)}
+		duplicates[i] = Record{Key: rng.Intn(3), Name: fmt.Sprint("d", i)}
+	}
+	return map[string][]Record{
+		"random":     random,
+		"sorted":     sorted,
+		"reversed":   reversed,
+		"duplicates": duplicates,
+	}
+}
+
+func strategies() map[string]SortStrategy {
+	return map[string]SortStrategy{
+		"insertion": InsertionSort{},
+		"std":       StdSort{},
+		"parallel":  NewParallelMergeSort(64),
+		"auto":      AutoSort{},
+	}
+}
+
+func TestSortStrategiesMatchReference(t *testing.T) {
+	stable := map[string]bool{"insertion": true, "parallel": true}
+	for _, n := range []int{0, 1, 2, 17, 100, 1000} {
+		for input, records := range inputs(n) {
+			want := slices.Clone(records)
+			sort.SliceStable(want, func(i, j int) bool { return want[i].Key < want[j].Key })
+
+			for name, strategy := range strategies() {
+				got := slices.Clone(records)
+				NewSorter(strategy, ByKey).Sort(got)
+				if stable[name] {
+					if !slices.Equal(got, want) {
+						t.Fatalf("%s on %d %s records is not the stable order", name, n, input)
+					}
+					continue
+				}
+				if !slices.IsSortedFunc(got, func(a, b Record) int { return a.Key - b.Key }) {
+					t.Fatalf("%s on %d %s records is not sorted", name, n, input)
+				}
+				slices.SortStableFunc(got, func(a, b Record) int { return a.Key - b.Key })
+				gotNames, wantNames := names(got), names(want)
+				slices.Sort(gotNames)
+				slices.Sort(wantNames)
+				if !slices.Equal(gotNames, wantNames) {
+					t.Fatalf("%s on %d %s records lost or duplicated records", name, n, input)
+				}
+			}
+		}
+	}
+}
+
+func names(records []Record) []string {
+	out := make([]string, len(records))
+	for i, r := range records {
+		out[i] = r.Name
+	}
+	return out
+}
+
+func TestSorterSwapsStrategy(t *testing.T) {
+	descending := func(a, b Record) bool { return a.Key > b.Key }
+	sorter := NewSorter(InsertionSort{}, descending)
+	records := []Record{{Key: 1}, {Key: 3}, {Key: 2}}
+	sorter.Sort(records)
+	sorter.SetStrategy(NewParallelMergeSort(1))
+	more := []Record{{Key: 5}, {Key: 9}, {Key: 7}, {Key: 8}}
+	sorter.Sort(more)
+	if records[0].Key != 3 || more[0].Key != 9 || more[3].Key != 5 {
+		t.Fatalf("sorted %v and %v, want both descending", records, more)
+	}
+}
+
+func TestParallelMergeSortUsesGoroutines(t *testing.T) {
+	var inFlight, peak atomic.Int64
+	less := func(a, b Record) bool {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for p := peak.Load(); n > p && !peak.CompareAndSwap(p, n); p = peak.Load() {
+		}
+		// let the other half's goroutine run even on one CPU
+		runtime.Gosched()
+		return a.Key < b.Key
+	}
+	NewParallelMergeSort(32).Sort(inputs(2048)["random"], less)
+	if peak.Load() < 2 {
+		t.Fatal("comparisons never overlapped, so the halves were sorted one after the other")
+	}
+
+	peak.Store(0)
+	NewParallelMergeSort(4096).Sort(inputs(2048)["random"], less)
+	if peak.Load() != 1 {
+		t.Fatalf("%d comparisons overlapped below the threshold, want 1", peak.Load())
+	}
+}
+
+func TestAutoSortPicksBySize(t *testing.T) {
+	for _, n := range []int{0, autoInsertionMax} {
+		if got := pick(n); got != (InsertionSort{}) {
+			t.Fatalf("pick(%d) = %T, want InsertionSort", n, got)
+		}
+	}
+	for _, n := range []int{autoInsertionMax + 1, 1 << 20} {
+		if got, ok := pick(n).(*ParallelMergeSort); !ok || got.threshold != defaultParallelThreshold {
+			t.Fatalf("pick(%d) = %#v, want the default ParallelMergeSort", n, pick(n))
+		}
+	}
+}
+
+// BenchmarkSortStrategies backs the AutoSort thresholds: compare the
+// strategies at each size with
+//
+//	go test -bench SortStrategies ./behavioral/strategy
+func BenchmarkSortStrategies(b *testing.B) {
+	for _, n := range []int{8, 32, 128, 1024, 1 << 15, 1 << 18} {
+		records := inputs(n)["random"]
+		for _, name := range []string{"insertion", "std", "parallel", "auto"} {
+			if name == "insertion" && n > 1024 {
+				continue
+			}
+			strategy := strategies()[name]
+			if name == "parallel" {
+				strategy = NewParallelMergeSort(0)
+			}
+			b.Run(fmt.Sprintf("%s/%d", name, n), func(b *testing.B) {
+				work := make([]Record, n)
+				for i := 0; i < b.N; i++ {
+					copy(work, records)
+					strategy.Sort(work, ByKey)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,202 @@
+package strategy
+
+import "container/list"
+
+//Strategy is a behavioral design pattern that lets you define a family of algorithms, put each of them into a separate class, and make their objects interchangeable.
+//The Strategy pattern suggests that you take a class that does something specific in a lot of different ways and extract all of these algorithms into separate classes called strategies.
+//The original class, called context, must have a field for storing a reference to one of the strategies. The context delegates the work to a linked strategy object instead of executing it on its own.
+//The context isn’t responsible for selecting an appropriate algorithm for the job. Instead, the client passes the desired strategy to the context.
+
+//How to Implement
+//
+//In the context class, identify an algorithm that’s prone to frequent changes. It may also be a massive conditional that selects and executes a variant of the same algorithm at runtime.
+//
+//Declare the strategy interface common to all variants of the algorithm.
+//
+//One by one, extract all algorithms into their own classes. They should all implement the strategy interface.
+//
+//In the context class, add a field for storing a reference to a strategy object. Provide a setter for replacing values of that field.
+//The context should work with the strategy object only via the strategy interface. The context may define an interface which lets the strategy access its data.
+//
+//Clients of the context must associate it with a suitable strategy that matches the way they expect the context to perform its primary job.
+
+// EvictionAlgo decides which key a full Cache drops. The cache tells it about
+// every key it adds and every hit, so it can keep whatever it ranks keys by.
+type EvictionAlgo interface {
+	Added(key string)
+	Accessed(key string)
+	// Evict picks the key to drop and forgets it. The cache only asks when
+	// it holds at least one key.
+	Evict() string
+}
+
+// Cache is the context: it stores values and leaves the choice of victim to
+// its EvictionAlgo, which can be swapped while the cache is in use.
+type Cache struct {
+	storage  map[string]string
+	order    []string
+	capacity int
+	eviction *Context[CacheEvent, string]
+}
+
+// NewCache makes a cache holding at most capacity keys, at least one.
+func NewCache(capacity int, algo EvictionAlgo) *Cache {
+	return &Cache{
+		storage:  make(map[string]string),
+		order:    make([]string, 0),
+		capacity: max(capacity, 1),
+		eviction: NewContext(evictionStrategy(algo)),
+	}
+}
+
+// SetEvictionAlgo switches strategy. The new one knows nothing about how
+// the keys were used so far; it is told about them in the order they were
+// added, as if they had just been added.
+func (c *Cache) SetEvictionAlgo(algo EvictionAlgo) {
+	c.eviction.SetStrategy(evictionStrategy(algo))
+	for _, key := range c.order {
+		c.eviction.Execute(CacheEvent{Kind: KeyAdded, Key: key})
+	}
+}
+
+// Add stores value under key. A new key added to a full cache evicts
+// another one first, which Add returns. Overwriting a key counts as using it.
+func (c *Cache) Add(key, value string) (evicted string, ok bool) {
+	if _, exists := c.storage[key]; exists {
+		c.storage[key] = value
+		c.eviction.Execute(CacheEvent{Kind: KeyAccessed, Key: key})
+		return "", false
+	}
+	if len(c.storage) >= c.capacity {
+		evicted, ok = c.evict(), true
+	}
+	c.storage[key] = value
+	c.order = append(c.order, key)
+	c.eviction.Execute(CacheEvent{Kind: KeyAdded, Key: key})
+	return evicted, ok
+}
+
+func (c *Cache) Get(key string) (string, bool) {
+	value, ok := c.storage[key]
+	if ok {
+		c.eviction.Execute(CacheEvent{Kind: KeyAccessed, Key: key})
+	}
+	return value, ok
+}
+
+func (c *Cache) Len() int {
+	return len(c.storage)
+}
+
+func (c *Cache) evict() string {
+	key := c.eviction.Execute(CacheEvent{Kind: EvictKey})
+	delete(c.storage, key)
+	for i, k := range c.order {
+		if k == key {
+			c.order = append(c.order[:i], c.order[i+1:]...)
+			break
+		}
+	}
+	return key
+}
+
+// FIFO evicts the key that was added first, however often it is used.
+type FIFO struct {
+	queue *list.List
+}
+
+func NewFIFO() *FIFO {
+	return &FIFO{queue: list.New()}
+}
+
+func (f *FIFO) Added(key string) {
+	f.queue.PushBack(key)
+}
+
+func (f *FIFO) Accessed(key string) {}
+
+func (f *FIFO) Evict() string {
+	return f.queue.Remove(f.queue.Front()).(string)
+}
+
+// LRU evicts the key that has gone longest without being added or read.
+type LRU struct {
+	recency  *list.List
+	elements map[string]*list.Element
+}
+
+func NewLRU() *LRU {
+	return &LRU{
+		recency:  list.New(),
+		elements: make(map[string]*list.Element),
+	}
+}
+
+func (l *LRU) Added(key string) {
+	l.elements[key] = l.recency.PushBack(key)
+}
+
+func (l *LRU) Accessed(key string) {
+	if e, ok := l.elements[key]; ok {
+		l.recency.MoveToBack(e)
+	}
+}
+
+func (l *LRU) Evict() string {
+	key := l.recency.Remove(l.recency.Front()).(string)
+	delete(l.elements, key)
+	return key
+}
+
+// LFU evicts the key used the fewest times. Adding a key counts as its first
+// use; among equally used keys the least recently used one goes.
+type LFU struct {
+	uses     map[string]int
+	lastUsed map[string]int
+	clock    int
+}
+
+func NewLFU() *LFU {
+	return &LFU{
+		uses:     make(map[string]int),
+		lastUsed: make(map[string]int),
+	}
+}
+
+func (l *LFU) Added(key string) {
+	l.uses[key] = 0
+	l.Accessed(key)
+}
+
+func (l *LFU) Accessed(key string) {
+	if _, ok := l.uses[key]; !ok {
+		return
+	}
+	l.clock++
+	l.uses[key]++
+	l.lastUsed[key] = l.clock
+}
+
+func (l *LFU) Evict() string {
+	victim, found := "", false
+	for key, uses := range l.uses {
+		if !found || uses < l.uses[victim] ||
+			uses == l.uses[victim] && l.lastUsed[key] < l.lastUsed[victim] {
+			victim, found = key, true
+		}
+	}
+	delete(l.uses, victim)
+	delete(l.lastUsed, victim)
+	return victim
+}
+
+//Pros and Cons
+//
+//You can swap algorithms used inside an object at runtime.
+//You can isolate the implementation details of an algorithm from the code that uses it.
+//You can replace inheritance with composition.
+//Open/Closed Principle. You can introduce new strategies without having to change the context.
+//
+//If you only have a couple of algorithms and they rarely change, there’s no real reason to overcomplicate the program with new classes and interfaces that come along with the pattern.
+//Clients must be aware of the differences between strategies to be able to select a proper one.
+//A lot of modern programming languages have functional type support that lets you implement different versions of an algorithm inside a set of anonymous functions.
@@ -0,0 +1,98 @@
+package strategy
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+// run applies a script of "+key" (add) and "?key" (get) steps and returns
+// the keys evicted along the way.
+func run(c *Cache, script string) []string {
+	evicted := make([]string, 0)
+	for _, step := range strings.Fields(script) {
+		key := step[1:]
+		switch step[0] {
+		case '+':
+			if victim, ok := c.Add(key, "value of "+key); ok {
+				evicted = append(evicted, victim)
+			}
+		case '?':
+			c.Get(key)
+		}
+	}
+	return evicted
+}
+
+func TestEvictionStrategies(t *testing.T) {
+	tests := []struct {
+		name   string
+		algo   func() EvictionAlgo
+		script string
+		want   []string
+	}{
+		{"fifo ignores reads", func() EvictionAlgo { return NewFIFO() }, "+a +b +c ?a ?a +d +e", []string{"a", "b"}},
+		{"lru keeps recently read", func() EvictionAlgo { return NewLRU() }, "+a +b +c ?a +d ?c +e", []string{"b", "a"}},
+		{"lru counts overwrites", func() EvictionAlgo { return NewLRU() }, "+a +b +c +a +d", []string{"b"}},
+		{"lfu keeps frequently read", func() EvictionAlgo { return NewLFU() }, "+a +b +c ?a ?a ?b +d +e", []string{"c", "d"}},
+		{"lfu breaks ties by recency", func() EvictionAlgo { return NewLFU() }, "+a +b +c ?c ?b ?a +d", []string{"c"}},
+		{"lfu counts overwrites", func() EvictionAlgo { return NewLFU() }, "+a +b +c +a +b +d", []string{"c"}},
+		{"misses are not uses", func() EvictionAlgo { return NewLRU() }, "+a +b +c ?zz +d", []string{"a"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewCache(3, tt.algo())
+			if got := run(c, tt.script); !slices.Equal(got, tt.want) {
+				t.Fatalf("evicted %v, want %v", got, tt.want)
+			}
+			if c.Len() != 3 {
+				t.Fatalf("Len() = %d, want 3", c.Len())
+			}
+			for _, key := range tt.want {
+				if _, ok := c.Get(key); ok {
+					t.Fatalf("Get(%q) found an evicted key", key)
+				}
+			}
+		})
+	}
+}
+
+func TestCacheGet(t *testing.T) {
+	c := NewCache(2, NewFIFO())
+	c.Add("a", "1")
+	c.Add("a", "2")
+	if v, ok := c.Get("a"); !ok || v != "2" {
+		t.Fatalf("Get(a) = %q, %v, want the overwritten value", v, ok)
+	}
+	if _, ok := c.Get("b"); ok {
+		t.Fatal("Get(b) found a key never added")
+	}
+	if c.Len() != 1 {
+		t.Fatalf("Len() = %d after overwriting, want 1", c.Len())
+	}
+}
+
+func TestSwapStrategyMidRun(t *testing.T) {
+	c := NewCache(3, NewLFU())
+	if got := run(c, "+a +b +c ?a ?a ?a ?b +d"); !slices.Equal(got, []string{"c"}) {
+		t.Fatalf("LFU evicted %v, want [c]", got)
+	}
+
+	// FIFO learns the current keys in the order they were added: a, b, d
+	c.SetEvictionAlgo(NewFIFO())
+	if got := run(c, "?a +e +f"); !slices.Equal(got, []string{"a", "b"}) {
+		t.Fatalf("FIFO after the swap evicted %v, want [a b]", got)
+	}
+
+	c.SetEvictionAlgo(NewLRU())
+	if got := run(c, "?d +g"); !slices.Equal(got, []string{"e"}) {
+		t.Fatalf("LRU after the swap evicted %v, want [e]", got)
+	}
+}
+
+func TestCapacityIsAtLeastOne(t *testing.T) {
+	c := NewCache(0, NewLRU())
+	if got := run(c, "+a +b"); !slices.Equal(got, []string{"a"}) {
+		t.Fatalf("evicted %v, want [a]", got)
+	}
+}
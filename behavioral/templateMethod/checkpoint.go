@@ -0,0 +1,225 @@
+package templateMethod
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+)
+
+var (
+	ErrCorruptCheckpoint   = errors.New("templateMethod: checkpoint is corrupt")
+	ErrCheckpointDiscarded = errors.New("templateMethod: checkpoint discarded, ran from the start")
+	ErrInvalidRunID        = errors.New("templateMethod: invalid run id")
+)
+
+// Checkpoint is what a resumable run has finished so far.
+type Checkpoint struct {
+	Template string   `json:"template"`
+	Version  int      `json:"version"`
+	Done     []string `json:"done"`
+}
+
+// Checkpointer stores one Checkpoint per run.
+type Checkpointer interface {
+	// Load reports found as false when the run has no checkpoint yet.
+	Load(runID string) (cp Checkpoint, found bool, err error)
+	Save(runID string, cp Checkpoint) error
+}
+
+// MemoryCheckpointer keeps checkpoints for the life of the process. It is
+// safe for concurrent use.
+type MemoryCheckpointer struct {
+	mu          sync.Mutex
+	checkpoints map[string]Checkpoint
+}
+
+func NewMemoryCheckpointer() *MemoryCheckpointer {
+	return &MemoryCheckpointer{checkpoints: make(map[string]Checkpoint)}
+}
+
+func (m *MemoryCheckpointer) Load(runID string) (Checkpoint, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	cp, ok := m.checkpoints[runID]
+	cp.Done = slices.Clone(cp.Done)
+	return cp, ok, nil
+}
+
+func (m *MemoryCheckpointer) Save(runID string, cp Checkpoint) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	cp.Done = slices.Clone(cp.Done)
+	m.checkpoints[runID] = cp
+	return nil
+}
+
+// FileCheckpointer keeps each run's checkpoint as a JSON file in dir, so a
+// run can be resumed by another process.
+type FileCheckpointer struct {
+	dir string
+}
+
+func NewFileCheckpointer(dir string) *FileCheckpointer {
+	return &FileCheckpointer{dir: dir}
+}
+
+func (f *FileCheckpointer) path(runID string) (string, error) {
+	if runID == "" || filepath.Base(runID) != runID || runID == "." || runID == ".." {
+		return "", fmt.Errorf("%w: %q", ErrInvalidRunID, runID)
+	}
+	return filepath.Join(f.dir, runID+".json"), nil
+}
+
+func (f *FileCheckpointer) Load(runID string) (Checkpoint, bool, error) {
+	var cp Checkpoint
+	path, err := f.path(runID)
+	if err != nil {
+		return cp, false, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return cp, false, nil
+	}
+	if err != nil {
+		return cp, false, err
+	}
+	if err := json.Unmarshal(data, &cp); err != nil {
+		return cp, true, fmt.Errorf("%w: %v", ErrCorruptCheckpoint, err)
+	}
+	return cp, true, nil
+}
+
+// Save writes to a temporary file first, so a crash mid-write leaves the
+// previous checkpoint rather than half of the new one.
+func (f *FileCheckpointer) Save(runID string, cp Checkpoint) error {
+	path, err := f.path(runID)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(cp)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(f.dir, runID+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// ResumableStep is one step of a ResumableTemplate. Key identifies it in
+// checkpoints and defaults to Name. Run gets an idempotency key unique to
+// the run and step, which side effects can be deduplicated on should a step
+// run again after a crash between doing its work and being checkpointed.
+type ResumableStep struct {
+	Name string
+	Key  string
+	Run  func(idempotencyKey string) error
+}
+
+// ResumableTemplate is a template whose runs survive failing midway. Steps
+// that need earlier steps' results must keep them somewhere that outlives
+// the run, since a resumed run doesn't repeat the steps that produced them.
+type ResumableTemplate struct {
+	name        string
+	version     int
+	steps       []ResumableStep
+	checkpoints Checkpointer
+}
+
+// NewResumableTemplate identifies the template by name and version; bump
+// version whenever the steps change so old checkpoints aren't trusted.
+func NewResumableTemplate(name string, version int, checkpoints Checkpointer, steps ...ResumableStep) *ResumableTemplate {
+	t := &ResumableTemplate{
+		name:        name,
+		version:     version,
+		checkpoints: checkpoints,
+		steps:       make([]ResumableStep, len(steps)),
+	}
+	for i, s := range steps {
+		if s.Key == "" {
+			s.Key = s.Name
+		}
+		t.steps[i] = s
+	}
+	return t
+}
+
+// Run starts runID from the first step, whatever was checkpointed before.
+func (t *ResumableTemplate) Run(runID string) error {
+	return t.runFrom(runID, 0)
+}
+
+// Resume continues runID after its last checkpointed step, or starts it if
+// it has no checkpoint. A checkpoint that can't be read or belongs to
+// another template or version is not trusted: the run starts over and the
+// result is joined with an ErrCheckpointDiscarded warning saying why.
+func (t *ResumableTemplate) Resume(runID string) error {
+	cp, found, err := t.checkpoints.Load(runID)
+	if errors.Is(err, ErrCorruptCheckpoint) {
+		return t.restart(runID, err)
+	}
+	if err != nil {
+		return err
+	}
+	if !found {
+		return t.runFrom(runID, 0)
+	}
+	if reason := t.mismatch(cp); reason != "" {
+		return t.restart(runID, errors.New(reason))
+	}
+	return t.runFrom(runID, len(cp.Done))
+}
+
+func (t *ResumableTemplate) restart(runID string, reason error) error {
+	warning := fmt.Errorf("%w: %w", ErrCheckpointDiscarded, reason)
+	return errors.Join(warning, t.runFrom(runID, 0))
+}
+
+func (t *ResumableTemplate) mismatch(cp Checkpoint) string {
+	if cp.Template != t.name || cp.Version != t.version {
+		return fmt.Sprintf("saved by %s v%d, this is %s v%d", cp.Template, cp.Version, t.name, t.version)
+	}
+	if len(cp.Done) > len(t.steps) {
+		return "more steps done than the template has"
+	}
+	for i, key := range cp.Done {
+		if t.steps[i].Key != key {
+			return fmt.Sprintf("step %d is %q, checkpoint has %q", i, t.steps[i].Key, key)
+		}
+	}
+	return ""
+}
+
+// runFrom runs the steps from index first on, checkpointing after each.
+func (t *ResumableTemplate) runFrom(runID string, first int) error {
+	cp := Checkpoint{Template: t.name, Version: t.version, Done: make([]string, 0, len(t.steps))}
+	for _, s := range t.steps[:first] {
+		cp.Done = append(cp.Done, s.Key)
+	}
+	if err := t.checkpoints.Save(runID, cp); err != nil {
+		return err
+	}
+	for _, s := range t.steps[first:] {
+		if err := s.Run(runID + "/" + s.Key); err != nil {
+			return &StepError{Step: s.Name, Err: err}
+		}
+		cp.Done = append(cp.Done, s.Key)
+		if err := t.checkpoints.Save(runID, cp); err != nil {
+			return fmt.Errorf("checkpointing %s: %w", s.Name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package templateMethod
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// counted is a workflow of three steps whose calls are logged; the step
+// named in fail fails until fail is cleared.
+type counted struct {
+	calls []string
+	fail  string
+}
+
+func (c *counted) steps() []ResumableStep {
+	steps := make([]ResumableStep, 0)
+	for _, name := range []string{"charge", "ship", "email"} {
+		steps = append(steps, ResumableStep{
+			Name: name,
+			Run: func(key string) error {
+				c.calls = append(c.calls, key)
+				if name == c.fail {
+					return errStep
+				}
+				return nil
+			},
+		})
+	}
+	return steps
+}
+
+func checkpointers(t *testing.T) map[string]Checkpointer {
+	return map[string]Checkpointer{
+		"memory": NewMemoryCheckpointer(),
+		"file":   NewFileCheckpointer(t.TempDir()),
+	}
+}
+
+func TestResumeSkipsDoneSteps(t *testing.T) {
+	for name, cps := range checkpointers(t) {
+		t.Run(name, func(t *testing.T) {
+			work := &counted{fail: "ship"}
+			tmpl := NewResumableTemplate("order", 1, cps, work.steps()...)
+			if err := tmpl.Run("order-7"); failedStep(err) != "ship" {
+				t.Fatalf("Run() = %v, want ship to fail", err)
+			}
+			work.fail = ""
+			if err := tmpl.Resume("order-7"); err != nil {
+				t.Fatal(err)
+			}
+			want := []string{"order-7/charge", "order-7/ship", "order-7/ship", "order-7/email"}
+			if !slices.Equal(work.calls, want) {
+				t.Fatalf("calls = %v, want %v", work.calls, want)
+			}
+
+			// a finished run has nothing left to resume
+			if err := tmpl.Resume("order-7"); err != nil || len(work.calls) != len(want) {
+				t.Fatalf("Resume() of a finished run = %v after calls %v", err, work.calls)
+			}
+			// Run starts over regardless
+			if err := tmpl.Run("order-7"); err != nil || len(work.calls) != len(want)+3 {
+				t.Fatalf("Run() = %v after calls %v", err, work.calls)
+			}
+		})
+	}
+}
+
+func TestResumeWithoutCheckpointRunsEverything(t *testing.T) {
+	work := &counted{}
+	if err := NewResumableTemplate("order", 1, NewMemoryCheckpointer(), work.steps()...).Resume("new"); err != nil {
+		t.Fatal(err)
+	}
+	if len(work.calls) != 3 {
+		t.Fatalf("calls = %v, want all three steps", work.calls)
+	}
+}
+
+func TestResumeDiscardsMismatchedCheckpoints(t *testing.T) {
+	tests := []struct {
+		name string
+		cp   Checkpoint
+	}{
+		{"other version", Checkpoint{Template: "order", Version: 0, Done: []string{"charge"}}},
+		{"other template", Checkpoint{Template: "refund", Version: 1, Done: []string{"charge"}}},
+		{"other steps", Checkpoint{Template: "order", Version: 1, Done: []string{"reserve"}}},
+		{"too many steps", Checkpoint{Template: "order", Version: 1, Done: []string{"charge", "ship", "email", "survey"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cps := NewMemoryCheckpointer()
+			_ = cps.Save("run", tt.cp)
+			work := &counted{}
+			err := NewResumableTemplate("order", 1, cps, work.steps()...).Resume("run")
+			if !errors.Is(err, ErrCheckpointDiscarded) {
+				t.Fatalf("Resume() = %v, want %v", err, ErrCheckpointDiscarded)
+			}
+			if len(work.calls) != 3 {
+				t.Fatalf("calls = %v, want a clean restart", work.calls)
+			}
+			if cp, _, _ := cps.Load("run"); cp.Version != 1 || len(cp.Done) != 3 {
+				t.Fatalf("checkpoint after restart = %+v", cp)
+			}
+		})
+	}
+}
+
+func TestResumeDiscardsCorruptFile(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "run.json"), []byte("{not json"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	work := &counted{fail: "email"}
+	err := NewResumableTemplate("order", 1, NewFileCheckpointer(dir), work.steps()...).Resume("run")
+	if !errors.Is(err, ErrCheckpointDiscarded) || !errors.Is(err, ErrCorruptCheckpoint) || failedStep(err) != "email" {
+		t.Fatalf("Resume() = %v, want the warning joined with the email failure", err)
+	}
+	if len(work.calls) != 3 {
+		t.Fatalf("calls = %v", work.calls)
+	}
+}
+
+func TestFileCheckpointerRejectsPaths(t *testing.T) {
+	cps := NewFileCheckpointer(t.TempDir())
+	for _, id := range []string{"", "..", "../escape", "a/b"} {
+		if err := cps.Save(id, Checkpoint{}); !errors.Is(err, ErrInvalidRunID) {
+			t.Fatalf("Save(%q) = %v, want %v", id, err, ErrInvalidRunID)
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package templateMethod
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/Antonious-Stewart/15-Most-Common-Design-Patterns/internal/output"
+	"github.com/Antonious-Stewart/15-Most-Common-Design-Patterns/structural/composite"
+)
+
+// Demo renders a sales report as CSV and onboards recruits into a squad,
+// one of them a batch the squad has no room for.
+func Demo(w io.Writer) error {
+	sales := func() ([]Sale, error) {
+		return []Sale{
+			{Product: "widget", Quantity: 3, UnitCents: 250},
+			{Product: "gadget", Quantity: 1, UnitCents: 1999},
+			{Product: "widget", Quantity: 2, UnitCents: 250},
+		}, nil
+	}
+	if err := NewReportGenerator(NewCSVReport(sales, w), nil).Generate(); err != nil {
+		return err
+	}
+
+	squad := composite.NewSquad("delta", composite.WithSink(output.NewWriter(w)))
+	if err := NewOnboarding(ActiveDutyOnboarding{}).Run(squad, []string{"Ortiz", "Kim"}); err != nil {
+		return err
+	}
+	err := NewOnboarding(ActiveDutyOnboarding{}).Run(squad, []string{"Lee", "Ortiz"})
+	fmt.Fprintf(w, "second batch: %v\n", err)
+	fmt.Fprintf(w, "squad delta has %d members\n", len(squad.Children()))
+	return nil
+}
@@ -0,0 +1,101 @@
+package templateMethod
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+// hooked is a recorder that also implements both optional hooks.
+type hooked struct {
+	recorder
+}
+
+func (h *hooked) beforeSend(string) error {
+	return h.step("beforeSend")
+}
+
+func (h *hooked) afterSend(string) error {
+	return h.step("afterSend")
+}
+
+func TestHooks(t *testing.T) {
+	tests := []struct {
+		name       string
+		otp        IOtp
+		want       []string
+		wantErr    string
+		wantReport string
+	}{
+		{
+			name: "no hooks",
+			otp:  &recorder{},
+			want: allSteps,
+		},
+		{
+			name: "both hooks",
+			otp:  &hooked{},
+			want: []string{"genRandomOTP", "saveOTPCache", "getMessage", "beforeSend", "sendNotification", "afterSend"},
+		},
+		{
+			name:    "before fails",
+			otp:     &hooked{recorder{fail: "beforeSend"}},
+			want:    []string{"genRandomOTP", "saveOTPCache", "getMessage", "beforeSend"},
+			wantErr: "beforeSend",
+		},
+		{
+			name:       "after fails",
+			otp:        &hooked{recorder{fail: "afterSend"}},
+			want:       []string{"genRandomOTP", "saveOTPCache", "getMessage", "beforeSend", "sendNotification", "afterSend"},
+			wantReport: "afterSend",
+		},
+		{
+			name:    "send fails",
+			otp:     &hooked{recorder{fail: "sendNotification"}},
+			want:    []string{"genRandomOTP", "saveOTPCache", "getMessage", "beforeSend", "sendNotification"},
+			wantErr: "sendNotification",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var reported []error
+			otp := NewOtp(tt.otp)
+			otp.OnHookError(func(err error) {
+				reported = append(reported, err)
+			})
+			err := otp.GenAndSendOTP(4)
+
+			if got := failedStep(err); got != tt.wantErr {
+				t.Fatalf("GenAndSendOTP() = %v, want a failure in %q", err, tt.wantErr)
+			}
+			var got string
+			if len(reported) > 0 {
+				got = failedStep(reported[0])
+			}
+			if len(reported) > 1 || got != tt.wantReport {
+				t.Fatalf("reported %v, want a failure in %q", reported, tt.wantReport)
+			}
+			if steps := stepsOf(tt.otp); !slices.Equal(steps, tt.want) {
+				t.Fatalf("steps = %v, want %v", steps, tt.want)
+			}
+		})
+	}
+}
+
+func failedStep(err error) string {
+	var stepErr *StepError
+	if errors.As(err, &stepErr) {
+		return stepErr.Step
+	}
+	return ""
+}
+
+func stepsOf(otp IOtp) []string {
+	switch r := otp.(type) {
+	case *recorder:
+		return r.steps
+	case *hooked:
+		return r.steps
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package templateMethod
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Antonious-Stewart/15-Most-Common-Design-Patterns/structural/composite"
+)
+
+var (
+	ErrSquadFull     = errors.New("templateMethod: squad is full")
+	ErrDuplicateName = errors.New("templateMethod: name already in the squad")
+	ErrNoName        = errors.New("templateMethod: recruit has no name")
+)
+
+// IOnboarding is the part of onboarding that differs between services.
+type IOnboarding interface {
+	// validate decides whether recruit may join squad, given the members
+	// it has at that point of the batch.
+	validate(squad *composite.Squad, recruit *composite.Enlisted) error
+	welcome(recruit *composite.Enlisted) string
+}
+
+// OnboardingTemplate enlists a batch of recruits into a squad.
+type OnboardingTemplate struct {
+	onboarding IOnboarding
+}
+
+func NewOnboarding(onboarding IOnboarding) *OnboardingTemplate {
+	return &OnboardingTemplate{onboarding: onboarding}
+}
+
+// Run creates an Enlisted for every name, validates and attaches them one
+// by one, then briefs each with its welcome. A batch joins whole or not at
+// all: if one recruit fails validation, or the squad's own limits refuse to
+// attach it, the ones attached before it are removed again and nobody is
+// welcomed. Recruits whose welcome fails, such as an empty one, stay in the
+// squad and are reported as the welcome step.
+func (t *OnboardingTemplate) Run(s *composite.Squad, names []string) error {
+	recruits := make([]*composite.Enlisted, 0, len(names))
+	for _, name := range names {
+		if name == "" {
+			return &StepError{Step: "create", Err: ErrNoName}
+		}
+		recruits = append(recruits, composite.NewEnlisted(name))
+	}
+
+	for i, recruit := range recruits {
+		if err := t.onboarding.validate(s, recruit); err != nil {
+			detach(s, recruits[:i])
+			return &StepError{Step: "validate", Err: fmt.Errorf("%s: %w", recruit.Name(), err)}
+		}
+		if err := s.Add(recruit); err != nil {
+			detach(s, recruits[:i])
+			return &StepError{Step: "attach", Err: fmt.Errorf("%s: %w", recruit.Name(), err)}
+		}
+	}
+
+	failed := make([]error, 0)
+	for _, recruit := range recruits {
+		if err := recruit.Brief(t.onboarding.welcome(recruit)); err != nil {
+			failed = append(failed, err)
+		}
+	}
+	if len(failed) > 0 {
+		return &StepError{Step: "welcome", Err: errors.Join(failed...)}
+	}
+	return nil
+}
+
+// detach takes the recruits attached so far back out of s.
+func detach(s *composite.Squad, attached []*composite.Enlisted) {
+	for _, recruit := range attached {
+		s.Remove(recruit)
+	}
+}
+
+// ReserveOnboarding fills reserve squads, which run larger and don't mind
+// two members sharing a name.
+type ReserveOnboarding struct{}
+
+const reserveSquadSize = 12
+
+func (ReserveOnboarding) validate(s *composite.Squad, recruit *composite.Enlisted) error {
+	if len(s.Children()) >= reserveSquadSize {
+		return ErrSquadFull
+	}
+	return nil
+}
+
+func (ReserveOnboarding) welcome(recruit *composite.Enlisted) string {
+	return fmt.Sprintf("Welcome to the reserve, %s. Drill is one weekend a month.", recruit.Name())
+}
+
+// ActiveDutyOnboarding fills active squads: nine members at most, each
+// with a name of their own so orders can't go to the wrong one.
+type ActiveDutyOnboarding struct{}
+
+const activeSquadSize = 9
+
+func (ActiveDutyOnboarding) validate(s *composite.Squad, recruit *composite.Enlisted) error {
+	members := s.Children()
+	if len(members) >= activeSquadSize {
+		return ErrSquadFull
+	}
+	for _, m := range members {
+		if m.Name() == recruit.Name() {
+			return ErrDuplicateName
+		}
+	}
+	return nil
+}
+
+func (ActiveDutyOnboarding) welcome(recruit *composite.Enlisted) string {
+	return fmt.Sprintf("Private %s, report to your squad leader at 0600.", recruit.Name())
+}
@@ -0,0 +1,173 @@
+package templateMethod
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/Antonious-Stewart/15-Most-Common-Design-Patterns/structural/composite"
+)
+
+func memberNames(s *composite.Squad) []string {
+	names := make([]string, 0)
+	for _, m := range s.Children() {
+		names = append(names, m.(*composite.Enlisted).Name())
+	}
+	return names
+}
+
+func recruits(n int) []string {
+	names := make([]string, n)
+	for i := range names {
+		names[i] = fmt.Sprintf("recruit-%d", i+1)
+	}
+	return names
+}
+
+func TestOnboardingFillsSquad(t *testing.T) {
+	tests := []struct {
+		name       string
+		onboarding IOnboarding
+		capacity   int
+	}{
+		{"reserve", ReserveOnboarding{}, reserveSquadSize},
+		{"active duty", ActiveDutyOnboarding{}, activeSquadSize},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			squad := composite.NewSquad("alpha")
+			squad.Add(composite.NewEnlisted("sergeant"))
+			names := recruits(tt.capacity - 1)
+			if err := NewOnboarding(tt.onboarding).Run(squad, names); err != nil {
+				t.Fatal(err)
+			}
+			if want := append([]string{"sergeant"}, names...); !slices.Equal(memberNames(squad), want) {
+				t.Fatalf("members = %v, want %v", memberNames(squad), want)
+			}
+
+			err := NewOnboarding(tt.onboarding).Run(squad, []string{"late"})
+			if !errors.Is(err, ErrSquadFull) || failedStep(err) != "validate" {
+				t.Fatalf("Run() on a full squad = %v, want %v", err, ErrSquadFull)
+			}
+		})
+	}
+}
+
+func TestOnboardingRollsBack(t *testing.T) {
+	squad := composite.NewSquad("bravo")
+	if err := NewOnboarding(ActiveDutyOnboarding{}).Run(squad, recruits(7)); err != nil {
+		t.Fatal(err)
+	}
+	before := memberNames(squad)
+
+	// the first two fit, the third hits the limit of nine
+	err := NewOnboarding(ActiveDutyOnboarding{}).Run(squad, []string{"x", "y", "z"})
+	if !errors.Is(err, ErrSquadFull) {
+		t.Fatalf("Run() = %v, want %v", err, ErrSquadFull)
+	}
+	if got := memberNames(squad); !slices.Equal(got, before) {
+		t.Fatalf("members after a failed batch = %v, want %v", got, before)
+	}
+
+	err = NewOnboarding(ActiveDutyOnboarding{}).Run(squad, []string{"new", "recruit-3"})
+	if !errors.Is(err, ErrDuplicateName) {
+		t.Fatalf("Run() with a taken name = %v, want %v", err, ErrDuplicateName)
+	}
+	if got := memberNames(squad); !slices.Equal(got, before) {
+		t.Fatalf("members after a duplicate = %v, want %v", got, before)
+	}
+
+	// reserve squads take the same name twice
+	if err := NewOnboarding(ReserveOnboarding{}).Run(squad, []string{"recruit-3"}); err != nil {
+		t.Fatalf("reserve Run() with a taken name = %v", err)
+	}
+	if err := NewOnboarding(ReserveOnboarding{}).Run(squad, []string{"ok", ""}); !errors.Is(err, ErrNoName) {
+		t.Fatalf("Run() with an empty name = %v, want %v", err, ErrNoName)
+	}
+	if n := len(squad.Children()); n != 8 {
+		t.Fatalf("squad has %d members, want 8", n)
+	}
+}
+
+func TestOnboardingRollsBackWhenTheSquadRefuses(t *testing.T) {
+	tests := []struct {
+		name  string
+		opt   composite.UnitOption
+		batch []string
+		want  error
+	}{
+		{"capacity", composite.WithCapacity(2), []string{"ana", "ben", "cy"}, composite.ErrCapacityExceeded},
+		{"unique names", composite.WithUniqueNames(), []string{"ana", "ben", "ana"}, composite.ErrDuplicateName},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			squad := composite.NewSquad("echo", tt.opt)
+			squad.SetOutput(&out)
+
+			// reserve validation lets both batches through, so the squad's
+			// own limits are what refuse the third recruit
+			err := NewOnboarding(ReserveOnboarding{}).Run(squad, tt.batch)
+			if !errors.Is(err, tt.want) || failedStep(err) != "attach" {
+				t.Fatalf("Run() = %v, want an attach StepError with %v", err, tt.want)
+			}
+			if got := memberNames(squad); len(got) != 0 {
+				t.Fatalf("members after a refused batch = %v, want none", got)
+			}
+			if out.Len() != 0 {
+				t.Fatalf("welcomed %q after a refused batch, want nobody", out.String())
+			}
+		})
+	}
+}
+
+func TestOnboardingAttachesToTheSquad(t *testing.T) {
+	platoon := composite.NewPlatoon("first")
+	squad := composite.NewSquad("charlie")
+	platoon.Add(squad)
+	if err := NewOnboarding(ReserveOnboarding{}).Run(squad, []string{"ana", "ben"}); err != nil {
+		t.Fatal(err)
+	}
+
+	squads := platoon.Children()
+	if len(squads) != 1 || squads[0] != composite.Soldier(squad) {
+		t.Fatalf("platoon children = %v, want just the squad", squads)
+	}
+	if got := memberNames(squad); !slices.Equal(got, []string{"ana", "ben"}) {
+		t.Fatalf("members = %v", got)
+	}
+}
+
+// silentOnboarding accepts everyone and welcomes nobody.
+type silentOnboarding struct{}
+
+func (silentOnboarding) validate(*composite.Squad, *composite.Enlisted) error {
+	return nil
+}
+
+func (silentOnboarding) welcome(*composite.Enlisted) string {
+	return ""
+}
+
+func TestOnboardingReportsFailedWelcomes(t *testing.T) {
+	squad := composite.NewSquad("delta")
+	err := NewOnboarding(silentOnboarding{}).Run(squad, []string{"Ortiz", "Kim"})
+	var step *StepError
+	if !errors.As(err, &step) || step.Step != "welcome" || !errors.Is(err, composite.ErrEmptyOrders) {
+		t.Fatalf("Run() = %v, want a welcome StepError with ErrEmptyOrders", err)
+	}
+	if got := memberNames(squad); !slices.Equal(got, []string{"Ortiz", "Kim"}) {
+		t.Fatalf("members = %v, want the recruits kept", got)
+	}
+}
+
+func ExampleOnboardingTemplate_Run() {
+	squad := composite.NewSquad("delta")
+	_ = NewOnboarding(ActiveDutyOnboarding{}).Run(squad, []string{"Ortiz"})
+	_ = NewOnboarding(ReserveOnboarding{}).Run(squad, []string{"Kim"})
+	// Output:
+	// Private Ortiz, report to your squad leader at 0600.
+	// Welcome to the reserve, Kim. Drill is one weekend a month.
+}
@@ -0,0 +1,230 @@
+package templateMethod
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// Sale is one line of the data a report is generated from.
+type Sale struct {
+	Product   string
+	Quantity  int
+	UnitCents int
+}
+
+// ProductTotal is a report row: every sale of one product added up.
+type ProductTotal struct {
+	Product      string
+	Quantity     int
+	RevenueCents int
+}
+
+// IReport is the set of steps a report format supplies.
+type IReport interface {
+	fetchData() ([]Sale, error)
+	transform(sales []Sale) ([]ProductTotal, error)
+	render(totals []ProductTotal) ([]byte, error)
+	publish(report []byte) error
+}
+
+// StepTiming is how long one step of a report run took.
+type StepTiming struct {
+	Step string
+	Took time.Duration
+}
+
+// ReportGenerator is the template for reports. Besides the order of the
+// steps it owns what every format would otherwise repeat: timing each step
+// and naming the step in its error.
+type ReportGenerator struct {
+	report  IReport
+	now     func() time.Time
+	timings []StepTiming
+}
+
+// NewReportGenerator times steps with now, time.Now if nil.
+func NewReportGenerator(report IReport, now func() time.Time) *ReportGenerator {
+	if now == nil {
+		now = time.Now
+	}
+	return &ReportGenerator{
+		report:  report,
+		now:     now,
+		timings: make([]StepTiming, 0),
+	}
+}
+
+// Generate fetches, transforms, renders and publishes one report, stopping
+// at the first step that fails with a StepError.
+func (g *ReportGenerator) Generate() error {
+	g.timings = g.timings[:0]
+	var sales []Sale
+	var totals []ProductTotal
+	var report []byte
+	return g.run(
+		step{"fetchData", func() (err error) {
+			sales, err = g.report.fetchData()
+			return err
+		}},
+		step{"transform", func() (err error) {
+			totals, err = g.report.transform(sales)
+			return err
+		}},
+		step{"render", func() (err error) {
+			report, err = g.report.render(totals)
+			return err
+		}},
+		step{"publish", func() error {
+			return g.report.publish(report)
+		}},
+	)
+}
+
+type step struct {
+	name string
+	fn   func() error
+}
+
+func (g *ReportGenerator) run(steps ...step) error {
+	for _, s := range steps {
+		start := g.now()
+		err := s.fn()
+		g.timings = append(g.timings, StepTiming{Step: s.name, Took: g.now().Sub(start)})
+		if err != nil {
+			return &StepError{Step: s.name, Err: err}
+		}
+	}
+	return nil
+}
+
+// Timings returns how long each step of the last run took, the failed one
+// included.
+func (g *ReportGenerator) Timings() []StepTiming {
+	timings := make([]StepTiming, len(g.timings))
+	copy(timings, g.timings)
+	return timings
+}
+
+// salesReport holds the steps every format shares: reading the sales,
+// adding them up per product and writing the result out.
+type salesReport struct {
+	source func() ([]Sale, error)
+	out    io.Writer
+}
+
+func (r *salesReport) fetchData() ([]Sale, error) {
+	return r.source()
+}
+
+// transform totals the sales per product, best sellers by revenue first.
+func (r *salesReport) transform(sales []Sale) ([]ProductTotal, error) {
+	index := make(map[string]int)
+	totals := make([]ProductTotal, 0)
+	for _, s := range sales {
+		if s.Quantity < 0 || s.UnitCents < 0 {
+			return nil, fmt.Errorf("negative sale of %q", s.Product)
+		}
+		i, ok := index[s.Product]
+		if !ok {
+			i = len(totals)
+			index[s.Product] = i
+			totals = append(totals, ProductTotal{Product: s.Product})
+		}
+		totals[i].Quantity += s.Quantity
+		totals[i].RevenueCents += s.Quantity * s.UnitCents
+	}
+	sort.SliceStable(totals, func(i, j int) bool {
+		if totals[i].RevenueCents != totals[j].RevenueCents {
+			return totals[i].RevenueCents > totals[j].RevenueCents
+		}
+		return totals[i].Product < totals[j].Product
+	})
+	return totals, nil
+}
+
+func (r *salesReport) publish(report []byte) error {
+	_, err := r.out.Write(report)
+	return err
+}
+
+func grandTotal(totals []ProductTotal) (quantity, revenueCents int) {
+	for _, t := range totals {
+		quantity += t.Quantity
+		revenueCents += t.RevenueCents
+	}
+	return quantity, revenueCents
+}
+
+func money(cents int) string {
+	return fmt.Sprintf("%d.%02d", cents/100, cents%100)
+}
+
+// CSVReport renders the totals as CSV with a closing total row.
+type CSVReport struct {
+	salesReport
+}
+
+func NewCSVReport(source func() ([]Sale, error), out io.Writer) *CSVReport {
+	return &CSVReport{salesReport{source: source, out: out}}
+}
+
+func (r *CSVReport) render(totals []ProductTotal) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	rows := [][]string{{"product", "quantity", "revenue"}}
+	for _, t := range totals {
+		rows = append(rows, []string{t.Product, strconv.Itoa(t.Quantity), money(t.RevenueCents)})
+	}
+	quantity, revenue := grandTotal(totals)
+	rows = append(rows, []string{"total", strconv.Itoa(quantity), money(revenue)})
+	if err := w.WriteAll(rows); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// JSONReport renders the totals as an indented JSON document.
+type JSONReport struct {
+	salesReport
+}
+
+func NewJSONReport(source func() ([]Sale, error), out io.Writer) *JSONReport {
+	return &JSONReport{salesReport{source: source, out: out}}
+}
+
+type jsonLine struct {
+	Product  string `json:"product"`
+	Quantity int    `json:"quantity"`
+	Revenue  string `json:"revenue"`
+}
+
+type jsonReport struct {
+	Products []jsonLine `json:"products"`
+	Quantity int        `json:"quantity"`
+	Revenue  string     `json:"revenue"`
+}
+
+func (r *JSONReport) render(totals []ProductTotal) ([]byte, error) {
+	doc := jsonReport{Products: make([]jsonLine, 0, len(totals))}
+	for _, t := range totals {
+		doc.Products = append(doc.Products, jsonLine{
+			Product:  t.Product,
+			Quantity: t.Quantity,
+			Revenue:  money(t.RevenueCents),
+		})
+	}
+	quantity, revenue := grandTotal(totals)
+	doc.Quantity = quantity
+	doc.Revenue = money(revenue)
+	report, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(report, '\n'), nil
+}
@@ -0,0 +1,137 @@
+package templateMethod
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files")
+
+func sales() ([]Sale, error) {
+	return []Sale{
+		{Product: "widget", Quantity: 3, UnitCents: 250},
+		{Product: "gadget", Quantity: 1, UnitCents: 1999},
+		{Product: "widget", Quantity: 2, UnitCents: 250},
+		{Product: "gizmo, large", Quantity: 4, UnitCents: 125},
+		{Product: "doohickey", Quantity: 5, UnitCents: 100},
+	}, nil
+}
+
+func TestReportGolden(t *testing.T) {
+	tests := []struct {
+		golden string
+		report func(out *bytes.Buffer) IReport
+	}{
+		{"sales.csv", func(out *bytes.Buffer) IReport { return NewCSVReport(sales, out) }},
+		{"sales.json", func(out *bytes.Buffer) IReport { return NewJSONReport(sales, out) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.golden, func(t *testing.T) {
+			var out bytes.Buffer
+			if err := NewReportGenerator(tt.report(&out), nil).Generate(); err != nil {
+				t.Fatal(err)
+			}
+			path := filepath.Join("testdata", tt.golden)
+			if *update {
+				if err := os.WriteFile(path, out.Bytes(), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			want, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(out.Bytes(), want) {
+				t.Fatalf("report =\n%s\nwant\n%s", out.Bytes(), want)
+			}
+		})
+	}
+}
+
+// tickingClock moves forward by one more millisecond on every reading, so
+// consecutive steps get different, known durations.
+type tickingClock struct {
+	now  time.Time
+	tick time.Duration
+}
+
+func (c *tickingClock) Now() time.Time {
+	c.tick += time.Millisecond
+	c.now = c.now.Add(c.tick)
+	return c.now
+}
+
+func TestReportTimings(t *testing.T) {
+	clock := &tickingClock{}
+	var out bytes.Buffer
+	g := NewReportGenerator(NewCSVReport(sales, &out), clock.Now)
+	if err := g.Generate(); err != nil {
+		t.Fatal(err)
+	}
+
+	// each step is timed between two readings: 2ms, 4ms, 6ms, 8ms
+	want := []StepTiming{
+		{"fetchData", 2 * time.Millisecond},
+		{"transform", 4 * time.Millisecond},
+		{"render", 6 * time.Millisecond},
+		{"publish", 8 * time.Millisecond},
+	}
+	if got := g.Timings(); !slices.Equal(got, want) {
+		t.Fatalf("Timings() = %v, want %v", got, want)
+	}
+}
+
+var errSource = errors.New("database down")
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errSource
+}
+
+func TestReportStepErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		report IReport
+		step   string
+	}{
+		{
+			name: "fetch",
+			report: NewJSONReport(func() ([]Sale, error) {
+				return nil, errSource
+			}, &bytes.Buffer{}),
+			step: "fetchData",
+		},
+		{
+			name: "transform",
+			report: NewCSVReport(func() ([]Sale, error) {
+				return []Sale{{Product: "widget", Quantity: -1}}, nil
+			}, &bytes.Buffer{}),
+			step: "transform",
+		},
+		{
+			name:   "publish",
+			report: NewCSVReport(sales, failingWriter{}),
+			step:   "publish",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewReportGenerator(tt.report, nil)
+			err := g.Generate()
+			if failedStep(err) != tt.step {
+				t.Fatalf("Generate() = %v, want a failure in %s", err, tt.step)
+			}
+			timings := g.Timings()
+			if last := timings[len(timings)-1]; last.Step != tt.step {
+				t.Fatalf("last timed step = %s, want the failed %s", last.Step, tt.step)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package templateMethod
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrMissingStep = errors.New("templateMethod: mandatory step missing")
+
+// Steps is the template method without embedding: the template is a struct
+// of funcs and a variant is whatever set of funcs it is built with. Fetch,
+// Render and Publish are mandatory; Transform defaults to passing the data
+// through unchanged.
+type Steps[D any] struct {
+	Fetch     func() (D, error)
+	Transform func(data D) (D, error)
+	Render    func(data D) ([]byte, error)
+	Publish   func(rendered []byte) error
+}
+
+// Template runs a set of Steps in their fixed order.
+type Template[D any] struct {
+	steps Steps[D]
+}
+
+// NewTemplate fills in the optional steps left nil.
+func NewTemplate[D any](steps Steps[D]) *Template[D] {
+	if steps.Transform == nil {
+		steps.Transform = func(data D) (D, error) {
+			return data, nil
+		}
+	}
+	return &Template[D]{steps: steps}
+}
+
+// Run checks that every mandatory step is there before running any, then
+// runs them in order and stops at the first that fails with a StepError.
+func (t *Template[D]) Run() error {
+	missing := make([]error, 0)
+	for _, step := range []struct {
+		name    string
+		present bool
+	}{
+		{"Fetch", t.steps.Fetch != nil},
+		{"Render", t.steps.Render != nil},
+		{"Publish", t.steps.Publish != nil},
+	} {
+		if !step.present {
+			missing = append(missing, fmt.Errorf("%w: %s", ErrMissingStep, step.name))
+		}
+	}
+	if len(missing) > 0 {
+		return errors.Join(missing...)
+	}
+
+	data, err := t.steps.Fetch()
+	if err != nil {
+		return &StepError{Step: "Fetch", Err: err}
+	}
+	if data, err = t.steps.Transform(data); err != nil {
+		return &StepError{Step: "Transform", Err: err}
+	}
+	rendered, err := t.steps.Render(data)
+	if err != nil {
+		return &StepError{Step: "Render", Err: err}
+	}
+	if err := t.steps.Publish(rendered); err != nil {
+		return &StepError{Step: "Publish", Err: err}
+	}
+	return nil
+}
+
+// OtpSteps is the OTP workflow of Otp.GenAndSendOTP as Steps: the code is
+// the data, caching it is the transform and the message is what gets
+// rendered and published. The beforeSend and afterSend hooks are not part
+// of it.
+func OtpSteps(c IOtp, length int) Steps[string] {
+	return Steps[string]{
+		Fetch: func() (string, error) {
+			return c.genRandomOTP(length)
+		},
+		Transform: func(otp string) (string, error) {
+			return otp, c.saveOTPCache(otp)
+		},
+		Render: func(otp string) ([]byte, error) {
+			message, err := c.getMessage(otp)
+			return []byte(message), err
+		},
+		Publish: func(message []byte) error {
+			return c.sendNotification(string(message))
+		},
+	}
+}
@@ -0,0 +1,94 @@
+package templateMethod
+
+import (
+	"bytes"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestTemplateMissingSteps(t *testing.T) {
+	ran := false
+	err := NewTemplate(Steps[int]{
+		Fetch: func() (int, error) {
+			ran = true
+			return 1, nil
+		},
+	}).Run()
+	if !errors.Is(err, ErrMissingStep) {
+		t.Fatalf("Run() = %v, want %v", err, ErrMissingStep)
+	}
+	for _, step := range []string{"Render", "Publish"} {
+		if !strings.Contains(err.Error(), step) {
+			t.Fatalf("Run() = %v, want %s reported missing", err, step)
+		}
+	}
+	if ran {
+		t.Fatal("Run() started with mandatory steps missing")
+	}
+}
+
+func TestTemplateDefaultTransform(t *testing.T) {
+	var published []byte
+	err := NewTemplate(Steps[int]{
+		Fetch: func() (int, error) {
+			return 42, nil
+		},
+		Render: func(v int) ([]byte, error) {
+			return []byte{byte(v)}, nil
+		},
+		Publish: func(b []byte) error {
+			published = b
+			return nil
+		},
+	}).Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(published, []byte{42}) {
+		t.Fatalf("published %v, want the fetched value untouched", published)
+	}
+}
+
+// otpSteps renames the Otp steps to the Steps ones they run as.
+var otpSteps = map[string]string{
+	"genRandomOTP":     "Fetch",
+	"saveOTPCache":     "Transform",
+	"getMessage":       "Render",
+	"sendNotification": "Publish",
+}
+
+func TestOtpStepsMatchOtp(t *testing.T) {
+	for _, failing := range append([]string{""}, allSteps...) {
+		t.Run("fail "+failing, func(t *testing.T) {
+			viaOtp, viaSteps := &recorder{fail: failing}, &recorder{fail: failing}
+			otpErr := NewOtp(viaOtp).GenAndSendOTP(4)
+			stepsErr := NewTemplate(OtpSteps(viaSteps, 4)).Run()
+
+			if !slices.Equal(viaOtp.steps, viaSteps.steps) {
+				t.Fatalf("steps = %v, Otp ran %v", viaSteps.steps, viaOtp.steps)
+			}
+			if failedStep(stepsErr) != otpSteps[failedStep(otpErr)] {
+				t.Fatalf("Run() = %v, GenAndSendOTP() = %v", stepsErr, otpErr)
+			}
+		})
+	}
+}
+
+func TestOtpStepsSendTheSameMessage(t *testing.T) {
+	digits := bytes.Repeat([]byte{3}, 6)
+	var viaOtp, viaSteps bytes.Buffer
+	a, b := NewSms("+15550100", NewCache(), &viaOtp), NewSms("+15550100", NewCache(), &viaSteps)
+	a.random, b.random = bytes.NewReader(digits), bytes.NewReader(digits)
+
+	if err := NewOtp(a).GenAndSendOTP(6); err != nil {
+		t.Fatal(err)
+	}
+	if err := NewTemplate(OtpSteps(b, 6)).Run(); err != nil {
+		t.Fatal(err)
+	}
+	if viaOtp.String() != viaSteps.String() || !strings.HasSuffix(viaSteps.String(), "333333\n") {
+		t.Fatalf("sent %q and %q, want the same message", viaOtp.String(), viaSteps.String())
+	}
+}
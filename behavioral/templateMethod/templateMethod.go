@@ -0,0 +1,238 @@
+package templateMethod
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math/big"
+	"net/mail"
+	"strings"
+	"sync"
+)
+
+//Template Method is a behavioral design pattern that defines the skeleton of an algorithm in the superclass but lets subclasses override specific steps of the algorithm without changing its structure.
+//The Template Method pattern suggests that you break down an algorithm into a series of steps, turn these steps into methods, and put a series of calls to these methods inside a single template method.
+//The steps may either be abstract, or have some default implementation. To use the algorithm, the client is supposed to provide its own subclass, implement all abstract steps, and override some of the optional ones if needed (but not the template method itself).
+//Go has no inheritance, so the template is a type holding the steps behind an interface and the concrete types supply them.
+
+//How to Implement
+//
+//Analyze the target algorithm to see whether you can break it into steps. Consider which steps are common to all subclasses and which ones will always be unique.
+//
+//Create the abstract base class and declare the template method and a set of abstract methods representing the algorithm’s steps.
+//Outline the algorithm’s structure in the template method by executing corresponding steps. Consider making the template method final to prevent subclasses from overriding it.
+//
+//It’s okay if all the steps end up being abstract. However, some steps might benefit from having a default implementation. Subclasses don’t have to implement those methods.
+//
+//Think of adding hooks between the crucial steps of the algorithm.
+//
+//For each variation of the algorithm, create a new concrete subclass. It must implement all of the abstract steps, but may also override some of the optional ones.
+
+var (
+	ErrInvalidLength = errors.New("templateMethod: OTP length must be positive")
+	ErrNoCache       = errors.New("templateMethod: no OTP cache")
+)
+
+// IOtp is the set of steps a one-time password channel supplies. The steps
+// are unexported: only the template calls them, and only in its own order.
+type IOtp interface {
+	genRandomOTP(length int) (string, error)
+	saveOTPCache(otp string) error
+	getMessage(otp string) (string, error)
+	sendNotification(message string) error
+}
+
+// beforeSender is an optional hook a channel may implement. It runs once the
+// message is built; an error stops the run before anything is sent.
+type beforeSender interface {
+	beforeSend(message string) error
+}
+
+// afterSender is an optional hook a channel may implement. It runs once the
+// message was sent; an error can't undo that, so it is reported to the
+// template's hook error handler and the run still succeeds.
+type afterSender interface {
+	afterSend(message string) error
+}
+
+// StepError names the step that stopped GenAndSendOTP. Steps after it did
+// not run.
+type StepError struct {
+	Step string
+	Err  error
+}
+
+func (e *StepError) Error() string {
+	return fmt.Sprintf("templateMethod: step %s: %v", e.Step, e.Err)
+}
+
+func (e *StepError) Unwrap() error {
+	return e.Err
+}
+
+// Otp is the template: it owns the order of the steps, the channel only
+// fills them in.
+type Otp struct {
+	iOtp        IOtp
+	onHookError func(error)
+}
+
+func NewOtp(iOtp IOtp) *Otp {
+	return &Otp{
+		iOtp: iOtp,
+		onHookError: func(err error) {
+			log.Println(err)
+		},
+	}
+}
+
+// OnHookError receives afterSend failures. Without it they are logged.
+func (o *Otp) OnHookError(fn func(error)) {
+	if fn != nil {
+		o.onHookError = fn
+	}
+}
+
+// GenAndSendOTP generates a code, caches it, builds the message and sends
+// it, stopping at the first step that fails. Channels implementing the
+// beforeSend or afterSend hooks have them called around the send; the
+// others skip straight past.
+func (o *Otp) GenAndSendOTP(length int) error {
+	otp, err := o.iOtp.genRandomOTP(length)
+	if err != nil {
+		return &StepError{Step: "genRandomOTP", Err: err}
+	}
+	if err := o.iOtp.saveOTPCache(otp); err != nil {
+		return &StepError{Step: "saveOTPCache", Err: err}
+	}
+	message, err := o.iOtp.getMessage(otp)
+	if err != nil {
+		return &StepError{Step: "getMessage", Err: err}
+	}
+	if hook, ok := o.iOtp.(beforeSender); ok {
+		if err := hook.beforeSend(message); err != nil {
+			return &StepError{Step: "beforeSend", Err: err}
+		}
+	}
+	if err := o.iOtp.sendNotification(message); err != nil {
+		return &StepError{Step: "sendNotification", Err: err}
+	}
+	if hook, ok := o.iOtp.(afterSender); ok {
+		if err := hook.afterSend(message); err != nil {
+			o.onHookError(&StepError{Step: "afterSend", Err: err})
+		}
+	}
+	return nil
+}
+
+// Cache keeps the last code sent to each recipient until it is checked.
+// It is safe for concurrent use.
+type Cache struct {
+	mu    sync.Mutex
+	codes map[string]string
+}
+
+func NewCache() *Cache {
+	return &Cache{codes: make(map[string]string)}
+}
+
+func (c *Cache) save(key, otp string) error {
+	if c == nil {
+		return ErrNoCache
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.codes[key] = otp
+	return nil
+}
+
+// Check reports whether otp is the code sent to key. A code can only be
+// used once.
+func (c *Cache) Check(key, otp string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if want, ok := c.codes[key]; !ok || want != otp {
+		return false
+	}
+	delete(c.codes, key)
+	return true
+}
+
+// channel holds what Sms and Email share; each still supplies its own
+// message and notification steps.
+type channel struct {
+	to     string
+	cache  *Cache
+	out    io.Writer
+	random io.Reader
+}
+
+func (c *channel) genRandomOTP(length int) (string, error) {
+	if length < 1 {
+		return "", fmt.Errorf("%w: %d", ErrInvalidLength, length)
+	}
+	var otp strings.Builder
+	for i := 0; i < length; i++ {
+		digit, err := rand.Int(c.random, big.NewInt(10))
+		if err != nil {
+			return "", err
+		}
+		otp.WriteString(digit.String())
+	}
+	return otp.String(), nil
+}
+
+func (c *channel) saveOTPCache(otp string) error {
+	return c.cache.save(c.to, otp)
+}
+
+// Sms sends codes as text messages, written to out.
+type Sms struct {
+	channel
+}
+
+func NewSms(phone string, cache *Cache, out io.Writer) *Sms {
+	return &Sms{channel{to: phone, cache: cache, out: out, random: rand.Reader}}
+}
+
+func (s *Sms) getMessage(otp string) (string, error) {
+	return "SMS OTP for login is " + otp, nil
+}
+
+func (s *Sms) sendNotification(message string) error {
+	_, err := fmt.Fprintf(s.out, "SMS to %s: %s\n", s.to, message)
+	return err
+}
+
+// Email sends codes by mail, written to out. The address is only checked
+// when sending, like a real mail server would.
+type Email struct {
+	channel
+}
+
+func NewEmail(address string, cache *Cache, out io.Writer) *Email {
+	return &Email{channel{to: address, cache: cache, out: out, random: rand.Reader}}
+}
+
+func (e *Email) getMessage(otp string) (string, error) {
+	return "EMAIL OTP for login is " + otp, nil
+}
+
+func (e *Email) sendNotification(message string) error {
+	if _, err := mail.ParseAddress(e.to); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(e.out, "Email to %s: %s\n", e.to, message)
+	return err
+}
+
+//Pros and Cons
+//
+//You can let clients override only certain parts of a large algorithm, making them less affected by changes that happen to other parts of the algorithm.
+//You can pull the duplicate code into a superclass.
+//
+//Some clients may be limited by the provided skeleton of an algorithm.
+//You might violate the Liskov Substitution Principle by suppressing a default step implementation via a subclass.
+//Template methods tend to be harder to maintain the more steps they have.
@@ -0,0 +1,142 @@
+package templateMethod
+
+import (
+	"bytes"
+	"errors"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+// recorder is a channel whose steps log their names and can be told to fail.
+type recorder struct {
+	steps []string
+	fail  string
+}
+
+var errStep = errors.New("step failed")
+
+func (r *recorder) step(name string) error {
+	r.steps = append(r.steps, name)
+	if name == r.fail {
+		return errStep
+	}
+	return nil
+}
+
+func (r *recorder) genRandomOTP(int) (string, error) {
+	return "1234", r.step("genRandomOTP")
+}
+
+func (r *recorder) saveOTPCache(string) error {
+	return r.step("saveOTPCache")
+}
+
+func (r *recorder) getMessage(otp string) (string, error) {
+	return otp, r.step("getMessage")
+}
+
+func (r *recorder) sendNotification(string) error {
+	return r.step("sendNotification")
+}
+
+var allSteps = []string{"genRandomOTP", "saveOTPCache", "getMessage", "sendNotification"}
+
+func TestGenAndSendOTPOrder(t *testing.T) {
+	r := &recorder{}
+	if err := NewOtp(r).GenAndSendOTP(4); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(r.steps, allSteps) {
+		t.Fatalf("steps = %v, want %v", r.steps, allSteps)
+	}
+}
+
+func TestFailingStepAborts(t *testing.T) {
+	for i, failing := range allSteps {
+		t.Run(failing, func(t *testing.T) {
+			r := &recorder{fail: failing}
+			err := NewOtp(r).GenAndSendOTP(4)
+
+			var stepErr *StepError
+			if !errors.As(err, &stepErr) || stepErr.Step != failing || !errors.Is(err, errStep) {
+				t.Fatalf("GenAndSendOTP() = %v, want a StepError for %s", err, failing)
+			}
+			if want := allSteps[:i+1]; !slices.Equal(r.steps, want) {
+				t.Fatalf("steps = %v, want %v", r.steps, want)
+			}
+		})
+	}
+}
+
+var sentCode = regexp.MustCompile(`OTP for login is (\d+)\n$`)
+
+func TestChannels(t *testing.T) {
+	tests := []struct {
+		name   string
+		otp    func(cache *Cache, out *bytes.Buffer) IOtp
+		to     string
+		prefix string
+	}{
+		{
+			name:   "sms",
+			otp:    func(cache *Cache, out *bytes.Buffer) IOtp { return NewSms("+15550100", cache, out) },
+			to:     "+15550100",
+			prefix: "SMS to +15550100: SMS OTP",
+		},
+		{
+			name:   "email",
+			otp:    func(cache *Cache, out *bytes.Buffer) IOtp { return NewEmail("jane@example.com", cache, out) },
+			to:     "jane@example.com",
+			prefix: "Email to jane@example.com: EMAIL OTP",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cache := NewCache()
+			var out bytes.Buffer
+			if err := NewOtp(tt.otp(cache, &out)).GenAndSendOTP(6); err != nil {
+				t.Fatal(err)
+			}
+			sent := out.String()
+			match := sentCode.FindStringSubmatch(sent)
+			if !strings.HasPrefix(sent, tt.prefix) || match == nil || len(match[1]) != 6 {
+				t.Fatalf("sent %q, want a six digit code after %q", sent, tt.prefix)
+			}
+			if !cache.Check(tt.to, match[1]) {
+				t.Fatal("sent code was not cached")
+			}
+			if cache.Check(tt.to, match[1]) {
+				t.Fatal("code could be used twice")
+			}
+		})
+	}
+}
+
+func TestChannelFailures(t *testing.T) {
+	cache := NewCache()
+	var out bytes.Buffer
+
+	broken := NewSms("+15550100", cache, &out)
+	broken.random = iotest.ErrReader(errStep)
+	if err := NewOtp(broken).GenAndSendOTP(6); !errors.Is(err, errStep) {
+		t.Fatalf("GenAndSendOTP() with a broken random source = %v", err)
+	}
+	if err := NewOtp(NewSms("+15550100", cache, &out)).GenAndSendOTP(0); !errors.Is(err, ErrInvalidLength) {
+		t.Fatalf("GenAndSendOTP(0) = %v, want %v", err, ErrInvalidLength)
+	}
+	if err := NewOtp(NewSms("+15550100", nil, &out)).GenAndSendOTP(6); !errors.Is(err, ErrNoCache) {
+		t.Fatalf("GenAndSendOTP() without a cache = %v, want %v", err, ErrNoCache)
+	}
+
+	err := NewOtp(NewEmail("not an address", cache, &out)).GenAndSendOTP(6)
+	var stepErr *StepError
+	if !errors.As(err, &stepErr) || stepErr.Step != "sendNotification" {
+		t.Fatalf("GenAndSendOTP() to a bad address = %v, want a sendNotification failure", err)
+	}
+	if out.Len() != 0 {
+		t.Fatalf("failed runs sent %q", out.String())
+	}
+}
@@ -0,0 +1,52 @@
+package visitor
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/Antonious-Stewart/15-Most-Common-Design-Patterns/structural/composite"
+)
+
+// Demo runs the shape visitors over a few shapes and the soldier visitors
+// over a small division.
+func Demo(w io.Writer) error {
+	shapes := []Shape{
+		&Square{Side: 2},
+		&Rectangle{Origin: Point{X: 1, Y: 1}, Width: 4, Height: 3},
+	}
+	area := NewAreaCalculator()
+	if err := VisitAll(shapes, area); err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "area %.2f, perimeter %.2f\n", area.Result(), PerimeterOf(shapes))
+
+	squad := composite.NewSquad("A")
+	if err := squad.Add(composite.NewEnlisted("Ortiz"), composite.NewEnlisted("Kim")); err != nil {
+		return err
+	}
+	platoon := composite.NewPlatoon("1st Platoon")
+	if err := platoon.Add(squad); err != nil {
+		return err
+	}
+	brigade := composite.NewBrigade("Alpha")
+	if err := brigade.Add(platoon); err != nil {
+		return err
+	}
+	division := composite.NewDivision("1st")
+	if err := division.Add(brigade); err != nil {
+		return err
+	}
+
+	roster := &RosterVisitor{}
+	if err := division.Accept(roster); err != nil {
+		return err
+	}
+	for _, entry := range roster.Roster() {
+		fmt.Fprintln(w, entry)
+	}
+	counts := ReadinessOf(division)
+	for _, rank := range []Rank{DivisionRank, BrigadeRank, PlatoonRank, SquadRank, EnlistedRank} {
+		fmt.Fprintf(w, "%s: %d\n", rank, counts[rank])
+	}
+	return nil
+}
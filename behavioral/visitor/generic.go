@@ -0,0 +1,75 @@
+package visitor
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Antonious-Stewart/15-Most-Common-Design-Patterns/structural/composite"
+)
+
+var ErrNotWalkable = errors.New("visitor: cannot walk this value")
+
+// VisitorOf is a visitor that folds everything it visits into one result,
+// so visitors that only accumulate don't each need their own field, getter
+// and visiting methods. Reduce gets the concrete element, such as a
+// *Square or a *composite.Squad, and returns the new result. It visits both
+// shapes and the soldier tree.
+type VisitorOf[R any] struct {
+	result R
+	reduce func(acc R, element any) R
+}
+
+func NewVisitorOf[R any](initial R, reduce func(acc R, element any) R) *VisitorOf[R] {
+	return &VisitorOf[R]{result: initial, reduce: reduce}
+}
+
+func (v *VisitorOf[R]) visit(element any) error {
+	v.result = v.reduce(v.result, element)
+	return nil
+}
+
+// Result is everything visited so far, folded.
+func (v *VisitorOf[R]) Result() R {
+	return v.result
+}
+
+func (v *VisitorOf[R]) VisitForSquare(s *Square) error       { return v.visit(s) }
+func (v *VisitorOf[R]) VisitForCircle(c *Circle) error       { return v.visit(c) }
+func (v *VisitorOf[R]) VisitForRectangle(r *Rectangle) error { return v.visit(r) }
+
+func (v *VisitorOf[R]) VisitDivision(d *composite.Division) error { return v.visit(d) }
+func (v *VisitorOf[R]) VisitBrigade(b *composite.Brigade) error   { return v.visit(b) }
+func (v *VisitorOf[R]) VisitPlatoon(p *composite.Platoon) error   { return v.visit(p) }
+func (v *VisitorOf[R]) VisitSquad(s *composite.Squad) error       { return v.visit(s) }
+func (v *VisitorOf[R]) VisitEnlisted(e *composite.Enlisted) error { return v.visit(e) }
+
+// soldierAcceptor is a unit of the composite soldier tree.
+type soldierAcceptor interface {
+	Accept(v composite.Visitor) error
+}
+
+// Walk has v visit root, which may be a Shape, a []Shape or a unit of the
+// soldier tree, and returns v's result.
+func Walk[R any](root any, v *VisitorOf[R]) (R, error) {
+	err := accept(root, v)
+	return v.result, err
+}
+
+// accept has v visit root, provided v is the kind of visitor root takes.
+func accept(root, v any) error {
+	switch r := root.(type) {
+	case Shape:
+		if v, ok := v.(Visitor); ok {
+			return r.Accept(v)
+		}
+	case []Shape:
+		if v, ok := v.(Visitor); ok {
+			return VisitAll(r, v)
+		}
+	case soldierAcceptor:
+		if v, ok := v.(composite.Visitor); ok {
+			return r.Accept(v)
+		}
+	}
+	return fmt.Errorf("%w: %T with %T", ErrNotWalkable, root, v)
+}
@@ -0,0 +1,83 @@
+package visitor
+
+import (
+	"errors"
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/Antonious-Stewart/15-Most-Common-Design-Patterns/structural/composite"
+)
+
+func TestVisitorOfNumeric(t *testing.T) {
+	count, err := Walk(shapes(), NewVisitorOf(0, func(n int, _ any) int {
+		return n + 1
+	}))
+	if err != nil || count != 3 {
+		t.Fatalf("Walk() = %d, %v, want 3 shapes", count, err)
+	}
+
+	units, err := Walk(division(), NewVisitorOf(0, func(n int, _ any) int {
+		return n + 1
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	readiness := NewReadinessVisitor()
+	if err := division().Accept(readiness); err != nil {
+		t.Fatal(err)
+	}
+	total := 0
+	for _, n := range readiness.Counts() {
+		total += n
+	}
+	if units != total {
+		t.Fatalf("Walk() counted %d units, ReadinessVisitor %d", units, total)
+	}
+}
+
+// shapeStats is a struct accumulator doing the work of both shape visitors.
+type shapeStats struct {
+	Area    float64
+	Middles []Point
+}
+
+func TestVisitorOfStruct(t *testing.T) {
+	stats, err := Walk(shapes(), NewVisitorOf(shapeStats{}, func(acc shapeStats, shape any) shapeStats {
+		acc.Area = addArea(acc.Area, shape)
+		middle := &MiddleCoordinates{}
+		if err := shape.(Shape).Accept(middle); err == nil {
+			acc.Middles = append(acc.Middles, middle.Middles()...)
+		}
+		return acc
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	area, middles := NewAreaCalculator(), &MiddleCoordinates{}
+	for _, s := range shapes() {
+		_ = s.Accept(area)
+		_ = s.Accept(middles)
+	}
+	if math.Abs(stats.Area-area.Area()) > 1e-9 || math.Abs(area.Area()-(28+math.Pi)) > 1e-9 {
+		t.Fatalf("Area = %v, AreaCalculator says %v", stats.Area, area.Area())
+	}
+	if !slices.Equal(stats.Middles, middles.Middles()) {
+		t.Fatalf("Middles = %v, MiddleCoordinates says %v", stats.Middles, middles.Middles())
+	}
+}
+
+func TestWalkSingleElements(t *testing.T) {
+	area, err := Walk(&Circle{Radius: 2}, NewAreaCalculator().VisitorOf)
+	if err != nil || math.Abs(area-4*math.Pi) > 1e-9 {
+		t.Fatalf("Walk(circle) = %v, %v", area, err)
+	}
+	counts, err := Walk(composite.NewEnlisted("Kim"), NewReadinessVisitor().VisitorOf)
+	if err != nil || counts[EnlistedRank] != 1 || len(counts) != 1 {
+		t.Fatalf("Walk(enlisted) = %v, %v", counts, err)
+	}
+	if _, err := Walk("not a shape", NewAreaCalculator().VisitorOf); !errors.Is(err, ErrNotWalkable) {
+		t.Fatalf("Walk(string) = %v, want %v", err, ErrNotWalkable)
+	}
+}
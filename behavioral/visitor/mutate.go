@@ -0,0 +1,176 @@
+package visitor
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/Antonious-Stewart/15-Most-Common-Design-Patterns/structural/composite"
+)
+
+var (
+	ErrConflict      = errors.New("visitor: planned changes conflict")
+	ErrInvalidFactor = errors.New("visitor: scale factor must be positive")
+	ErrOutOfRange    = errors.New("visitor: scaled dimension out of range")
+)
+
+// Change is one modification a Mutator has planned.
+type Change struct {
+	Description string
+	apply       func()
+}
+
+// Mutator is a visitor that modifies what it visits. Visiting only plans
+// the changes; Transact applies them once the whole walk has succeeded.
+type Mutator interface {
+	// Plan returns the changes planned so far, or an error if they can't
+	// all be applied together.
+	Plan() ([]Change, error)
+}
+
+// Transact walks root with m, a shape or soldier visitor like for Walk,
+// and applies the planned changes only if every visit succeeded and the
+// plan holds together. Otherwise nothing is changed.
+func Transact(root any, m Mutator) ([]Change, error) {
+	if err := accept(root, m); err != nil {
+		return nil, err
+	}
+	changes, err := m.Plan()
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range changes {
+		c.apply()
+	}
+	return changes, nil
+}
+
+// renamable is every unit of the soldier tree.
+type renamable interface {
+	named
+	Rename(name string)
+}
+
+type rename struct {
+	unit     renamable
+	parent   composite.Soldier
+	from, to string
+}
+
+// RenameVisitor plans to rename every unit with fn. Units fn returns the
+// same name for are left alone; an error from fn fails the visit.
+type RenameVisitor struct {
+	fn      func(name string) (string, error)
+	parents []composite.Soldier
+	planned []rename
+}
+
+func NewRenameVisitor(fn func(name string) (string, error)) *RenameVisitor {
+	return &RenameVisitor{fn: fn}
+}
+
+func (r *RenameVisitor) plan(unit renamable) error {
+	to, err := r.fn(unit.Name())
+	if err != nil {
+		return fmt.Errorf("renaming %q: %w", unit.Name(), err)
+	}
+	var parent composite.Soldier
+	if len(r.parents) > 0 {
+		parent = r.parents[len(r.parents)-1]
+	}
+	r.planned = append(r.planned, rename{unit: unit, parent: parent, from: unit.Name(), to: to})
+	return nil
+}
+
+func (r *RenameVisitor) open(unit interface {
+	renamable
+	composite.Soldier
+}) error {
+	if err := r.plan(unit); err != nil {
+		return err
+	}
+	r.parents = append(r.parents, unit)
+	return nil
+}
+
+func (r *RenameVisitor) VisitDivision(d *composite.Division) error { return r.open(d) }
+func (r *RenameVisitor) VisitBrigade(b *composite.Brigade) error   { return r.open(b) }
+func (r *RenameVisitor) VisitPlatoon(p *composite.Platoon) error   { return r.open(p) }
+func (r *RenameVisitor) VisitSquad(s *composite.Squad) error       { return r.open(s) }
+func (r *RenameVisitor) VisitEnlisted(e *composite.Enlisted) error { return r.plan(e) }
+
+func (r *RenameVisitor) Leave(composite.Soldier) {
+	r.parents = r.parents[:len(r.parents)-1]
+}
+
+// Plan fails with ErrConflict if a unit would be renamed to the name
+// another unit of the same container ends up with. Units that keep their
+// names are never a conflict, even if they already shared one.
+func (r *RenameVisitor) Plan() ([]Change, error) {
+	type slot struct {
+		parent composite.Soldier
+		name   string
+	}
+	final := make(map[slot][]rename)
+	for _, p := range r.planned {
+		key := slot{p.parent, p.to}
+		final[key] = append(final[key], p)
+	}
+	changes := make([]Change, 0)
+	for _, p := range r.planned {
+		if p.from == p.to {
+			continue
+		}
+		if sharing := final[slot{p.parent, p.to}]; len(sharing) > 1 {
+			return nil, fmt.Errorf("%w: %d units would be named %q", ErrConflict, len(sharing), p.to)
+		}
+		changes = append(changes, Change{
+			Description: fmt.Sprintf("rename %q to %q", p.from, p.to),
+			apply: func() {
+				p.unit.Rename(p.to)
+			},
+		})
+	}
+	return changes, nil
+}
+
+// ScaleVisitor plans to multiply every dimension of the shapes it visits
+// by factor. Positions are left where they are.
+type ScaleVisitor struct {
+	factor  float64
+	changes []Change
+}
+
+func NewScaleVisitor(factor float64) *ScaleVisitor {
+	return &ScaleVisitor{factor: factor}
+}
+
+func (s *ScaleVisitor) scale(shape Shape, dimensions ...*float64) error {
+	if s.factor <= 0 || math.IsNaN(s.factor) {
+		return fmt.Errorf("%w: %v", ErrInvalidFactor, s.factor)
+	}
+	scaled := make([]float64, len(dimensions))
+	for i, d := range dimensions {
+		scaled[i] = *d * s.factor
+		if math.IsInf(scaled[i], 0) {
+			return fmt.Errorf("%w: %s dimension %v", ErrOutOfRange, shape.GetType(), *d)
+		}
+	}
+	s.changes = append(s.changes, Change{
+		Description: fmt.Sprintf("scale %s by %v", shape.GetType(), s.factor),
+		apply: func() {
+			for i, d := range dimensions {
+				*d = scaled[i]
+			}
+		},
+	})
+	return nil
+}
+
+func (s *ScaleVisitor) VisitForSquare(sq *Square) error      { return s.scale(sq, &sq.Side) }
+func (s *ScaleVisitor) VisitForCircle(c *Circle) error       { return s.scale(c, &c.Radius) }
+func (s *ScaleVisitor) VisitForRectangle(r *Rectangle) error { return s.scale(r, &r.Width, &r.Height) }
+
+func (s *ScaleVisitor) Plan() ([]Change, error) {
+	return s.changes, nil
+}
@@ -0,0 +1,157 @@
+package visitor
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/Antonious-Stewart/15-Most-Common-Design-Patterns/structural/composite"
+)
+
+// snapshot renders a tree so two states of it can be compared.
+func snapshot(t *testing.T, root *composite.Division) string {
+	t.Helper()
+	x := &ExportVisitor{}
+	if err := root.Accept(x); err != nil {
+		t.Fatal(err)
+	}
+	data, err := x.JSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+var errBadName = errors.New("name not allowed")
+
+func TestRenameCommits(t *testing.T) {
+	d := division()
+	changes, err := Transact(d, NewRenameVisitor(func(name string) (string, error) {
+		return strings.ToUpper(name), nil
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	roster := &RosterVisitor{}
+	_ = d.Accept(roster)
+	if got := roster.Roster()[0]; got != "1ST/ALPHA/1ST PLATOON/A/ORTIZ" {
+		t.Fatalf("first path = %q after renaming", got)
+	}
+	// the squads keep their single-letter names
+	if len(changes) != 3+5+3 {
+		t.Fatalf("%d changes, want the division, brigades, platoons and enlisted", len(changes))
+	}
+}
+
+func TestRenameFailureChangesNothing(t *testing.T) {
+	d := division()
+	before := snapshot(t, d)
+	seen := 0
+	_, err := Transact(d, NewRenameVisitor(func(name string) (string, error) {
+		seen++
+		if name == "Novak" {
+			return "", errBadName
+		}
+		return "renamed " + name, nil
+	}))
+	if !errors.Is(err, errBadName) {
+		t.Fatalf("Transact() = %v, want %v", err, errBadName)
+	}
+	if seen < 5 {
+		t.Fatalf("failed after %d visits, want the failure mid-traversal", seen)
+	}
+	if after := snapshot(t, d); after != before {
+		t.Fatalf("tree changed after a failed rename:\n%s\nwas\n%s", after, before)
+	}
+}
+
+func TestRenameConflicts(t *testing.T) {
+	tests := []struct {
+		name     string
+		rename   map[string]string
+		conflict bool
+	}{
+		{"onto an untouched sibling", map[string]string{"Kim": "Ortiz"}, true},
+		{"two onto one name", map[string]string{"A": "X", "B": "X"}, true},
+		{"same name in different containers", map[string]string{"A": "X", "C": "X"}, false},
+		{"existing duplicates left alone", map[string]string{"Novak": "Nowak"}, false},
+		{"swap", map[string]string{"A": "B", "B": "A"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := division()
+			before := snapshot(t, d)
+			_, err := Transact(d, NewRenameVisitor(func(name string) (string, error) {
+				if to, ok := tt.rename[name]; ok {
+					return to, nil
+				}
+				return name, nil
+			}))
+			if tt.conflict {
+				if !errors.Is(err, ErrConflict) {
+					t.Fatalf("Transact() = %v, want %v", err, ErrConflict)
+				}
+				if snapshot(t, d) != before {
+					t.Fatal("tree changed despite the conflict")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestScaleCommits(t *testing.T) {
+	s := shapes()
+	if _, err := Transact(s, NewScaleVisitor(2)); err != nil {
+		t.Fatal(err)
+	}
+	want := []Shape{
+		&Square{Origin: Point{0, 0}, Side: 4},
+		&Circle{Center: Point{5, 5}, Radius: 2},
+		&Rectangle{Origin: Point{1, 2}, Width: 8, Height: 12},
+	}
+	if !reflect.DeepEqual(s, want) {
+		t.Fatalf("scaled shapes = %v, want %v", s, want)
+	}
+}
+
+func TestScaleFailureChangesNothing(t *testing.T) {
+	s := append(shapes(), &Square{Side: math.MaxFloat64}, &Circle{Radius: 1})
+	before := slices.Clone(s)
+	for i, shape := range before {
+		before[i] = clone(shape)
+	}
+	if _, err := Transact(s, NewScaleVisitor(10)); !errors.Is(err, ErrOutOfRange) {
+		t.Fatalf("Transact() = %v, want %v", err, ErrOutOfRange)
+	}
+	if !reflect.DeepEqual(s, before) {
+		t.Fatalf("shapes changed after a failed scale: %v", s)
+	}
+	if _, err := Transact(s, NewScaleVisitor(0)); !errors.Is(err, ErrInvalidFactor) {
+		t.Fatalf("Transact() = %v, want %v", err, ErrInvalidFactor)
+	}
+	if _, err := Transact(s, NewRenameVisitor(nil)); !errors.Is(err, ErrNotWalkable) {
+		t.Fatalf("renaming shapes = %v, want %v", err, ErrNotWalkable)
+	}
+}
+
+func clone(s Shape) Shape {
+	switch s := s.(type) {
+	case *Square:
+		c := *s
+		return &c
+	case *Circle:
+		c := *s
+		return &c
+	case *Rectangle:
+		c := *s
+		return &c
+	}
+	return s
+}
@@ -0,0 +1,116 @@
+package visitor
+
+import (
+	"sync"
+
+	"github.com/Antonious-Stewart/15-Most-Common-Design-Patterns/structural/composite"
+)
+
+// tasksPerWorker is how many subtrees ParallelWalk aims to have per worker,
+// so one deep subtree doesn't leave the other workers idle.
+const tasksPerWorker = 4
+
+// ParallelWalk is Walk spread over up to workers goroutines for large
+// structures: a soldier tree is split into independent subtrees, a list of
+// shapes into chunks. Every goroutine folds its share with a visitor of its
+// own from factory and the results are combined with merge, in no
+// particular order, so it only suits visitors whose result doesn't depend
+// on visiting order, like counts and sums. Soldier subtrees are reached
+// through Children, so visitors relying on Leave won't work here.
+func ParallelWalk[R any](root any, workers int, factory func() *VisitorOf[R], merge func(a, b R) R) (R, error) {
+	if workers < 1 {
+		workers = 1
+	}
+	top := factory()
+	var tasks []any
+	switch r := root.(type) {
+	case []Shape:
+		tasks = chunks(r, workers)
+	case composite.Soldier:
+		tasks = split(r, top, workers*tasksPerWorker)
+	default:
+		tasks = []any{root}
+	}
+
+	queue := make(chan any)
+	results := make([]R, workers)
+	errs := make([]error, workers)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			v := factory()
+			for task := range queue {
+				if errs[w] == nil {
+					errs[w] = accept(task, v)
+				}
+			}
+			results[w] = v.Result()
+		}()
+	}
+	for _, task := range tasks {
+		queue <- task
+	}
+	close(queue)
+	wg.Wait()
+
+	result := top.Result()
+	for w := 0; w < workers; w++ {
+		if errs[w] != nil {
+			var zero R
+			return zero, errs[w]
+		}
+		result = merge(result, results[w])
+	}
+	return result, nil
+}
+
+func chunks(shapes []Shape, n int) []any {
+	size := (len(shapes) + n - 1) / n
+	tasks := make([]any, 0, n)
+	for start := 0; start < len(shapes); start += size {
+		tasks = append(tasks, shapes[start:min(start+size, len(shapes))])
+	}
+	return tasks
+}
+
+// split opens containers from the root down, folding each opened one into
+// top, until there are at least want subtrees or nothing left to open.
+// Foreign soldiers are dropped, as Walk passes over them too.
+func split[R any](root composite.Soldier, top *VisitorOf[R], want int) []any {
+	frontier := []composite.Soldier{root}
+	for len(frontier) < want {
+		next := make([]composite.Soldier, 0, len(frontier)*2)
+		opened := false
+		for _, s := range frontier {
+			c, ok := s.(interface{ Children() []composite.Soldier })
+			if !ok {
+				next = append(next, s)
+				continue
+			}
+			_ = top.visit(s)
+			next = append(next, c.Children()...)
+			opened = true
+		}
+		frontier = next
+		if !opened {
+			break
+		}
+	}
+	tasks := make([]any, 0, len(frontier))
+	for _, s := range frontier {
+		if _, ok := s.(soldierAcceptor); ok {
+			tasks = append(tasks, s)
+		}
+	}
+	return tasks
+}
+
+// MergeCounts adds b's counts to a, for merging ReadinessVisitor results.
+func MergeCounts(a, b map[Rank]int) map[Rank]int {
+	for rank, n := range b {
+		a[rank] += n
+	}
+	return a
+}
@@ -0,0 +1,110 @@
+package visitor
+
+import (
+	"fmt"
+	"maps"
+	"math"
+	"testing"
+
+	"github.com/Antonious-Stewart/15-Most-Common-Design-Patterns/structural/composite"
+)
+
+// largeDivision builds brigades x platoons x squads x enlisted, plus
+// containers, e.g. 10, 10, 10, 100 for just over 100k nodes.
+func largeDivision(brigades, platoons, squads, enlisted int) *composite.Division {
+	d := composite.NewDivision("big")
+	for b := 0; b < brigades; b++ {
+		brigade := composite.NewBrigade(fmt.Sprint("b", b))
+		d.Add(brigade)
+		for p := 0; p < platoons; p++ {
+			platoon := composite.NewPlatoon(fmt.Sprint("p", p))
+			brigade.Add(platoon)
+			for s := 0; s < squads; s++ {
+				squad := composite.NewSquad(fmt.Sprint("s", s))
+				platoon.Add(squad)
+				for e := 0; e < enlisted; e++ {
+					squad.Add(composite.NewEnlisted(fmt.Sprint("e", e)))
+				}
+			}
+		}
+	}
+	return d
+}
+
+func readinessFactory() *VisitorOf[map[Rank]int] {
+	return NewReadinessVisitor().VisitorOf
+}
+
+func TestParallelReadinessMatchesSequential(t *testing.T) {
+	for _, d := range []*composite.Division{division(), largeDivision(3, 4, 5, 6)} {
+		sequential := NewReadinessVisitor()
+		if err := d.Accept(sequential); err != nil {
+			t.Fatal(err)
+		}
+		for _, workers := range []int{0, 1, 3, 8} {
+			got, err := ParallelWalk(d, workers, readinessFactory, MergeCounts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := sequential.Counts(); !maps.Equal(got, want) {
+				t.Fatalf("%d workers: counts = %v, want %v", workers, got, want)
+			}
+		}
+	}
+}
+
+func TestParallelAreaMatchesSequential(t *testing.T) {
+	shapes := make([]Shape, 0, 1000)
+	for i := 0; i < 1000; i++ {
+		switch i % 3 {
+		case 0:
+			shapes = append(shapes, &Square{Side: float64(i)})
+		case 1:
+			shapes = append(shapes, &Circle{Radius: float64(i)})
+		case 2:
+			shapes = append(shapes, &Rectangle{Width: float64(i), Height: 2})
+		}
+	}
+	sequential := NewAreaCalculator()
+	if err := VisitAll(shapes, sequential); err != nil {
+		t.Fatal(err)
+	}
+	add := func(a, b float64) float64 { return a + b }
+	for _, workers := range []int{1, 4, 7, 2000} {
+		got, err := ParallelWalk(shapes, workers, func() *VisitorOf[float64] {
+			return NewAreaCalculator().VisitorOf
+		}, add)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := sequential.Area(); math.Abs(got-want) > want*1e-12 {
+			t.Fatalf("%d workers: area = %v, want %v", workers, got, want)
+		}
+	}
+}
+
+func TestParallelWalkSingleElements(t *testing.T) {
+	got, err := ParallelWalk(composite.NewEnlisted("Kim"), 4, readinessFactory, MergeCounts)
+	if err != nil || got[EnlistedRank] != 1 {
+		t.Fatalf("ParallelWalk(enlisted) = %v, %v", got, err)
+	}
+	if _, err := ParallelWalk(42, 2, readinessFactory, MergeCounts); err == nil {
+		t.Fatal("ParallelWalk(42) succeeded")
+	}
+}
+
+func BenchmarkReadiness(b *testing.B) {
+	d := largeDivision(10, 10, 10, 100)
+	b.Run("sequential", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = d.Accept(NewReadinessVisitor())
+		}
+	})
+	for _, workers := range []int{2, 4, 8} {
+		b.Run(fmt.Sprint("parallel-", workers), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_, _ = ParallelWalk(d, workers, readinessFactory, MergeCounts)
+			}
+		})
+	}
+}
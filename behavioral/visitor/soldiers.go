@@ -0,0 +1,185 @@
+package visitor
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/Antonious-Stewart/15-Most-Common-Design-Patterns/structural/composite"
+)
+
+// named is what every unit in the composite package offers besides Soldier.
+type named interface {
+	Name() string
+}
+
+// path keeps the names of the containers a visitor is currently inside.
+type path []string
+
+func (p *path) push(name string) {
+	*p = append(*p, name)
+}
+
+func (p *path) Leave(composite.Soldier) {
+	*p = (*p)[:len(*p)-1]
+}
+
+func (p path) join(name string) string {
+	return strings.Join(append(p[:len(p):len(p)], name), "/")
+}
+
+// RosterVisitor lists every enlisted soldier by the unit names leading to
+// them, root first, e.g. "1st/Alpha/1st Platoon/A/Ortiz".
+type RosterVisitor struct {
+	path
+	roster []string
+}
+
+func (r *RosterVisitor) VisitDivision(d *composite.Division) error {
+	r.push(d.Name())
+	return nil
+}
+
+func (r *RosterVisitor) VisitBrigade(b *composite.Brigade) error {
+	r.push(b.Name())
+	return nil
+}
+
+func (r *RosterVisitor) VisitPlatoon(p *composite.Platoon) error {
+	r.push(p.Name())
+	return nil
+}
+
+func (r *RosterVisitor) VisitSquad(s *composite.Squad) error {
+	r.push(s.Name())
+	return nil
+}
+
+func (r *RosterVisitor) VisitEnlisted(e *composite.Enlisted) error {
+	r.roster = append(r.roster, r.join(e.Name()))
+	return nil
+}
+
+// Roster returns a copy of the paths collected so far, in visiting order.
+func (r *RosterVisitor) Roster() []string {
+	roster := make([]string, len(r.roster))
+	copy(roster, r.roster)
+	return roster
+}
+
+// Rank names a level of the soldier tree.
+type Rank string
+
+const (
+	DivisionRank Rank = "Division"
+	BrigadeRank  Rank = "Brigade"
+	PlatoonRank  Rank = "Platoon"
+	SquadRank    Rank = "Squad"
+	EnlistedRank Rank = "Enlisted"
+)
+
+// ReadinessVisitor counts the units of every rank it visits.
+type ReadinessVisitor struct {
+	*VisitorOf[map[Rank]int]
+}
+
+func NewReadinessVisitor() *ReadinessVisitor {
+	return &ReadinessVisitor{NewVisitorOf(make(map[Rank]int), countRank)}
+}
+
+func countRank(counts map[Rank]int, unit any) map[Rank]int {
+	if rank := rankOf(unit); rank != "" {
+		counts[rank]++
+	}
+	return counts
+}
+
+func rankOf(unit any) Rank {
+	switch unit.(type) {
+	case *composite.Division:
+		return DivisionRank
+	case *composite.Brigade:
+		return BrigadeRank
+	case *composite.Platoon:
+		return PlatoonRank
+	case *composite.Squad:
+		return SquadRank
+	case *composite.Enlisted:
+		return EnlistedRank
+	}
+	return ""
+}
+
+// Counts returns a copy of the counts so far. Ranks never visited are left out.
+func (r *ReadinessVisitor) Counts() map[Rank]int {
+	counts := make(map[Rank]int, len(r.Result()))
+	for rank, n := range r.Result() {
+		counts[rank] = n
+	}
+	return counts
+}
+
+// ExportNode is one unit of an exported tree.
+type ExportNode struct {
+	Rank     Rank          `json:"rank"`
+	Name     string        `json:"name"`
+	Children []*ExportNode `json:"children,omitempty"`
+}
+
+// ExportVisitor rebuilds the visited tree as ExportNodes, ready for JSON.
+type ExportVisitor struct {
+	root  *ExportNode
+	stack []*ExportNode
+}
+
+func (x *ExportVisitor) add(rank Rank, s named) *ExportNode {
+	node := &ExportNode{Rank: rank, Name: s.Name()}
+	if len(x.stack) == 0 {
+		x.root = node
+	} else {
+		parent := x.stack[len(x.stack)-1]
+		parent.Children = append(parent.Children, node)
+	}
+	return node
+}
+
+func (x *ExportVisitor) open(rank Rank, s named) {
+	x.stack = append(x.stack, x.add(rank, s))
+}
+
+func (x *ExportVisitor) VisitDivision(d *composite.Division) error {
+	x.open(DivisionRank, d)
+	return nil
+}
+
+func (x *ExportVisitor) VisitBrigade(b *composite.Brigade) error {
+	x.open(BrigadeRank, b)
+	return nil
+}
+
+func (x *ExportVisitor) VisitPlatoon(p *composite.Platoon) error {
+	x.open(PlatoonRank, p)
+	return nil
+}
+
+func (x *ExportVisitor) VisitSquad(s *composite.Squad) error {
+	x.open(SquadRank, s)
+	return nil
+}
+
+func (x *ExportVisitor) VisitEnlisted(e *composite.Enlisted) error {
+	x.add(EnlistedRank, e)
+	return nil
+}
+
+func (x *ExportVisitor) Leave(composite.Soldier) {
+	x.stack = x.stack[:len(x.stack)-1]
+}
+
+// Tree returns the root of the exported tree, nil before any visit.
+func (x *ExportVisitor) Tree() *ExportNode {
+	return x.root
+}
+
+func (x *ExportVisitor) JSON() ([]byte, error) {
+	return json.Marshal(x.root)
+}
@@ -0,0 +1,177 @@
+package visitor
+
+import (
+	"encoding/json"
+	"errors"
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/Antonious-Stewart/15-Most-Common-Design-Patterns/structural/composite"
+)
+
+func division() *composite.Division {
+	d := composite.NewDivision("1st")
+	alpha, bravo := composite.NewBrigade("Alpha"), composite.NewBrigade("Bravo")
+	first, second := composite.NewPlatoon("1st Platoon"), composite.NewPlatoon("2nd Platoon")
+	a, b, c := composite.NewSquad("A"), composite.NewSquad("B"), composite.NewSquad("C")
+	d.Add(alpha, bravo)
+	alpha.Add(first, second)
+	bravo.Add(composite.NewPlatoon("3rd Platoon"))
+	first.Add(a, b)
+	second.Add(c)
+	a.Add(composite.NewEnlisted("Ortiz"), composite.NewEnlisted("Kim"))
+	b.Add(composite.NewEnlisted("Singh"))
+	c.Add(composite.NewEnlisted("Novak"), composite.NewEnlisted("Ortiz"))
+	return d
+}
+
+// directly walks the tree with Children and a type switch, the way callers
+// had to before there were visitors, calling fn with each unit's path.
+func directly(s composite.Soldier, parents []string, fn func(s composite.Soldier, rank Rank, path []string)) {
+	path := append(parents[:len(parents):len(parents)], s.(named).Name())
+	fn(s, directRank(s), path)
+	for _, child := range childrenOf(s) {
+		directly(child, path, fn)
+	}
+}
+
+func directRank(s composite.Soldier) Rank {
+	switch s.(type) {
+	case *composite.Division:
+		return DivisionRank
+	case *composite.Brigade:
+		return BrigadeRank
+	case *composite.Platoon:
+		return PlatoonRank
+	case *composite.Squad:
+		return SquadRank
+	case *composite.Enlisted:
+		return EnlistedRank
+	}
+	return ""
+}
+
+func childrenOf(s composite.Soldier) []composite.Soldier {
+	if c, ok := s.(interface{ Children() []composite.Soldier }); ok {
+		return c.Children()
+	}
+	return nil
+}
+
+func TestRosterVisitor(t *testing.T) {
+	d := division()
+	var want []string
+	directly(d, nil, func(_ composite.Soldier, rank Rank, path []string) {
+		if rank == EnlistedRank {
+			want = append(want, strings.Join(path, "/"))
+		}
+	})
+
+	roster := &RosterVisitor{}
+	d.Accept(roster)
+	if got := roster.Roster(); !slices.Equal(got, want) {
+		t.Fatalf("Roster() = %v, want %v", got, want)
+	}
+	if want[0] != "1st/Alpha/1st Platoon/A/Ortiz" {
+		t.Fatalf("first path = %q", want[0])
+	}
+}
+
+func TestReadinessVisitor(t *testing.T) {
+	d := division()
+	want := make(map[Rank]int)
+	directly(d, nil, func(_ composite.Soldier, rank Rank, _ []string) {
+		want[rank]++
+	})
+
+	readiness := NewReadinessVisitor()
+	d.Accept(readiness)
+	if got := readiness.Counts(); !maps.Equal(got, want) {
+		t.Fatalf("Counts() = %v, want %v", got, want)
+	}
+	if want[EnlistedRank] != 5 || want[SquadRank] != 3 {
+		t.Fatalf("fixture counts = %v", want)
+	}
+}
+
+func TestExportVisitor(t *testing.T) {
+	d := division()
+	var export func(s composite.Soldier) *ExportNode
+	export = func(s composite.Soldier) *ExportNode {
+		node := &ExportNode{Rank: directRank(s), Name: s.(named).Name()}
+		for _, child := range childrenOf(s) {
+			node.Children = append(node.Children, export(child))
+		}
+		return node
+	}
+	want, err := json.Marshal(export(d))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	x := &ExportVisitor{}
+	d.Accept(x)
+	got, err := x.JSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != string(want) {
+		t.Fatalf("JSON() =\n%s\nwant\n%s", got, want)
+	}
+	if x.Tree().Children[1].Children[0].Name != "3rd Platoon" {
+		t.Fatalf("Tree() = %+v", x.Tree())
+	}
+}
+
+// skipSquad is a roster that leaves out one squad.
+type skipSquad struct {
+	*RosterVisitor
+	name string
+}
+
+func (s skipSquad) VisitSquad(squad *composite.Squad) error {
+	if err := s.RosterVisitor.VisitSquad(squad); err != nil {
+		return err
+	}
+	if squad.Name() == s.name {
+		return composite.SkipSubtree
+	}
+	return nil
+}
+
+// failAt is a roster that fails on reaching one enlisted soldier.
+type failAt struct {
+	*RosterVisitor
+	name string
+}
+
+var errExport = errors.New("disk full")
+
+func (f failAt) VisitEnlisted(e *composite.Enlisted) error {
+	if e.Name() == f.name {
+		return errExport
+	}
+	return f.RosterVisitor.VisitEnlisted(e)
+}
+
+func TestSoldierVisitorsPruneAndFail(t *testing.T) {
+	pruned := skipSquad{&RosterVisitor{}, "A"}
+	if err := division().Accept(pruned); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"1st/Alpha/1st Platoon/B/Singh", "1st/Alpha/2nd Platoon/C/Novak", "1st/Alpha/2nd Platoon/C/Ortiz"}
+	if got := pruned.Roster(); !slices.Equal(got, want) {
+		t.Fatalf("Roster() = %v, want %v", got, want)
+	}
+
+	failing := failAt{&RosterVisitor{}, "Novak"}
+	if err := division().Accept(failing); !errors.Is(err, errExport) {
+		t.Fatalf("Accept() = %v, want %v", err, errExport)
+	}
+	want = []string{"1st/Alpha/1st Platoon/A/Ortiz", "1st/Alpha/1st Platoon/A/Kim", "1st/Alpha/1st Platoon/B/Singh"}
+	if got := failing.Roster(); !slices.Equal(got, want) {
+		t.Fatalf("Roster() before the failure = %v, want %v", got, want)
+	}
+}
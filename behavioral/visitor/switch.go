@@ -0,0 +1,115 @@
+package visitor
+
+import (
+	"math"
+
+	"github.com/Antonious-Stewart/15-Most-Common-Design-Patterns/structural/composite"
+)
+
+// The functions below are the visitors of this package written the usual
+// Go way: a type switch over the elements instead of Accept calling back.
+// Adding an operation is one function, adding an element type means
+// finding every switch; the visitors have it the other way round.
+
+func AreaOf(shapes []Shape) float64 {
+	total := 0.0
+	for _, shape := range shapes {
+		switch s := shape.(type) {
+		case *Square:
+			total += s.Side * s.Side
+		case *Circle:
+			total += math.Pi * s.Radius * s.Radius
+		case *Rectangle:
+			total += s.Width * s.Height
+		}
+	}
+	return total
+}
+
+func MiddlesOf(shapes []Shape) []Point {
+	middles := make([]Point, 0, len(shapes))
+	for _, shape := range shapes {
+		switch s := shape.(type) {
+		case *Square:
+			middles = append(middles, Point{X: s.Origin.X + s.Side/2, Y: s.Origin.Y + s.Side/2})
+		case *Circle:
+			middles = append(middles, s.Center)
+		case *Rectangle:
+			middles = append(middles, Point{X: s.Origin.X + s.Width/2, Y: s.Origin.Y + s.Height/2})
+		}
+	}
+	return middles
+}
+
+func PerimeterOf(shapes []Shape) float64 {
+	total := 0.0
+	for _, shape := range shapes {
+		switch s := shape.(type) {
+		case *Square:
+			total += 4 * s.Side
+		case *Circle:
+			total += 2 * math.Pi * s.Radius
+		case *Rectangle:
+			total += 2 * (s.Width + s.Height)
+		case *Triangle:
+			total += distance(s.A, s.B) + distance(s.B, s.C) + distance(s.C, s.A)
+		}
+	}
+	return total
+}
+
+// children is what the composite containers have and Enlisted doesn't.
+type children interface {
+	Children() []composite.Soldier
+}
+
+func RosterOf(root composite.Soldier) []string {
+	roster := make([]string, 0)
+	var walk func(s composite.Soldier, p path)
+	walk = func(s composite.Soldier, p path) {
+		switch s := s.(type) {
+		case *composite.Enlisted:
+			roster = append(roster, p.join(s.Name()))
+		case children:
+			p = append(p, s.(named).Name())
+			for _, child := range s.Children() {
+				walk(child, p)
+			}
+		}
+	}
+	walk(root, nil)
+	return roster
+}
+
+func ReadinessOf(root composite.Soldier) map[Rank]int {
+	counts := make(map[Rank]int)
+	var walk func(s composite.Soldier)
+	walk = func(s composite.Soldier) {
+		if rank := rankOf(s); rank != "" {
+			counts[rank]++
+		}
+		if c, ok := s.(children); ok {
+			for _, child := range c.Children() {
+				walk(child)
+			}
+		}
+	}
+	walk(root)
+	return counts
+}
+
+func ExportOf(root composite.Soldier) *ExportNode {
+	rank := rankOf(root)
+	if rank == "" {
+		return nil
+	}
+	node := &ExportNode{Rank: rank, Name: root.(named).Name()}
+	if c, ok := root.(children); ok {
+		for _, child := range c.Children() {
+			if n := ExportOf(child); n != nil {
+				node.Children = append(node.Children, n)
+			}
+		}
+	}
+	return node
+}
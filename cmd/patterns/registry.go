@@ -0,0 +1,94 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/Antonious-Stewart/15-Most-Common-Design-Patterns/behavioral/interpreter"
+	"github.com/Antonious-Stewart/15-Most-Common-Design-Patterns/behavioral/memento"
+	"github.com/Antonious-Stewart/15-Most-Common-Design-Patterns/behavioral/observer"
+	"github.com/Antonious-Stewart/15-Most-Common-Design-Patterns/behavioral/state"
+	"github.com/Antonious-Stewart/15-Most-Common-Design-Patterns/behavioral/strategy"
+	"github.com/Antonious-Stewart/15-Most-Common-Design-Patterns/behavioral/templateMethod"
+	"github.com/Antonious-Stewart/15-Most-Common-Design-Patterns/behavioral/visitor"
+	"github.com/Antonious-Stewart/15-Most-Common-Design-Patterns/creational/factoryMethod"
+	"github.com/Antonious-Stewart/15-Most-Common-Design-Patterns/structural/composite"
+)
+
+var (
+	errDuplicateDemo = errors.New("patterns: demo already registered")
+	errUnknownDemo   = errors.New("patterns: no such pattern")
+)
+
+// Demo is what every pattern package exports to show itself off. It must
+// write the same output on every run.
+type Demo func(w io.Writer) error
+
+type registry struct {
+	demos map[string]Demo
+}
+
+func newRegistry() *registry {
+	return &registry{
+		demos: make(map[string]Demo),
+	}
+}
+
+// builtin holds the demo of every pattern package in this module.
+func builtin() *registry {
+	r := newRegistry()
+	for name, demo := range map[string]Demo{
+		"composite":      composite.Demo,
+		"factoryMethod":  factoryMethod.Demo,
+		"interpreter":    interpreter.Demo,
+		"memento":        memento.Demo,
+		"observer":       observer.Demo,
+		"state":          state.Demo,
+		"strategy":       strategy.Demo,
+		"templateMethod": templateMethod.Demo,
+		"visitor":        visitor.Demo,
+	} {
+		r.demos[name] = demo
+	}
+	return r
+}
+
+func (r *registry) register(name string, demo Demo) error {
+	if _, ok := r.demos[name]; ok {
+		return fmt.Errorf("%w: %q", errDuplicateDemo, name)
+	}
+	r.demos[name] = demo
+	return nil
+}
+
+// names lists the registered patterns in sorted order.
+func (r *registry) names() []string {
+	names := make([]string, 0, len(r.demos))
+	for name := range r.demos {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// run checks every name before running anything, then runs the demos in
+// the order given, each under a "== name ==" header. A failing demo doesn't
+// stop the ones after it; the failures are joined, each prefixed with the
+// pattern's name.
+func (r *registry) run(w io.Writer, names ...string) error {
+	for _, name := range names {
+		if _, ok := r.demos[name]; !ok {
+			return fmt.Errorf("%w: %q", errUnknownDemo, name)
+		}
+	}
+	errs := make([]error, 0)
+	for _, name := range names {
+		fmt.Fprintf(w, "== %s ==\n", name)
+		if err := r.demos[name](w); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
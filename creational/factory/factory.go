@@ -0,0 +1,93 @@
+// Package factory is the bookkeeping behind a factory: constructors kept by
+// name, looked up when something is to be made. The creational packages
+// build their registries on it.
+package factory
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+)
+
+var (
+	ErrEmptyName      = errors.New("factory: name is empty")
+	ErrNilConstructor = errors.New("factory: nil constructor")
+	ErrDuplicate      = errors.New("factory: name already registered")
+	ErrUnknown        = errors.New("factory: nothing registered under name")
+)
+
+// Factory makes a T with whichever constructor was registered under a name.
+// The zero value is empty and ready to use, and a Factory is safe to use
+// from several goroutines.
+type Factory[T any] struct {
+	mu           sync.RWMutex
+	constructors map[string]func() (T, error)
+}
+
+// Register makes fn the constructor for name. It fails with ErrEmptyName,
+// ErrNilConstructor, or ErrDuplicate if name already has one.
+func (f *Factory[T]) Register(name string, fn func() (T, error)) error {
+	if name == "" {
+		return ErrEmptyName
+	}
+	if fn == nil {
+		return fmt.Errorf("%w for %q", ErrNilConstructor, name)
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.constructors[name]; ok {
+		return fmt.Errorf("%w: %q", ErrDuplicate, name)
+	}
+	if f.constructors == nil {
+		f.constructors = make(map[string]func() (T, error))
+	}
+	f.constructors[name] = fn
+	return nil
+}
+
+// Unregister removes the constructor for name, reporting whether there was
+// one.
+func (f *Factory[T]) Unregister(name string) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	_, ok := f.constructors[name]
+	delete(f.constructors, name)
+	return ok
+}
+
+// Create runs the constructor for name, failing with ErrUnknown if there is
+// none. The constructor runs without the factory locked, so it may use the
+// factory itself.
+func (f *Factory[T]) Create(name string) (T, error) {
+	f.mu.RLock()
+	fn, ok := f.constructors[name]
+	f.mu.RUnlock()
+	if !ok {
+		var zero T
+		return zero, fmt.Errorf("%w %q", ErrUnknown, name)
+	}
+	return fn()
+}
+
+// MustCreate is Create that panics on failure, for tests and values that
+// can't fail to be made.
+func (f *Factory[T]) MustCreate(name string) T {
+	v, err := f.Create(name)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Names lists the registered names in order.
+func (f *Factory[T]) Names() []string {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	names := make([]string, 0, len(f.constructors))
+	for name := range f.constructors {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
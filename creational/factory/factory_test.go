@@ -0,0 +1,135 @@
+package factory
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// shape is an interface unrelated to anything else in the module.
+type shape interface {
+	Area() float64
+}
+
+type square struct{ side float64 }
+
+func (s square) Area() float64 { return s.side * s.side }
+
+type circle struct{ r float64 }
+
+func (c circle) Area() float64 { return 3 * c.r * c.r }
+
+func shapes(t *testing.T) *Factory[shape] {
+	t.Helper()
+	var f Factory[shape]
+	for name, fn := range map[string]func() (shape, error){
+		"square": func() (shape, error) { return square{2}, nil },
+		"circle": func() (shape, error) { return circle{1}, nil },
+	} {
+		if err := f.Register(name, fn); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return &f
+}
+
+func TestCreate(t *testing.T) {
+	f := shapes(t)
+	for name, want := range map[string]float64{"square": 4, "circle": 3} {
+		s, err := f.Create(name)
+		if err != nil || s.Area() != want {
+			t.Fatalf("Create(%q) = %v, %v, want area %v", name, s, err, want)
+		}
+	}
+	if got := f.Names(); !slices.Equal(got, []string{"circle", "square"}) {
+		t.Fatalf("Names() = %q", got)
+	}
+	if got := f.MustCreate("square"); got != (square{2}) {
+		t.Fatalf("MustCreate(square) = %v", got)
+	}
+}
+
+func TestCreateUnknown(t *testing.T) {
+	f := shapes(t)
+	s, err := f.Create("hexagon")
+	if !errors.Is(err, ErrUnknown) || s != nil {
+		t.Fatalf("Create(hexagon) = %v, %v, want %v", s, err, ErrUnknown)
+	}
+	if want := `factory: nothing registered under name "hexagon"`; err.Error() != want {
+		t.Fatalf("error = %q, want %q", err, want)
+	}
+	var empty Factory[string]
+	if _, err := empty.Create("x"); !errors.Is(err, ErrUnknown) || len(empty.Names()) != 0 {
+		t.Fatalf("zero Factory Create() = %v", err)
+	}
+	defer func() {
+		if err, _ := recover().(error); !errors.Is(err, ErrUnknown) {
+			t.Fatalf("MustCreate(hexagon) panicked with %v, want %v", err, ErrUnknown)
+		}
+	}()
+	f.MustCreate("hexagon")
+}
+
+func TestCreateConstructorError(t *testing.T) {
+	var f Factory[string]
+	broken := errors.New("out of ink")
+	f.Register("pen", func() (string, error) { return "", broken })
+	if _, err := f.Create("pen"); err != broken {
+		t.Fatalf("Create(pen) = %v, want the constructor's error", err)
+	}
+}
+
+func TestRegisterRejects(t *testing.T) {
+	f := shapes(t)
+	tests := []struct {
+		name string
+		fn   func() (shape, error)
+		want error
+	}{
+		{"square", func() (shape, error) { return square{9}, nil }, ErrDuplicate},
+		{"", func() (shape, error) { return square{9}, nil }, ErrEmptyName},
+		{"triangle", nil, ErrNilConstructor},
+	}
+	for _, tt := range tests {
+		if err := f.Register(tt.name, tt.fn); !errors.Is(err, tt.want) {
+			t.Fatalf("Register(%q) = %v, want %v", tt.name, err, tt.want)
+		}
+	}
+	if got := f.MustCreate("square"); got != (square{2}) {
+		t.Fatalf("a rejected Register replaced square: %v", got)
+	}
+	if !f.Unregister("square") || f.Unregister("square") {
+		t.Fatal("Unregister(square) should succeed once")
+	}
+	if err := f.Register("square", func() (shape, error) { return square{3}, nil }); err != nil {
+		t.Fatalf("Register() after Unregister = %v", err)
+	}
+}
+
+func TestFactoryConcurrent(t *testing.T) {
+	var f Factory[string]
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			name := fmt.Sprint("n", i)
+			for range 50 {
+				f.Register(name, func() (string, error) { return strings.ToUpper(name), nil })
+				if v, err := f.Create(name); err != nil || v != strings.ToUpper(name) {
+					t.Errorf("Create(%q) = %q, %v", name, v, err)
+					return
+				}
+				f.Names()
+				f.Unregister(name)
+			}
+		}()
+	}
+	wg.Wait()
+	if got := f.Names(); len(got) != 0 {
+		t.Fatalf("Names() = %q after every goroutine unregistered", got)
+	}
+}
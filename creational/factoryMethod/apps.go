@@ -0,0 +1,58 @@
+package factoryMethod
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+var (
+	ErrPhoneOff        = errors.New("factoryMethod: phone is off")
+	ErrEmptyApp        = errors.New("factoryMethod: app name is empty")
+	ErrAppInstalled    = errors.New("factoryMethod: app already installed")
+	ErrAppNotInstalled = errors.New("factoryMethod: app not installed")
+)
+
+// InstallApp adds the app called name. The phone must be on or asleep.
+func (p *Phone) InstallApp(name string) error {
+	if err := p.changingApps(name); err != nil {
+		return err
+	}
+	i, found := slices.BinarySearch(p.apps, name)
+	if found {
+		return fmt.Errorf("%w: %q", ErrAppInstalled, name)
+	}
+	p.apps = slices.Insert(p.apps, i, name)
+	return nil
+}
+
+// UninstallApp removes the app called name, pre-installed or not. The
+// phone must be on or asleep.
+func (p *Phone) UninstallApp(name string) error {
+	if err := p.changingApps(name); err != nil {
+		return err
+	}
+	i, found := slices.BinarySearch(p.apps, name)
+	if !found {
+		return fmt.Errorf("%w: %q", ErrAppNotInstalled, name)
+	}
+	p.apps = slices.Delete(p.apps, i, i+1)
+	return nil
+}
+
+func (p *Phone) changingApps(name string) error {
+	if strings.TrimSpace(name) == "" {
+		return ErrEmptyApp
+	}
+	if p.power() == offState {
+		return fmt.Errorf("%w: can't change %q", ErrPhoneOff, name)
+	}
+	return nil
+}
+
+// InstalledApps lists the apps on the phone in order. Changing the list
+// leaves the phone alone.
+func (p *Phone) InstalledApps() []string {
+	return slices.Clone(p.apps)
+}
@@ -0,0 +1,101 @@
+package factoryMethod
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/Antonious-Stewart/15-Most-Common-Design-Patterns/internal/output"
+)
+
+func TestPreinstalledApps(t *testing.T) {
+	tests := []struct {
+		os   string
+		want []string
+	}{
+		{"android", []string{"Chrome", "Gmail", "Play Store"}},
+		{"google", []string{"Chrome", "Gemini", "Gmail", "Pixel Camera", "Play Store"}},
+		{"ios", []string{"App Store", "Messages", "Safari"}},
+		{"samsung", []string{"Galaxy Store", "Play Store", "Samsung Internet"}},
+	}
+	for _, tt := range tests {
+		phone, _ := NewPhone(tt.os)
+		if got := phone.InstalledApps(); !slices.Equal(got, tt.want) {
+			t.Fatalf("%s apps = %q, want %q", tt.os, got, tt.want)
+		}
+	}
+	var zero Phone
+	if got := zero.InstalledApps(); len(got) != 0 {
+		t.Fatalf("zero-value phone apps = %q", got)
+	}
+}
+
+func TestInstallApp(t *testing.T) {
+	phone := NewAndroid(WithStatus(StatusOn), WithSink(output.NewRecorder()))
+	for _, app := range []string{"Maps", "Authenticator", "Zoom"} {
+		if err := phone.InstallApp(app); err != nil {
+			t.Fatalf("InstallApp(%q) = %v", app, err)
+		}
+	}
+	want := []string{"Authenticator", "Chrome", "Gmail", "Maps", "Play Store", "Zoom"}
+	apps := phone.InstalledApps()
+	if !slices.Equal(apps, want) {
+		t.Fatalf("InstalledApps() = %q, want %q", apps, want)
+	}
+	apps[0] = "changed"
+	if got := phone.InstalledApps(); !slices.Equal(got, want) {
+		t.Fatalf("changing the list changed the phone: %q", got)
+	}
+
+	if err := phone.UninstallApp("Chrome"); err != nil {
+		t.Fatalf("UninstallApp(Chrome) = %v", err)
+	}
+	phone.Sleep()
+	if err := phone.UninstallApp("Zoom"); err != nil {
+		t.Fatalf("UninstallApp() on a sleeping phone = %v", err)
+	}
+	if got := phone.InstalledApps(); !slices.Equal(got, []string{"Authenticator", "Gmail", "Maps", "Play Store"}) {
+		t.Fatalf("InstalledApps() = %q after uninstalling", got)
+	}
+}
+
+func TestInstallAppErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []PhoneOption
+		call func(IPhone) error
+		want error
+	}{
+		{"install while off", nil, func(p IPhone) error { return p.InstallApp("Maps") }, ErrPhoneOff},
+		{"uninstall while off", nil, func(p IPhone) error { return p.UninstallApp("Chrome") }, ErrPhoneOff},
+		{"installed twice", []PhoneOption{WithStatus(StatusOn)}, func(p IPhone) error { return p.InstallApp("Chrome") }, ErrAppInstalled},
+		{"not installed", []PhoneOption{WithStatus(StatusOn)}, func(p IPhone) error { return p.UninstallApp("Maps") }, ErrAppNotInstalled},
+		{"no name", []PhoneOption{WithStatus(StatusOn)}, func(p IPhone) error { return p.InstallApp(" ") }, ErrEmptyApp},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			phone := NewAndroid(tt.opts...)
+			before := phone.InstalledApps()
+			if err := tt.call(phone); !errors.Is(err, tt.want) {
+				t.Fatalf("got %v, want %v", err, tt.want)
+			}
+			if got := phone.InstalledApps(); !slices.Equal(got, before) {
+				t.Fatalf("apps changed to %q by a failed call", got)
+			}
+		})
+	}
+}
+
+func TestRestoreFactorySettingsRemovesApps(t *testing.T) {
+	phone := NewGoogle(WithSink(output.NewRecorder()))
+	c := NewPhoneCaretaker(phone)
+	phone.TurnOn()
+	phone.InstallApp("Maps")
+	phone.UninstallApp("Gemini")
+	if err := c.RestoreFactorySettings(); err != nil {
+		t.Fatal(err)
+	}
+	if got := phone.InstalledApps(); !slices.Equal(got, []string{"Chrome", "Gemini", "Gmail", "Pixel Camera", "Play Store"}) {
+		t.Fatalf("InstalledApps() = %q after a factory reset", got)
+	}
+}
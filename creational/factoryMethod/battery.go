@@ -0,0 +1,59 @@
+package factoryMethod
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	fullBattery = 100
+	// lowBattery is the level at or below which BatteryLow is published.
+	lowBattery = 20
+)
+
+var (
+	ErrNegativePercent = errors.New("factoryMethod: battery percent must not be negative")
+	ErrBatteryDead     = errors.New("factoryMethod: battery is dead")
+)
+
+// WithBattery starts the phone at percent instead of full.
+func WithBattery(percent int) PhoneOption {
+	return func(p *Phone) error {
+		if percent < 0 || percent > fullBattery {
+			return fmt.Errorf("%w: %d", ErrBadBattery, percent)
+		}
+		p.battery = percent
+		return nil
+	}
+}
+
+func (p *Phone) BatteryLevel() int {
+	return p.battery
+}
+
+// Charge adds percent to the battery, stopping at full.
+func (p *Phone) Charge(percent int) error {
+	if percent < 0 {
+		return fmt.Errorf("%w: %d", ErrNegativePercent, percent)
+	}
+	p.battery = min(p.battery+percent, fullBattery)
+	return nil
+}
+
+// Drain takes percent off the battery, stopping at empty. Crossing into the
+// low range publishes BatteryLow once; it fires again only after a recharge.
+// A phone left empty once BatteryLow's observers are done turns itself off.
+func (p *Phone) Drain(percent int) error {
+	if percent < 0 {
+		return fmt.Errorf("%w: %d", ErrNegativePercent, percent)
+	}
+	before := p.battery
+	p.battery = max(p.battery-percent, 0)
+	if before > lowBattery && p.battery <= lowBattery {
+		p.publish(BatteryLow)
+	}
+	if p.battery == 0 && p.power() != offState {
+		p.transition(offState, "Battery dead, turning phone off")
+	}
+	return nil
+}
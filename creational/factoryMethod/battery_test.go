@@ -0,0 +1,110 @@
+package factoryMethod
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/Antonious-Stewart/15-Most-Common-Design-Patterns/internal/output"
+)
+
+func TestBatteryChargeAndDrainClamp(t *testing.T) {
+	phone := NewAndroid()
+	if phone.BatteryLevel() != fullBattery {
+		t.Fatalf("new phone at %d%%, want %d%%", phone.BatteryLevel(), fullBattery)
+	}
+	if err := phone.Drain(130); err != nil {
+		t.Fatal(err)
+	}
+	if phone.BatteryLevel() != 0 {
+		t.Fatalf("BatteryLevel() = %d after over-draining, want 0", phone.BatteryLevel())
+	}
+	if err := phone.Charge(40); err != nil {
+		t.Fatal(err)
+	}
+	if err := phone.Charge(80); err != nil {
+		t.Fatal(err)
+	}
+	if phone.BatteryLevel() != fullBattery {
+		t.Fatalf("BatteryLevel() = %d after over-charging, want %d", phone.BatteryLevel(), fullBattery)
+	}
+}
+
+func TestBatteryRejectsNegativePercent(t *testing.T) {
+	phone := NewGoogle()
+	if err := phone.Charge(-1); !errors.Is(err, ErrNegativePercent) {
+		t.Fatalf("Charge(-1) = %v, want ErrNegativePercent", err)
+	}
+	if err := phone.Drain(-1); !errors.Is(err, ErrNegativePercent) {
+		t.Fatalf("Drain(-1) = %v, want ErrNegativePercent", err)
+	}
+	if phone.BatteryLevel() != fullBattery {
+		t.Fatalf("BatteryLevel() = %d after rejected calls, want it unchanged", phone.BatteryLevel())
+	}
+}
+
+func TestTurnOnDeadBattery(t *testing.T) {
+	for _, os := range []string{"android", "ios"} {
+		log := output.NewRecorder()
+		rec := &recordingPublisher{}
+		phone, _ := NewPhone(os, WithBattery(0), WithSink(log), WithPublisher(rec))
+		if err := phone.TurnOn(); !errors.Is(err, ErrBatteryDead) {
+			t.Fatalf("%s TurnOn() at 0%% = %v, want %v", os, err, ErrBatteryDead)
+		}
+		if phone.GetStatus() != StatusOff || len(log.Lines()) != 0 || len(rec.events) != 0 {
+			t.Fatalf("%s phone is %s after printing %q, want it left off quietly", os, phone.GetStatus(), log.Lines())
+		}
+		if err := phone.Charge(1); err != nil {
+			t.Fatal(err)
+		}
+		if err := phone.TurnOn(); err != nil {
+			t.Fatalf("%s TurnOn() after charging = %v", os, err)
+		}
+	}
+}
+
+func TestDrainToEmptyTurnsOff(t *testing.T) {
+	for _, steps := range [][]func(IPhone){{turnOn}, {turnOn, IPhone.Sleep}} {
+		log := output.NewRecorder()
+		rec := &recordingPublisher{}
+		phone := NewAndroid(WithBattery(30), WithSink(log))
+		for _, step := range steps {
+			step(phone)
+		}
+		phone.(*Android).publisher = rec
+		if err := phone.Drain(50); err != nil {
+			t.Fatal(err)
+		}
+		if phone.GetStatus() != StatusOff || phone.BatteryLevel() != 0 {
+			t.Fatalf("phone is %s at %d%% after draining, want off at 0%%", phone.GetStatus(), phone.BatteryLevel())
+		}
+		if got := rec.kinds(); !slices.Equal(got, []EventKind{BatteryLow, StatusChanged}) {
+			t.Fatalf("published %v, want BatteryLow then StatusChanged", got)
+		}
+		if lines := log.Lines(); lines[len(lines)-1] != "Battery dead, turning phone off" {
+			t.Fatalf("printed %q", lines)
+		}
+	}
+
+	// a phone charged back up by a BatteryLow observer stays on
+	phone := NewGoogle(WithBattery(30))
+	phone.TurnOn()
+	phone.(*Google).publisher = chargeOnLow{phone}
+	if err := phone.Drain(100); err != nil {
+		t.Fatal(err)
+	}
+	if phone.GetStatus() != StatusOn || phone.BatteryLevel() != 50 {
+		t.Fatalf("phone is %s at %d%%, want on at 50%%", phone.GetStatus(), phone.BatteryLevel())
+	}
+}
+
+// chargeOnLow charges the phone halfway on BatteryLow.
+type chargeOnLow struct {
+	phone IPhone
+}
+
+func (c chargeOnLow) Publish(e PhoneEvent) {
+	if e.Kind == BatteryLow {
+		c.phone.Charge(50)
+	}
+}
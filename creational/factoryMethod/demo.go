@@ -0,0 +1,46 @@
+package factoryMethod
+
+import (
+	"fmt"
+	"io"
+)
+
+// eventLog writes every phone event as it is published.
+type eventLog struct {
+	w io.Writer
+}
+
+func (l eventLog) Publish(e PhoneEvent) {
+	fmt.Fprintf(l.w, "%s: %s is %s at %d%%\n", e.Kind, e.OS, e.Status, e.Battery)
+}
+
+// Demo makes one phone of each kind and uses it for a while, then
+// reverts the last change and restores the factory settings.
+func Demo(w io.Writer) error {
+	for _, os := range []string{"android", "google"} {
+		phone, err := NewPhone(os, WithPublisher(eventLog{w: w}), WithOutput(w))
+		if err != nil {
+			return err
+		}
+		caretaker := NewPhoneCaretaker(phone)
+		if err := phone.TurnOn(); err != nil {
+			return err
+		}
+		if err := phone.Elapse(3); err != nil {
+			return err
+		}
+		caretaker.Checkpoint()
+		if err := phone.Elapse(6); err != nil {
+			return err
+		}
+		if err := caretaker.RevertLastChange(); err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "%s reverted to %d%%\n", phone.GetOS(), phone.BatteryLevel())
+		if err := caretaker.RestoreFactorySettings(); err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "%s restored to %d%%\n", phone.GetOS(), phone.BatteryLevel())
+	}
+	return nil
+}
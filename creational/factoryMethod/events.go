@@ -0,0 +1,57 @@
+package factoryMethod
+
+type EventKind int
+
+const (
+	StatusChanged EventKind = iota
+	BatteryLow
+)
+
+func (k EventKind) String() string {
+	switch k {
+	case StatusChanged:
+		return "StatusChanged"
+	case BatteryLow:
+		return "BatteryLow"
+	}
+	return "EventKind(unknown)"
+}
+
+// PhoneEvent describes a change on Phone, with the state it was left in.
+type PhoneEvent struct {
+	Kind    EventKind
+	Phone   IPhone
+	OS      string
+	Status  Status
+	Battery int
+}
+
+// EventPublisher gets every PhoneEvent on the goroutine that changed the
+// phone, before the TurnOn, TurnOff or Drain call returns. observer.Topic
+// satisfies it.
+type EventPublisher interface {
+	Publish(e PhoneEvent)
+}
+
+// WithPublisher makes the phone publish StatusChanged and BatteryLow events.
+func WithPublisher(publisher EventPublisher) PhoneOption {
+	return func(p *Phone) error {
+		p.publisher = publisher
+		return nil
+	}
+}
+
+// publish runs after the phone's state has been updated, so an observer that
+// calls back into the phone sees the new state and can change it again.
+func (p *Phone) publish(kind EventKind) {
+	if p.publisher == nil {
+		return
+	}
+	p.publisher.Publish(PhoneEvent{
+		Kind:    kind,
+		Phone:   p.self,
+		OS:      p.os,
+		Status:  p.power().status(),
+		Battery: p.battery,
+	})
+}
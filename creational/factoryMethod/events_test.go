@@ -0,0 +1,81 @@
+package factoryMethod
+
+import "testing"
+
+// recordingPublisher collects the events a phone publishes.
+type recordingPublisher struct {
+	events []PhoneEvent
+}
+
+func (r *recordingPublisher) Publish(e PhoneEvent) {
+	r.events = append(r.events, e)
+}
+
+func (r *recordingPublisher) kinds() []EventKind {
+	kinds := make([]EventKind, len(r.events))
+	for i, e := range r.events {
+		kinds[i] = e.Kind
+	}
+	return kinds
+}
+
+func TestPhonePublishesStatusChanges(t *testing.T) {
+	rec := &recordingPublisher{}
+	phone := NewAndroid(WithPublisher(rec))
+	phone.TurnOn()
+	phone.TurnOn()
+	phone.TurnOff()
+
+	if len(rec.events) != 2 {
+		t.Fatalf("published %v, want one event per actual change", rec.kinds())
+	}
+	for i, status := range []Status{StatusOn, StatusOff} {
+		e := rec.events[i]
+		if e.Kind != StatusChanged || e.Status != status || e.OS != "android" || e.Phone != phone {
+			t.Fatalf("event %d = %+v, want StatusChanged to %s from this phone", i, e, status)
+		}
+	}
+}
+
+func TestPhonePublishesBatteryLowOncePerCrossing(t *testing.T) {
+	rec := &recordingPublisher{}
+	phone := NewGoogle(WithPublisher(rec))
+	for _, percent := range []int{70, 15, 5} {
+		if err := phone.Drain(percent); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(rec.events) != 1 || rec.events[0].Kind != BatteryLow || rec.events[0].Battery != 15 {
+		t.Fatalf("published %+v, want a single BatteryLow at 15%%", rec.events)
+	}
+
+	if err := phone.Charge(50); err != nil {
+		t.Fatal(err)
+	}
+	if err := phone.Drain(50); err != nil {
+		t.Fatal(err)
+	}
+	if len(rec.events) != 2 {
+		t.Fatalf("published %v, want BatteryLow again after a recharge", rec.kinds())
+	}
+}
+
+func TestPhoneWithoutPublisher(t *testing.T) {
+	phone := NewAndroid()
+	phone.TurnOn()
+	if err := phone.Drain(90); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestEventKindString(t *testing.T) {
+	for kind, want := range map[EventKind]string{
+		StatusChanged: "StatusChanged",
+		BatteryLow:    "BatteryLow",
+		EventKind(99): "EventKind(unknown)",
+	} {
+		if kind.String() != want {
+			t.Fatalf("%d.String() = %q, want %q", int(kind), kind.String(), want)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package factoryMethod
+
+import "fmt"
+
+// PhoneFactory is the creator in the Factory Method pattern as far as Go
+// allows: client code holding one makes phones without knowing which
+// product it gets.
+type PhoneFactory interface {
+	CreatePhone() IPhone
+	Name() string
+}
+
+// AndroidFactory, GoogleFactory, AppleFactory and SamsungFactory make their
+// product with Options, panicking as its constructor does if one fails.
+type AndroidFactory struct {
+	Options []PhoneOption
+}
+
+func (f AndroidFactory) CreatePhone() IPhone { return NewAndroid(f.Options...) }
+func (f AndroidFactory) Name() string        { return "android" }
+
+type GoogleFactory struct {
+	Options []PhoneOption
+}
+
+func (f GoogleFactory) CreatePhone() IPhone { return NewGoogle(f.Options...) }
+func (f GoogleFactory) Name() string        { return "google" }
+
+type AppleFactory struct {
+	Options []PhoneOption
+}
+
+func (f AppleFactory) CreatePhone() IPhone { return NewApple(f.Options...) }
+func (f AppleFactory) Name() string        { return "ios" }
+
+type SamsungFactory struct {
+	Options []PhoneOption
+}
+
+func (f SamsungFactory) CreatePhone() IPhone { return NewSamsung(f.Options...) }
+func (f SamsungFactory) Name() string        { return "samsung" }
+
+// GetFactory is the factory for one of the package's own products, picked
+// by os as NewPhoneWithOptions does. Products added with Register are made
+// with NewPhone instead.
+func GetFactory(os string) (PhoneFactory, error) {
+	switch normalize(os) {
+	case "android":
+		return AndroidFactory{}, nil
+	case "google":
+		return GoogleFactory{}, nil
+	case "ios":
+		return AppleFactory{}, nil
+	case "samsung":
+		return SamsungFactory{}, nil
+	}
+	return nil, fmt.Errorf("%w %q", ErrUnknownOS, os)
+}
+
+// ProvisionPhone is client code depending only on the factory: it makes a
+// phone and turns it on, failing if the phone won't.
+func ProvisionPhone(f PhoneFactory) (IPhone, error) {
+	phone := f.CreatePhone()
+	if err := phone.TurnOn(); err != nil {
+		return nil, fmt.Errorf("factoryMethod: provisioning %s phone: %w", f.Name(), err)
+	}
+	return phone, nil
+}
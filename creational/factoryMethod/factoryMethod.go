@@ -1,6 +1,13 @@
 package factoryMethod
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/Antonious-Stewart/15-Most-Common-Design-Patterns/internal/output"
+)
 
 // Factory method is a creational design pattern which solves the problem of creating product objects without specifying their concrete classes.
 
@@ -29,57 +36,264 @@ import "fmt"
 
 type IPhone interface {
 	GetOS() string
-	TurnOn()
-	TurnOff()
+	GetModel() string
+	GetStatus() Status
+	GetSpec() Spec
+	// TurnOn fails with ErrAlreadyOn for a phone that is on and with
+	// ErrBatteryDead for an empty one, and TurnOff with ErrAlreadyOff for
+	// one that is off. Either works on a sleeping phone.
+	TurnOn() error
+	TurnOff() error
+	Sleep()
+	Elapse(hours int) error
+	BatteryLevel() int
+	Charge(percent int) error
+	Drain(percent int) error
+	Save() PhoneMemento
+	Restore(m PhoneMemento) error
+	// InstallApp fails with ErrPhoneOff for a phone that is off and with
+	// ErrAppInstalled for an app it has, and UninstallApp with ErrPhoneOff
+	// or ErrAppNotInstalled.
+	InstallApp(name string) error
+	UninstallApp(name string) error
+	InstalledApps() []string
+	// Connect fails with ErrPhoneOff for a phone that is off. Turning the
+	// phone off disconnects it.
+	Connect(network string) error
+	Disconnect()
+	Network() string
+	// Reset puts the phone back as its product starts out, turning it off
+	// first if it is on.
+	Reset() error
 }
 
 type Phone struct {
-	status string
-	os     string
+	state   powerState
+	os      string
+	model   string
+	battery int
+	apps    []string // sorted
+	network string
+
+	// self is the concrete product embedding this Phone, so events can
+	// hand observers the phone they came from.
+	self      IPhone
+	publisher EventPublisher
+	out       output.Sink
+}
+
+var (
+	ErrBadStatus  = errors.New("factoryMethod: unknown status")
+	ErrBadBattery = errors.New("factoryMethod: battery percent must be between 0 and 100")
+	ErrEmptyModel = errors.New("factoryMethod: model is empty")
+)
+
+// PhoneOption changes a phone as it is made, failing for a value the phone
+// can't take.
+type PhoneOption func(p *Phone) error
+
+// WithSink makes the phone print what it is doing to s instead of stdout.
+func WithSink(s output.Sink) PhoneOption {
+	return func(p *Phone) error {
+		p.out = s
+		return nil
+	}
+}
+
+// WithOutput makes the phone print what it is doing to w instead of
+// stdout.
+func WithOutput(w io.Writer) PhoneOption {
+	return WithSink(output.NewWriter(w))
+}
+
+// WithStatus starts the phone on, off or asleep instead of off. A phone
+// with an empty battery can only start off.
+func WithStatus(status Status) PhoneOption {
+	return func(p *Phone) error {
+		state, ok := powerStates[status]
+		if !ok {
+			return fmt.Errorf("%w: %d", ErrBadStatus, int(status))
+		}
+		p.state = state
+		return nil
+	}
+}
+
+// WithModel names the model instead of the product's default one, such as
+// "Pixel" for Google phones.
+func WithModel(model string) PhoneOption {
+	return func(p *Phone) error {
+		if strings.TrimSpace(model) == "" {
+			return ErrEmptyModel
+		}
+		p.model = model
+		return nil
+	}
+}
+
+// setup applies opts in order, then checks that the phone they leave makes
+// sense, so the order options are given in doesn't matter.
+func (p *Phone) setup(self IPhone, opts []PhoneOption) error {
+	p.self = self
+	for _, opt := range opts {
+		if err := opt(p); err != nil {
+			return err
+		}
+	}
+	if p.battery == 0 && p.power() != offState {
+		return fmt.Errorf("%w: a phone at 0%% can't start %s", ErrBatteryDead, p.power().status())
+	}
+	return nil
+}
+
+// phone lets the registry reach the Phone inside products that embed one.
+func (p *Phone) phone() *Phone {
+	return p
 }
 
 func (p *Phone) GetOS() string {
 	return p.os
 }
 
-func (p *Phone) TurnOn() {
-	if p.status == "on" {
-		return
+func (p *Phone) GetModel() string {
+	return p.model
+}
+
+func (p *Phone) GetStatus() Status {
+	return p.power().status()
+}
+
+// Spec describes a phone as it is at the moment, whatever its product.
+type Spec struct {
+	OS      string
+	Model   string
+	Status  Status
+	Battery int
+}
+
+func (p *Phone) GetSpec() Spec {
+	return Spec{OS: p.os, Model: p.model, Status: p.power().status(), Battery: p.battery}
+}
+
+// String describes the phone as it is at the moment, e.g. "Pixel phone
+// (os=google, status=off, battery=100%)".
+func (p *Phone) String() string {
+	name := "Phone"
+	if p.model != "" {
+		name = p.model + " phone"
+	}
+	return fmt.Sprintf("%s (os=%s, status=%s, battery=%d%%)", name, p.os, p.power().status(), p.battery)
+}
+
+// TurnOn, TurnOff and Sleep delegate to the current power state, which
+// decides whether anything happens and which state comes next.
+func (p *Phone) TurnOn() error {
+	if p.battery == 0 {
+		return ErrBatteryDead
+	}
+	return p.power().turnOn(p)
+}
+
+func (p *Phone) TurnOff() error {
+	return p.power().turnOff(p)
+}
+
+func (p *Phone) Sleep() {
+	p.power().sleep(p)
+}
+
+// NewPhoneWithOptions makes one of the package's own products, which all
+// start off with a full battery and the product's default model unless
+// opts say otherwise, and with the product's own apps installed. It fails
+// with ErrUnknownOS for an os that isn't one of them, and with the error of
+// an option given a value the phone can't take. Unlike NewPhone it ignores
+// the registry.
+func NewPhoneWithOptions(os string, opts ...PhoneOption) (IPhone, error) {
+	product, defaults, ok := builtin(normalize(os))
+	if !ok {
+		return nil, fmt.Errorf("%w %q", ErrUnknownOS, os)
+	}
+	*product.phone() = defaults
+	if err := product.phone().setup(product, opts); err != nil {
+		return nil, err
+	}
+	return product, nil
+}
+
+// builtin is an empty product of the package's own for the normalized os
+// name, and the Phone it starts out as.
+func builtin(name string) (product interface {
+	IPhone
+	phone() *Phone
+}, defaults Phone, ok bool) {
+	defaults = Phone{os: name, state: offState, battery: fullBattery}
+	switch name {
+	case "android":
+		product, defaults.model = &Android{}, "Android"
+		defaults.apps = []string{"Chrome", "Gmail", "Play Store"}
+	case "google":
+		product, defaults.model = &Google{}, "Pixel"
+		defaults.apps = []string{"Chrome", "Gemini", "Gmail", "Pixel Camera", "Play Store"}
+	case "ios":
+		product, defaults.model = &Apple{}, "iPhone"
+		defaults.apps = []string{"App Store", "Messages", "Safari"}
+	case "samsung":
+		product, defaults.model = &Samsung{}, "Galaxy"
+		defaults.apps = []string{"Galaxy Store", "Play Store", "Samsung Internet"}
+	default:
+		return nil, Phone{}, false
 	}
-	p.status = "on"
-	fmt.Println("Turning phone on")
+	return product, defaults, true
 }
 
-func (p *Phone) TurnOff() {
-	if p.status == "off" {
-		return
+// mustPhone is for the products' own constructors, which have no error to
+// return.
+func mustPhone(phone IPhone, err error) IPhone {
+	if err != nil {
+		panic(err)
 	}
-	p.status = "off"
-	fmt.Println("Turning phone off")
+	return phone
 }
 
 type Android struct {
 	Phone
 }
 
-func NewAndroid() IPhone {
-	return &Android{
-		Phone: Phone{
-			os:     "android",
-			status: "off",
-		},
-	}
+// NewAndroid, NewGoogle, NewApple and NewSamsung are NewPhoneWithOptions
+// for their product, panicking if an option fails.
+func NewAndroid(opts ...PhoneOption) IPhone {
+	return mustPhone(NewPhoneWithOptions("android", opts...))
 }
 
 type Google struct {
 	Phone
 }
 
-func NewGoogle() IPhone {
-	return &Google{
-		Phone: Phone{
-			os:     "google",
-			status: "off",
-		},
+func NewGoogle(opts ...PhoneOption) IPhone {
+	return mustPhone(NewPhoneWithOptions("google", opts...))
+}
+
+// Apple shows its logo while booting from off, before the usual power-on.
+type Apple struct {
+	Phone
+}
+
+func NewApple(opts ...PhoneOption) IPhone {
+	return mustPhone(NewPhoneWithOptions("ios", opts...))
+}
+
+func (a *Apple) TurnOn() error {
+	if a.power() == offState && a.battery > 0 {
+		a.sink().Println("Showing the Apple logo")
 	}
+	return a.Phone.TurnOn()
+}
+
+// Samsung runs Android but is a product of its own.
+type Samsung struct {
+	Phone
+}
+
+func NewSamsung(opts ...PhoneOption) IPhone {
+	return mustPhone(NewPhoneWithOptions("samsung", opts...))
 }
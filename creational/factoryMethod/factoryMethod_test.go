@@ -0,0 +1,38 @@
+package factoryMethod
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/Antonious-Stewart/15-Most-Common-Design-Patterns/internal/output"
+)
+
+func TestVendorTurnOn(t *testing.T) {
+	tests := []struct {
+		os   string
+		want []string
+	}{
+		{"android", []string{"Turning phone on", "Putting phone to sleep", "Waking phone up"}},
+		{"samsung", []string{"Turning phone on", "Putting phone to sleep", "Waking phone up"}},
+		{"ios", []string{"Showing the Apple logo", "Turning phone on", "Putting phone to sleep", "Waking phone up"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.os, func(t *testing.T) {
+			log := output.NewRecorder()
+			rec := &recordingPublisher{}
+			phone, err := NewPhone(tt.os, WithSink(log), WithPublisher(rec))
+			if err != nil {
+				t.Fatal(err)
+			}
+			phone.TurnOn()
+			phone.Sleep()
+			phone.TurnOn()
+			if got := log.Lines(); !slices.Equal(got, tt.want) {
+				t.Fatalf("printed %q, want %q", got, tt.want)
+			}
+			if len(rec.events) != 3 || rec.events[0].OS != tt.os || rec.events[0].Phone != phone {
+				t.Fatalf("published %+v, want three events from the %s phone", rec.events, tt.os)
+			}
+		})
+	}
+}
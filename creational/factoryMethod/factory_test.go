@@ -0,0 +1,67 @@
+package factoryMethod
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/Antonious-Stewart/15-Most-Common-Design-Patterns/internal/output"
+)
+
+func TestProvisionPhone(t *testing.T) {
+	log := output.NewRecorder()
+	opts := []PhoneOption{WithSink(log)}
+	tests := []struct {
+		factory PhoneFactory
+		want    string
+	}{
+		{AndroidFactory{opts}, "android"},
+		{GoogleFactory{opts}, "google"},
+		{AppleFactory{opts}, "ios"},
+		{SamsungFactory{opts}, "samsung"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			phone, err := ProvisionPhone(tt.factory)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if phone.GetOS() != tt.want || tt.factory.Name() != tt.want || phone.GetStatus() != StatusOn {
+				t.Fatalf("%s factory provisioned a %s phone that is %s, want %s and on", tt.factory.Name(), phone.GetOS(), phone.GetStatus(), tt.want)
+			}
+		})
+	}
+	if got := log.Lines(); len(got) != 5 || !slices.Contains(got, "Showing the Apple logo") {
+		t.Fatalf("printed %q, want each phone turned on", got)
+	}
+}
+
+func TestGetFactory(t *testing.T) {
+	for _, os := range builtins {
+		factory, err := GetFactory(" " + os)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// the zero-value factories print to stdout
+		phone, err := ProvisionPhone(factory)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if phone.GetOS() != os || phone.GetStatus() != StatusOn {
+			t.Fatalf("GetFactory(%q) provisioned a %s phone that is %s", os, phone.GetOS(), phone.GetStatus())
+		}
+	}
+	if f, err := GetFactory("symbian"); !errors.Is(err, ErrUnknownOS) || f != nil {
+		t.Fatalf("GetFactory(symbian) = %v, %v, want %v", f, err, ErrUnknownOS)
+	}
+}
+
+func TestProvisionPhoneFails(t *testing.T) {
+	phone, err := ProvisionPhone(GoogleFactory{[]PhoneOption{WithBattery(0)}})
+	if !errors.Is(err, ErrBatteryDead) || phone != nil {
+		t.Fatalf("ProvisionPhone() = %v, %v, want %v", phone, err, ErrBatteryDead)
+	}
+	if want := "factoryMethod: provisioning google phone: factoryMethod: battery is dead"; err.Error() != want {
+		t.Fatalf("error = %q, want %q", err, want)
+	}
+}
@@ -0,0 +1,96 @@
+package factoryMethod
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+var ErrMissingField = errors.New("factoryMethod: phone JSON is missing a field")
+
+// jsonPhone is how MarshalJSON writes a phone. Kind is its os, which names
+// the product to rebuild.
+type jsonPhone struct {
+	Kind    string   `json:"kind"`
+	Model   string   `json:"model"`
+	Status  Status   `json:"status"`
+	Battery int      `json:"battery"`
+	Apps    []string `json:"apps"`
+}
+
+// MarshalJSON writes the phone's product and state, e.g.
+// {"kind":"google","model":"Pixel","status":"on","battery":70,"apps":["Chrome"]}.
+func (p *Phone) MarshalJSON() ([]byte, error) {
+	apps := p.apps
+	if apps == nil {
+		apps = []string{}
+	}
+	return json.Marshal(jsonPhone{Kind: p.os, Model: p.model, Status: p.power().status(), Battery: p.battery, Apps: apps})
+}
+
+func (s Status) MarshalText() ([]byte, error) {
+	if _, ok := powerStates[s]; !ok {
+		return nil, fmt.Errorf("%w: %d", ErrBadStatus, int(s))
+	}
+	return []byte(s.String()), nil
+}
+
+func (s *Status) UnmarshalText(text []byte) error {
+	for status := range powerStates {
+		if status.String() == string(text) {
+			*s = status
+			return nil
+		}
+	}
+	return fmt.Errorf("%w %q", ErrBadStatus, text)
+}
+
+// UnmarshalPhone rebuilds a phone written by MarshalJSON as the product its
+// "kind" names, one of the package's own. Every field must be there, and
+// a value a phone can't take fails as its PhoneOption would.
+func UnmarshalPhone(data []byte) (IPhone, error) {
+	var in struct {
+		Kind    *string   `json:"kind"`
+		Model   *string   `json:"model"`
+		Status  *Status   `json:"status"`
+		Battery *int      `json:"battery"`
+		Apps    *[]string `json:"apps"`
+	}
+	if err := json.Unmarshal(data, &in); err != nil {
+		return nil, fmt.Errorf("factoryMethod: reading phone: %w", err)
+	}
+	for _, field := range []struct {
+		name    string
+		missing bool
+	}{
+		{"kind", in.Kind == nil},
+		{"model", in.Model == nil},
+		{"status", in.Status == nil},
+		{"battery", in.Battery == nil},
+		{"apps", in.Apps == nil},
+	} {
+		if field.missing {
+			return nil, fmt.Errorf("%w: %q", ErrMissingField, field.name)
+		}
+	}
+	return NewPhoneWithOptions(*in.Kind, WithModel(*in.Model), WithStatus(*in.Status), WithBattery(*in.Battery), withApps(*in.Apps))
+}
+
+// withApps replaces the pre-installed apps with apps, in any order.
+func withApps(apps []string) PhoneOption {
+	return func(p *Phone) error {
+		sorted := slices.Sorted(slices.Values(apps))
+		for i, app := range sorted {
+			if strings.TrimSpace(app) == "" {
+				return ErrEmptyApp
+			}
+			if i > 0 && sorted[i-1] == app {
+				return fmt.Errorf("%w: %q", ErrAppInstalled, app)
+			}
+		}
+		p.apps = sorted
+		return nil
+	}
+}
@@ -0,0 +1,119 @@
+package factoryMethod
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/Antonious-Stewart/15-Most-Common-Design-Patterns/internal/output"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files")
+
+func TestPhoneJSONRoundTrip(t *testing.T) {
+	tests := []struct {
+		os    string
+		check func(IPhone) bool
+	}{
+		{"android", isType[*Android]},
+		{"google", isType[*Google]},
+		{"ios", isType[*Apple]},
+		{"samsung", isType[*Samsung]},
+	}
+	for _, tt := range tests {
+		t.Run(tt.os, func(t *testing.T) {
+			phone, _ := NewPhoneWithOptions(tt.os, WithModel("Model X"), WithSink(output.NewRecorder()))
+			phone.TurnOn()
+			if err := phone.Drain(35); err != nil {
+				t.Fatal(err)
+			}
+			if err := phone.InstallApp("Maps"); err != nil {
+				t.Fatal(err)
+			}
+			data, err := json.Marshal(phone)
+			if err != nil {
+				t.Fatal(err)
+			}
+			back, err := UnmarshalPhone(data)
+			if err != nil {
+				t.Fatalf("UnmarshalPhone(%s) = %v", data, err)
+			}
+			if !tt.check(back) || back.GetSpec() != phone.GetSpec() || !slices.Equal(back.InstalledApps(), phone.InstalledApps()) {
+				t.Fatalf("UnmarshalPhone(%s) = %T %+v, want %T %+v", data, back, back.GetSpec(), phone, phone.GetSpec())
+			}
+			// the copy is a working phone of its own
+			if err := back.TurnOff(); err != nil || phone.GetStatus() != StatusOn {
+				t.Fatalf("TurnOff() on the copy = %v, original %s", err, phone.GetStatus())
+			}
+		})
+	}
+}
+
+func TestPhoneJSONGolden(t *testing.T) {
+	phone := NewGoogle(WithStatus(StatusSleeping), WithBattery(42))
+	data, err := json.Marshal(phone)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for range 5 {
+		if again, _ := json.Marshal(phone); !bytes.Equal(again, data) {
+			t.Fatalf("Marshal() = %s, then %s", data, again)
+		}
+	}
+	out := bytes.NewBuffer(append(data, '\n'))
+	path := filepath.Join("testdata", "phone.json")
+	if *update {
+		if err := os.WriteFile(path, out.Bytes(), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(out.Bytes(), want) {
+		t.Fatalf("JSON =\n%s\nwant\n%s", out.Bytes(), want)
+	}
+}
+
+func TestUnmarshalPhoneErrors(t *testing.T) {
+	tests := []struct {
+		name, data string
+		want       error
+		message    string
+	}{
+		{"unknown kind", `{"kind":"symbian","model":"N95","status":"off","battery":10,"apps":[]}`, ErrUnknownOS, `factoryMethod: unknown os "symbian"`},
+		{"no kind", `{"model":"Pixel","status":"off","battery":10,"apps":[]}`, ErrMissingField, `factoryMethod: phone JSON is missing a field: "kind"`},
+		{"no battery", `{"kind":"google","model":"Pixel","status":"off","apps":[]}`, ErrMissingField, `factoryMethod: phone JSON is missing a field: "battery"`},
+		{"no status", `{"kind":"google","model":"Pixel","battery":10,"apps":[]}`, ErrMissingField, `factoryMethod: phone JSON is missing a field: "status"`},
+		{"bad status", `{"kind":"google","model":"Pixel","status":"melted","battery":10,"apps":[]}`, ErrBadStatus, ""},
+		{"bad battery", `{"kind":"google","model":"Pixel","status":"off","battery":120,"apps":[]}`, ErrBadBattery, ""},
+		{"empty model", `{"kind":"google","model":"","status":"off","battery":10,"apps":[]}`, ErrEmptyModel, ""},
+		{"no apps", `{"kind":"google","model":"Pixel","status":"off","battery":10}`, ErrMissingField, `factoryMethod: phone JSON is missing a field: "apps"`},
+		{"app twice", `{"kind":"google","model":"Pixel","status":"off","battery":10,"apps":["Maps","Chrome","Maps"]}`, ErrAppInstalled, `factoryMethod: app already installed: "Maps"`},
+		{"empty app", `{"kind":"google","model":"Pixel","status":"off","battery":10,"apps":[""]}`, ErrEmptyApp, ""},
+		{"dead and on", `{"kind":"google","model":"Pixel","status":"on","battery":0,"apps":[]}`, ErrBatteryDead, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			phone, err := UnmarshalPhone([]byte(tt.data))
+			if !errors.Is(err, tt.want) || phone != nil {
+				t.Fatalf("UnmarshalPhone() = %v, %v, want %v", phone, err, tt.want)
+			}
+			if tt.message != "" && err.Error() != tt.message {
+				t.Fatalf("error = %q, want %q", err, tt.message)
+			}
+		})
+	}
+	if _, err := UnmarshalPhone([]byte(`[1, 2]`)); err == nil {
+		t.Fatal("UnmarshalPhone() of an array succeeded")
+	}
+	if _, err := json.Marshal(&Phone{state: nil, battery: 5}); err != nil {
+		t.Fatalf("Marshal() of a zero-value phone = %v", err)
+	}
+}
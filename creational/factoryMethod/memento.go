@@ -0,0 +1,103 @@
+package factoryMethod
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+var ErrNoSnapshot = errors.New("factoryMethod: no snapshot to revert to")
+
+// PhoneMemento is an opaque snapshot of a phone's state. Only the product it
+// was taken from can make use of it.
+type PhoneMemento struct {
+	product string
+	state   powerState
+	battery int
+	apps    []string
+	network string
+}
+
+// ProductMismatchError is returned when a memento is restored into a
+// different kind of product than the one that produced it. Want and Got
+// are the concrete product types, such as "*factoryMethod.Android".
+type ProductMismatchError struct {
+	Want string
+	Got  string
+}
+
+func (e *ProductMismatchError) Error() string {
+	return fmt.Sprintf("factoryMethod: cannot restore %s snapshot into %s phone", e.Got, e.Want)
+}
+
+func (p *Phone) Save() PhoneMemento {
+	return PhoneMemento{
+		product: p.product(),
+		state:   p.power(),
+		battery: p.battery,
+		apps:    slices.Clone(p.apps),
+		network: p.network,
+	}
+}
+
+func (p *Phone) Restore(m PhoneMemento) error {
+	if m.product != p.product() {
+		return &ProductMismatchError{Want: p.product(), Got: m.product}
+	}
+	p.state = m.state
+	p.battery = m.battery
+	p.apps = slices.Clone(m.apps)
+	p.network = m.network
+	return nil
+}
+
+// product names the concrete type the phone was built as. Products sharing
+// an os are still told apart.
+func (p *Phone) product() string {
+	if p.self == nil {
+		return fmt.Sprintf("%T", p)
+	}
+	return fmt.Sprintf("%T", p.self)
+}
+
+// PhoneCaretaker keeps the snapshots of a single phone. The state the phone
+// is in when the caretaker is created counts as its factory settings.
+type PhoneCaretaker struct {
+	phone   IPhone
+	factory PhoneMemento
+	history []PhoneMemento
+}
+
+func NewPhoneCaretaker(phone IPhone) *PhoneCaretaker {
+	return &PhoneCaretaker{
+		phone:   phone,
+		factory: phone.Save(),
+		history: make([]PhoneMemento, 0),
+	}
+}
+
+// Checkpoint records the phone's current state; call it before each change.
+func (c *PhoneCaretaker) Checkpoint() {
+	c.history = append(c.history, c.phone.Save())
+}
+
+func (c *PhoneCaretaker) RevertLastChange() error {
+	if len(c.history) == 0 {
+		return ErrNoSnapshot
+	}
+	last := len(c.history) - 1
+	if err := c.phone.Restore(c.history[last]); err != nil {
+		return err
+	}
+	c.history = c.history[:last]
+	return nil
+}
+
+// RestoreFactorySettings returns the phone to its initial state and clears the history.
+func (c *PhoneCaretaker) RestoreFactorySettings() error {
+	if err := c.phone.Restore(c.factory); err != nil {
+		return err
+	}
+	c.history = make([]PhoneMemento, 0)
+	return nil
+}
@@ -0,0 +1,135 @@
+package factoryMethod
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+var products = []struct {
+	name string
+	new  func(opts ...PhoneOption) IPhone
+}{
+	{"android", NewAndroid},
+	{"google", NewGoogle},
+}
+
+func TestPhoneSaveRestore(t *testing.T) {
+	for _, product := range products {
+		t.Run(product.name, func(t *testing.T) {
+			phone := product.new()
+			phone.TurnOn()
+			if err := phone.Drain(30); err != nil {
+				t.Fatal(err)
+			}
+			saved := phone.Save()
+
+			phone.TurnOff()
+			if err := phone.Drain(50); err != nil {
+				t.Fatal(err)
+			}
+			if err := phone.Restore(saved); err != nil {
+				t.Fatal(err)
+			}
+			if got := phone.Save(); !reflect.DeepEqual(got, saved) {
+				t.Fatalf("restored phone = %+v, want %+v", got, saved)
+			}
+			if phone.BatteryLevel() != 70 {
+				t.Fatalf("BatteryLevel() = %d, want 70", phone.BatteryLevel())
+			}
+		})
+	}
+}
+
+func TestPhoneRestoreRejectsOtherProduct(t *testing.T) {
+	android, google := NewAndroid(), NewGoogle()
+
+	err := android.Restore(google.Save())
+	var mismatch *ProductMismatchError
+	if !errors.As(err, &mismatch) {
+		t.Fatalf("Restore(google snapshot) = %v, want *ProductMismatchError", err)
+	}
+	if mismatch.Want != "*factoryMethod.Android" || mismatch.Got != "*factoryMethod.Google" {
+		t.Fatalf("ProductMismatchError = %+v, want Android <- Google", mismatch)
+	}
+}
+
+func TestPhoneRestoreComparesProductTypes(t *testing.T) {
+	// a product of its own type that happens to share Android's os
+	lookalike := &Nokia{Phone: Phone{os: "android", state: offState, battery: fullBattery}}
+	lookalike.setup(lookalike, nil)
+
+	err := NewAndroid().Restore(lookalike.Save())
+	var mismatch *ProductMismatchError
+	if !errors.As(err, &mismatch) || mismatch.Got != "*factoryMethod.Nokia" {
+		t.Fatalf("Restore(lookalike snapshot) = %v, want a *ProductMismatchError from Nokia", err)
+	}
+}
+
+func TestPhoneRestoresNetwork(t *testing.T) {
+	phone := NewGoogle()
+	if err := phone.TurnOn(); err != nil {
+		t.Fatal(err)
+	}
+	if err := phone.Connect("home"); err != nil {
+		t.Fatal(err)
+	}
+	saved := phone.Save()
+
+	if err := phone.Connect("office"); err != nil {
+		t.Fatal(err)
+	}
+	if err := phone.Restore(saved); err != nil {
+		t.Fatal(err)
+	}
+	if got := phone.Network(); got != "home" {
+		t.Fatalf("Network() after restore = %q, want home", got)
+	}
+
+	if err := phone.TurnOff(); err != nil {
+		t.Fatal(err)
+	}
+	if err := phone.Restore(saved); err != nil {
+		t.Fatal(err)
+	}
+	if phone.GetStatus() != StatusOn || phone.Network() != "home" {
+		t.Fatalf("restored a phone that was off to %s on %q, want on, on home", phone.GetStatus(), phone.Network())
+	}
+}
+
+func TestPhoneCaretaker(t *testing.T) {
+	for _, product := range products {
+		t.Run(product.name, func(t *testing.T) {
+			phone := product.new()
+			factory := phone.Save()
+			c := NewPhoneCaretaker(phone)
+
+			c.Checkpoint()
+			phone.TurnOn()
+			afterTurnOn := phone.Save()
+			c.Checkpoint()
+			if err := phone.Drain(40); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := c.RevertLastChange(); err != nil {
+				t.Fatal(err)
+			}
+			if got := phone.Save(); !reflect.DeepEqual(got, afterTurnOn) {
+				t.Fatalf("after revert = %+v, want %+v", got, afterTurnOn)
+			}
+			if err := phone.Drain(10); err != nil {
+				t.Fatal(err)
+			}
+			if err := c.RestoreFactorySettings(); err != nil {
+				t.Fatal(err)
+			}
+			if got := phone.Save(); !reflect.DeepEqual(got, factory) {
+				t.Fatalf("after factory reset = %+v, want %+v", got, factory)
+			}
+			if err := c.RevertLastChange(); !errors.Is(err, ErrNoSnapshot) {
+				t.Fatalf("RevertLastChange after reset = %v, want ErrNoSnapshot", err)
+			}
+		})
+	}
+}
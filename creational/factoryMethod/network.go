@@ -0,0 +1,33 @@
+package factoryMethod
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var ErrEmptyNetwork = errors.New("factoryMethod: network name is empty")
+
+// Connect joins the network called name, leaving the one the phone was on.
+// The phone must be on or asleep.
+func (p *Phone) Connect(name string) error {
+	if strings.TrimSpace(name) == "" {
+		return ErrEmptyNetwork
+	}
+	if p.power() == offState {
+		return fmt.Errorf("%w: can't join %q", ErrPhoneOff, name)
+	}
+	p.network = name
+	return nil
+}
+
+// Disconnect leaves the phone's network. A phone on none stays that way.
+func (p *Phone) Disconnect() {
+	p.network = ""
+}
+
+// Network is the network the phone is on, or "" for none. Turning the phone
+// off leaves it.
+func (p *Phone) Network() string {
+	return p.network
+}
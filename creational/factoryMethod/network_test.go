@@ -0,0 +1,71 @@
+package factoryMethod
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Antonious-Stewart/15-Most-Common-Design-Patterns/internal/output"
+)
+
+func TestConnect(t *testing.T) {
+	phone := NewSamsung(WithStatus(StatusOn), WithSink(output.NewRecorder()))
+	if got := phone.Network(); got != "" {
+		t.Fatalf("new phone is on %q, want no network", got)
+	}
+	if err := phone.Connect("home"); err != nil {
+		t.Fatal(err)
+	}
+	phone.Sleep()
+	if err := phone.Connect("office"); err != nil {
+		t.Fatalf("Connect() on a sleeping phone = %v", err)
+	}
+	if got := phone.Network(); got != "office" {
+		t.Fatalf("Network() = %q, want office", got)
+	}
+	phone.Disconnect()
+	phone.Disconnect()
+	if got := phone.Network(); got != "" {
+		t.Fatalf("Network() after Disconnect = %q", got)
+	}
+}
+
+func TestConnectRefused(t *testing.T) {
+	phone := NewAndroid(WithSink(output.NewRecorder()))
+	if err := phone.Connect("home"); !errors.Is(err, ErrPhoneOff) {
+		t.Fatalf("Connect() on a phone that is off = %v, want ErrPhoneOff", err)
+	}
+	if err := phone.TurnOn(); err != nil {
+		t.Fatal(err)
+	}
+	if err := phone.Connect(" "); !errors.Is(err, ErrEmptyNetwork) {
+		t.Fatalf("Connect(blank) = %v, want ErrEmptyNetwork", err)
+	}
+	if got := phone.Network(); got != "" {
+		t.Fatalf("Network() after refused connects = %q", got)
+	}
+}
+
+func TestPoweringOffDisconnects(t *testing.T) {
+	tests := []struct {
+		name string
+		off  func(p IPhone) error
+	}{
+		{"TurnOff", func(p IPhone) error { return p.TurnOff() }},
+		{"battery runs out", func(p IPhone) error { return p.Drain(fullBattery) }},
+		{"Reset", func(p IPhone) error { return p.Reset() }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			phone := NewGoogle(WithStatus(StatusOn), WithSink(output.NewRecorder()))
+			if err := phone.Connect("home"); err != nil {
+				t.Fatal(err)
+			}
+			if err := tt.off(phone); err != nil {
+				t.Fatal(err)
+			}
+			if phone.GetStatus() != StatusOff || phone.Network() != "" {
+				t.Fatalf("phone is %s on %q, want off on no network", phone.GetStatus(), phone.Network())
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package factoryMethod
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Antonious-Stewart/15-Most-Common-Design-Patterns/internal/output"
+)
+
+func TestNewPhoneWithOptionsDefaults(t *testing.T) {
+	tests := []struct {
+		os, model string
+	}{
+		{"android", "Android"},
+		{"google", "Pixel"},
+		{"ios", "iPhone"},
+		{" Samsung", "Galaxy"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.os, func(t *testing.T) {
+			phone, err := NewPhoneWithOptions(tt.os)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if phone.GetModel() != tt.model || phone.GetStatus() != StatusOff || phone.BatteryLevel() != fullBattery {
+				t.Fatalf("%s phone is a %s, %s at %d%%, want a %s, off at full",
+					phone.GetOS(), phone.GetModel(), phone.GetStatus(), phone.BatteryLevel(), tt.model)
+			}
+		})
+	}
+	if _, err := NewPhoneWithOptions("symbian"); !errors.Is(err, ErrUnknownOS) {
+		t.Fatalf("NewPhoneWithOptions(symbian) = %v, want %v", err, ErrUnknownOS)
+	}
+}
+
+func TestNewPhoneWithOptionsEach(t *testing.T) {
+	log := output.NewRecorder()
+	rec := &recordingPublisher{}
+	tests := []struct {
+		name  string
+		opt   PhoneOption
+		check func(IPhone) bool
+	}{
+		{"status", WithStatus(StatusSleeping), func(p IPhone) bool { return p.GetStatus() == StatusSleeping }},
+		{"battery", WithBattery(35), func(p IPhone) bool { return p.BatteryLevel() == 35 }},
+		{"empty battery", WithBattery(0), func(p IPhone) bool { return p.BatteryLevel() == 0 }},
+		{"model", WithModel("Pixel 9"), func(p IPhone) bool { return p.GetModel() == "Pixel 9" }},
+		{"sink", WithSink(log), func(p IPhone) bool { p.TurnOn(); return len(log.Lines()) == 1 }},
+		{"publisher", WithPublisher(rec), func(p IPhone) bool { p.TurnOn(); return len(rec.events) == 1 }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			phone, err := NewPhoneWithOptions("google", tt.opt)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !tt.check(phone) {
+				t.Fatalf("option not applied: %s phone, %s at %d%%", phone.GetModel(), phone.GetStatus(), phone.BatteryLevel())
+			}
+		})
+	}
+}
+
+func TestNewPhoneWithOptionsOrder(t *testing.T) {
+	opts := []PhoneOption{WithBattery(0), WithStatus(StatusOn), WithStatus(StatusOff), WithModel("Galaxy S"), WithBattery(60), WithStatus(StatusOn)}
+	forward, err := NewPhoneWithOptions("samsung", opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// options setting different things can go in any order
+	backward, err := NewPhoneWithOptions("samsung", WithStatus(StatusOn), WithBattery(60), WithModel("Galaxy S"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, phone := range []IPhone{forward, backward} {
+		if phone.GetStatus() != StatusOn || phone.BatteryLevel() != 60 || phone.GetModel() != "Galaxy S" {
+			t.Fatalf("phone is a %s, %s at %d%%, want a Galaxy S, on at 60%%", phone.GetModel(), phone.GetStatus(), phone.BatteryLevel())
+		}
+	}
+	// an empty battery and being on clash whichever comes first
+	for _, opts := range [][]PhoneOption{
+		{WithStatus(StatusOn), WithBattery(0)},
+		{WithBattery(0), WithStatus(StatusOn)},
+	} {
+		if _, err := NewPhoneWithOptions("android", opts...); !errors.Is(err, ErrBatteryDead) {
+			t.Fatalf("NewPhoneWithOptions() = %v, want %v", err, ErrBatteryDead)
+		}
+	}
+}
+
+func TestNewPhoneWithOptionsInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		opt  PhoneOption
+		want error
+	}{
+		{"negative battery", WithBattery(-1), ErrBadBattery},
+		{"battery over full", WithBattery(101), ErrBadBattery},
+		{"empty model", WithModel(""), ErrEmptyModel},
+		{"blank model", WithModel("  "), ErrEmptyModel},
+		{"unknown status", WithStatus(Status(7)), ErrBadStatus},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			phone, err := NewPhoneWithOptions("ios", WithModel("iPhone 16"), tt.opt)
+			if !errors.Is(err, tt.want) || phone != nil {
+				t.Fatalf("NewPhoneWithOptions() = %v, %v, want %v", phone, err, tt.want)
+			}
+			// through the registry too
+			if _, err := NewPhone("ios", tt.opt); !errors.Is(err, tt.want) {
+				t.Fatalf("NewPhone() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestConstructorsPanicOnBadOptions(t *testing.T) {
+	defer func() {
+		if err, _ := recover().(error); !errors.Is(err, ErrBadBattery) {
+			t.Fatalf("recovered %v, want %v", err, ErrBadBattery)
+		}
+	}()
+	NewAndroid(WithBattery(500))
+	t.Fatal("NewAndroid with a bad option didn't panic")
+}
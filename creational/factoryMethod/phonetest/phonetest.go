@@ -0,0 +1,315 @@
+// Package phonetest has test doubles for code using factoryMethod.IPhone: a
+// FakePhone to hand it, and a Recorder to see what it did with a phone.
+package phonetest
+
+import (
+	"slices"
+	"sync"
+
+	"github.com/Antonious-Stewart/15-Most-Common-Design-Patterns/creational/factoryMethod"
+)
+
+// FakePhone is an IPhone whose state is its fields. Without the funcs it
+// behaves simply: TurnOn, TurnOff and Sleep set Status, InstallApp and
+// UninstallApp change Apps, Connect and Disconnect change NetworkName, all
+// always succeeding, and Charge, Drain and Elapse change nothing. A func,
+// when set, replaces the method of the same name.
+type FakePhone struct {
+	OS, Model   string
+	Status      factoryMethod.Status
+	Battery     int
+	Apps        []string
+	NetworkName string
+
+	TurnOnFunc  func() error
+	TurnOffFunc func() error
+	SleepFunc   func()
+	ChargeFunc  func(percent int) error
+	DrainFunc   func(percent int) error
+	ElapseFunc  func(hours int) error
+	RestoreFunc func(m factoryMethod.PhoneMemento) error
+
+	InstallAppFunc   func(name string) error
+	UninstallAppFunc func(name string) error
+	ConnectFunc      func(network string) error
+	DisconnectFunc   func()
+	ResetFunc        func() error
+}
+
+func (f *FakePhone) GetOS() string                   { return f.OS }
+func (f *FakePhone) GetModel() string                { return f.Model }
+func (f *FakePhone) GetStatus() factoryMethod.Status { return f.Status }
+func (f *FakePhone) BatteryLevel() int               { return f.Battery }
+func (f *FakePhone) Network() string                 { return f.NetworkName }
+
+func (f *FakePhone) GetSpec() factoryMethod.Spec {
+	return factoryMethod.Spec{OS: f.OS, Model: f.Model, Status: f.Status, Battery: f.Battery}
+}
+
+func (f *FakePhone) TurnOn() error {
+	if f.TurnOnFunc != nil {
+		return f.TurnOnFunc()
+	}
+	f.Status = factoryMethod.StatusOn
+	return nil
+}
+
+func (f *FakePhone) TurnOff() error {
+	if f.TurnOffFunc != nil {
+		return f.TurnOffFunc()
+	}
+	f.Status = factoryMethod.StatusOff
+	return nil
+}
+
+func (f *FakePhone) Sleep() {
+	if f.SleepFunc != nil {
+		f.SleepFunc()
+		return
+	}
+	f.Status = factoryMethod.StatusSleeping
+}
+
+func (f *FakePhone) Elapse(hours int) error {
+	if f.ElapseFunc != nil {
+		return f.ElapseFunc(hours)
+	}
+	return nil
+}
+
+func (f *FakePhone) Charge(percent int) error {
+	if f.ChargeFunc != nil {
+		return f.ChargeFunc(percent)
+	}
+	return nil
+}
+
+func (f *FakePhone) Drain(percent int) error {
+	if f.DrainFunc != nil {
+		return f.DrainFunc(percent)
+	}
+	return nil
+}
+
+// Save returns an empty memento, which no real phone will restore.
+func (f *FakePhone) Save() factoryMethod.PhoneMemento {
+	return factoryMethod.PhoneMemento{}
+}
+
+func (f *FakePhone) Restore(m factoryMethod.PhoneMemento) error {
+	if f.RestoreFunc != nil {
+		return f.RestoreFunc(m)
+	}
+	return nil
+}
+
+func (f *FakePhone) InstallApp(name string) error {
+	if f.InstallAppFunc != nil {
+		return f.InstallAppFunc(name)
+	}
+	f.Apps = append(f.Apps, name)
+	return nil
+}
+
+func (f *FakePhone) UninstallApp(name string) error {
+	if f.UninstallAppFunc != nil {
+		return f.UninstallAppFunc(name)
+	}
+	f.Apps = slices.DeleteFunc(f.Apps, func(app string) bool { return app == name })
+	return nil
+}
+
+func (f *FakePhone) Connect(network string) error {
+	if f.ConnectFunc != nil {
+		return f.ConnectFunc(network)
+	}
+	f.NetworkName = network
+	return nil
+}
+
+func (f *FakePhone) Disconnect() {
+	if f.DisconnectFunc != nil {
+		f.DisconnectFunc()
+		return
+	}
+	f.NetworkName = ""
+}
+
+// Reset only turns the fake off.
+func (f *FakePhone) Reset() error {
+	if f.ResetFunc != nil {
+		return f.ResetFunc()
+	}
+	f.Status = factoryMethod.StatusOff
+	return nil
+}
+
+// InstalledApps is a copy of Apps.
+func (f *FakePhone) InstalledApps() []string {
+	return slices.Clone(f.Apps)
+}
+
+// Call is one method called on a Recorder, with its arguments.
+type Call struct {
+	Method string
+	Args   []any
+}
+
+// Recorder is an IPhone passing every call on to Phone and remembering it,
+// in order. It is safe to use from several goroutines if Phone is.
+type Recorder struct {
+	Phone factoryMethod.IPhone
+
+	mu    sync.Mutex
+	calls []Call
+}
+
+func NewRecorder(phone factoryMethod.IPhone) *Recorder {
+	return &Recorder{Phone: phone}
+}
+
+func (r *Recorder) record(method string, args ...any) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = append(r.calls, Call{Method: method, Args: args})
+}
+
+// Calls is every call so far, in the order they were made.
+func (r *Recorder) Calls() []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return slices.Clone(r.calls)
+}
+
+// Methods is the names of the calls so far, in order.
+func (r *Recorder) Methods() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	methods := make([]string, len(r.calls))
+	for i, c := range r.calls {
+		methods[i] = c.Method
+	}
+	return methods
+}
+
+// Count is how many times method was called.
+func (r *Recorder) Count(method string) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	n := 0
+	for _, c := range r.calls {
+		if c.Method == method {
+			n++
+		}
+	}
+	return n
+}
+
+// Before reports whether first was called and the first call to it came
+// before any call to then.
+func (r *Recorder) Before(first, then string) bool {
+	methods := r.Methods()
+	i := slices.Index(methods, first)
+	j := slices.Index(methods, then)
+	return i >= 0 && (j < 0 || i < j)
+}
+
+func (r *Recorder) GetOS() string {
+	r.record("GetOS")
+	return r.Phone.GetOS()
+}
+
+func (r *Recorder) GetModel() string {
+	r.record("GetModel")
+	return r.Phone.GetModel()
+}
+
+func (r *Recorder) GetStatus() factoryMethod.Status {
+	r.record("GetStatus")
+	return r.Phone.GetStatus()
+}
+
+func (r *Recorder) GetSpec() factoryMethod.Spec {
+	r.record("GetSpec")
+	return r.Phone.GetSpec()
+}
+
+func (r *Recorder) TurnOn() error {
+	r.record("TurnOn")
+	return r.Phone.TurnOn()
+}
+
+func (r *Recorder) TurnOff() error {
+	r.record("TurnOff")
+	return r.Phone.TurnOff()
+}
+
+func (r *Recorder) Sleep() {
+	r.record("Sleep")
+	r.Phone.Sleep()
+}
+
+func (r *Recorder) Elapse(hours int) error {
+	r.record("Elapse", hours)
+	return r.Phone.Elapse(hours)
+}
+
+func (r *Recorder) BatteryLevel() int {
+	r.record("BatteryLevel")
+	return r.Phone.BatteryLevel()
+}
+
+func (r *Recorder) Charge(percent int) error {
+	r.record("Charge", percent)
+	return r.Phone.Charge(percent)
+}
+
+func (r *Recorder) Drain(percent int) error {
+	r.record("Drain", percent)
+	return r.Phone.Drain(percent)
+}
+
+func (r *Recorder) Save() factoryMethod.PhoneMemento {
+	r.record("Save")
+	return r.Phone.Save()
+}
+
+func (r *Recorder) Restore(m factoryMethod.PhoneMemento) error {
+	r.record("Restore", m)
+	return r.Phone.Restore(m)
+}
+
+func (r *Recorder) InstallApp(name string) error {
+	r.record("InstallApp", name)
+	return r.Phone.InstallApp(name)
+}
+
+func (r *Recorder) UninstallApp(name string) error {
+	r.record("UninstallApp", name)
+	return r.Phone.UninstallApp(name)
+}
+
+func (r *Recorder) InstalledApps() []string {
+	r.record("InstalledApps")
+	return r.Phone.InstalledApps()
+}
+
+func (r *Recorder) Connect(network string) error {
+	r.record("Connect", network)
+	return r.Phone.Connect(network)
+}
+
+func (r *Recorder) Disconnect() {
+	r.record("Disconnect")
+	r.Phone.Disconnect()
+}
+
+func (r *Recorder) Network() string {
+	r.record("Network")
+	return r.Phone.Network()
+}
+
+func (r *Recorder) Reset() error {
+	r.record("Reset")
+	return r.Phone.Reset()
+}
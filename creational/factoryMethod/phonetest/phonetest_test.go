@@ -0,0 +1,136 @@
+package phonetest
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/Antonious-Stewart/15-Most-Common-Design-Patterns/creational/factoryMethod"
+	"github.com/Antonious-Stewart/15-Most-Common-Design-Patterns/internal/output"
+)
+
+var _ factoryMethod.IPhone = (*FakePhone)(nil)
+var _ factoryMethod.IPhone = (*Recorder)(nil)
+
+func TestFakePhoneDefaults(t *testing.T) {
+	fake := &FakePhone{OS: "fake", Model: "Mk I", Battery: 50}
+	if err := fake.TurnOn(); err != nil || fake.GetStatus() != factoryMethod.StatusOn {
+		t.Fatalf("TurnOn() = %v, phone %s", err, fake.GetStatus())
+	}
+	fake.Sleep()
+	want := factoryMethod.Spec{OS: "fake", Model: "Mk I", Status: factoryMethod.StatusSleeping, Battery: 50}
+	if got := fake.GetSpec(); got != want {
+		t.Fatalf("GetSpec() = %+v, want %+v", got, want)
+	}
+	for _, err := range []error{fake.TurnOff(), fake.Charge(10), fake.Drain(10), fake.Elapse(1), fake.Restore(fake.Save())} {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if fake.GetStatus() != factoryMethod.StatusOff || fake.BatteryLevel() != 50 {
+		t.Fatalf("fake is %s at %d%%", fake.GetStatus(), fake.BatteryLevel())
+	}
+	fake.InstallApp("Maps")
+	fake.InstallApp("Notes")
+	fake.UninstallApp("Maps")
+	apps := fake.InstalledApps()
+	apps[0] = "changed"
+	if !slices.Equal(fake.Apps, []string{"Notes"}) {
+		t.Fatalf("Apps = %q, want [Notes]", fake.Apps)
+	}
+	if err := fake.Connect("home"); err != nil || fake.Network() != "home" {
+		t.Fatalf("Connect() = %v, network %q", err, fake.Network())
+	}
+	fake.Disconnect()
+	if fake.NetworkName != "" {
+		t.Fatalf("NetworkName = %q after Disconnect", fake.NetworkName)
+	}
+}
+
+func TestFakePhoneFuncs(t *testing.T) {
+	broken := errors.New("button stuck")
+	var drained []int
+	fake := &FakePhone{
+		TurnOnFunc: func() error { return broken },
+		DrainFunc:  func(percent int) error { drained = append(drained, percent); return nil },
+	}
+	if err := fake.TurnOn(); err != broken || fake.GetStatus() != factoryMethod.StatusOff {
+		t.Fatalf("TurnOn() = %v, phone %s, want the func's error and no change", err, fake.GetStatus())
+	}
+	fake.Drain(5)
+	fake.Drain(7)
+	if !slices.Equal(drained, []int{5, 7}) {
+		t.Fatalf("DrainFunc got %v", drained)
+	}
+
+	slept := 0
+	fake.SleepFunc = func() { slept++ }
+	fake.Sleep()
+	if slept != 1 || fake.GetStatus() != factoryMethod.StatusOff {
+		t.Fatalf("Sleep() ran SleepFunc %d time(s), phone %s, want once and no change", slept, fake.GetStatus())
+	}
+
+	fake.NetworkName = "home"
+	fake.DisconnectFunc = func() {}
+	fake.Disconnect()
+	if fake.NetworkName != "home" {
+		t.Fatalf("Disconnect() with a DisconnectFunc changed NetworkName to %q", fake.NetworkName)
+	}
+}
+
+func TestRecorder(t *testing.T) {
+	phone := NewRecorder(factoryMethod.NewGoogle(factoryMethod.WithSink(output.NewRecorder())))
+	provision(phone)
+
+	if phone.Count("TurnOn") != 1 || !phone.Before("TurnOn", "TurnOff") {
+		t.Fatalf("calls %q, want TurnOn once before TurnOff", phone.Methods())
+	}
+	want := []Call{
+		{Method: "TurnOn"},
+		{Method: "Elapse", Args: []any{2}},
+		{Method: "GetStatus"},
+		{Method: "TurnOff"},
+	}
+	if got := phone.Calls(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("Calls() = %+v, want %+v", got, want)
+	}
+	// the calls reached the real phone
+	if phone.Phone.BatteryLevel() != 80 || phone.Phone.GetStatus() != factoryMethod.StatusOff {
+		t.Fatalf("wrapped phone is %s at %d%%", phone.Phone.GetStatus(), phone.Phone.BatteryLevel())
+	}
+	if phone.Before("TurnOff", "TurnOn") || phone.Before("Sleep", "TurnOn") {
+		t.Fatal("Before() reported calls out of order")
+	}
+}
+
+// provision is code under test taking any IPhone.
+func provision(phone factoryMethod.IPhone) {
+	phone.TurnOn()
+	phone.Elapse(2)
+	if phone.GetStatus() == factoryMethod.StatusOn {
+		phone.TurnOff()
+	}
+}
+
+func TestFakeInRegistry(t *testing.T) {
+	r := factoryMethod.NewRegistry()
+	err := r.Register("fake", func() factoryMethod.IPhone {
+		return NewRecorder(&FakePhone{OS: "fake", Battery: 100})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	phone, err := r.Create("fake")
+	if err != nil {
+		t.Fatal(err)
+	}
+	provision(phone)
+	rec := phone.(*Recorder)
+	if got := rec.Methods(); !slices.Equal(got, []string{"TurnOn", "Elapse", "GetStatus", "TurnOff"}) {
+		t.Fatalf("Methods() = %q", got)
+	}
+	if _, err := r.NewPhone("fake", factoryMethod.WithBattery(5)); !errors.Is(err, factoryMethod.ErrNoOptions) {
+		t.Fatalf("NewPhone(fake, opts) = %v, want %v", err, factoryMethod.ErrNoOptions)
+	}
+}
@@ -0,0 +1,161 @@
+package factoryMethod
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Antonious-Stewart/15-Most-Common-Design-Patterns/internal/output"
+)
+
+// The phone's power behavior is a small application of the State pattern:
+// each state decides what TurnOn, TurnOff and Sleep mean while it is active
+// and which state the phone moves to.
+
+var (
+	ErrAlreadyOn  = errors.New("factoryMethod: phone is already on")
+	ErrAlreadyOff = errors.New("factoryMethod: phone is already off")
+)
+
+// Status is what a phone's power is doing. The zero value is StatusOff, as
+// for a zero-value Phone.
+type Status int
+
+const (
+	StatusOff Status = iota
+	StatusOn
+	StatusSleeping
+)
+
+func (s Status) String() string {
+	switch s {
+	case StatusOff:
+		return "off"
+	case StatusOn:
+		return "on"
+	case StatusSleeping:
+		return "sleeping"
+	}
+	return "Status(unknown)"
+}
+
+type powerState interface {
+	turnOn(p *Phone) error
+	turnOff(p *Phone) error
+	sleep(p *Phone)
+	status() Status
+	// drainPerHour is the battery percentage used per hour in this state.
+	drainPerHour() int
+}
+
+type poweredOff struct{}
+
+type poweredOn struct{}
+
+// asleep keeps the phone on with the screen off, using less battery.
+type asleep struct{}
+
+var (
+	offState   powerState = &poweredOff{}
+	onState    powerState = &poweredOn{}
+	sleepState powerState = &asleep{}
+)
+
+var powerStates = map[Status]powerState{
+	StatusOff:      offState,
+	StatusOn:       onState,
+	StatusSleeping: sleepState,
+}
+
+// power is the current state. A zero-value Phone has none yet and counts as
+// off, like a freshly built one.
+func (p *Phone) power() powerState {
+	if p.state == nil {
+		return offState
+	}
+	return p.state
+}
+
+func (s *poweredOff) turnOn(p *Phone) error {
+	p.transition(onState, "Turning phone on")
+	return nil
+}
+
+func (s *poweredOff) turnOff(p *Phone) error {
+	return ErrAlreadyOff
+}
+
+// sleep does nothing: a phone that is off can't go to sleep.
+func (s *poweredOff) sleep(p *Phone) {}
+
+func (s *poweredOff) status() Status {
+	return StatusOff
+}
+
+func (s *poweredOff) drainPerHour() int {
+	return 0
+}
+
+func (s *poweredOn) turnOn(p *Phone) error {
+	return ErrAlreadyOn
+}
+
+func (s *poweredOn) turnOff(p *Phone) error {
+	p.transition(offState, "Turning phone off")
+	return nil
+}
+
+func (s *poweredOn) sleep(p *Phone) {
+	p.transition(sleepState, "Putting phone to sleep")
+}
+
+func (s *poweredOn) status() Status {
+	return StatusOn
+}
+
+func (s *poweredOn) drainPerHour() int {
+	return 10
+}
+
+func (s *asleep) turnOn(p *Phone) error {
+	p.transition(onState, "Waking phone up")
+	return nil
+}
+
+func (s *asleep) turnOff(p *Phone) error {
+	p.transition(offState, "Turning phone off")
+	return nil
+}
+
+func (s *asleep) sleep(p *Phone) {}
+
+func (s *asleep) status() Status {
+	return StatusSleeping
+}
+
+func (s *asleep) drainPerHour() int {
+	return 2
+}
+
+func (p *Phone) transition(to powerState, message string) {
+	p.state = to
+	if to == offState {
+		p.network = ""
+	}
+	p.sink().Println(message)
+	p.publish(StatusChanged)
+}
+
+func (p *Phone) sink() output.Sink {
+	if p.out == nil {
+		return output.Stdout
+	}
+	return p.out
+}
+
+// Elapse drains the battery for hours of use at the current state's rate.
+func (p *Phone) Elapse(hours int) error {
+	if hours < 0 {
+		return fmt.Errorf("factoryMethod: hours must not be negative: %d", hours)
+	}
+	return p.Drain(hours * p.power().drainPerHour())
+}
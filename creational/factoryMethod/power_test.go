@@ -0,0 +1,219 @@
+package factoryMethod
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/Antonious-Stewart/15-Most-Common-Design-Patterns/internal/output"
+)
+
+func status(p IPhone) Status {
+	return p.Save().state.status()
+}
+
+// turnOn and turnOff ignore the error, for tables of steps.
+func turnOn(p IPhone)  { p.TurnOn() }
+func turnOff(p IPhone) { p.TurnOff() }
+
+func TestPowerTransitions(t *testing.T) {
+	// from is reached from a fresh phone by the listed calls
+	reach := map[Status][]func(IPhone){
+		StatusOff:      nil,
+		StatusOn:       {turnOn},
+		StatusSleeping: {turnOn, IPhone.Sleep},
+	}
+	tests := []struct {
+		from Status
+		call string
+		to   Status
+		err  error
+	}{
+		{StatusOff, "TurnOn", StatusOn, nil},
+		{StatusOff, "TurnOff", StatusOff, ErrAlreadyOff},
+		{StatusOff, "Sleep", StatusOff, nil},
+		{StatusOn, "TurnOn", StatusOn, ErrAlreadyOn},
+		{StatusOn, "TurnOff", StatusOff, nil},
+		{StatusOn, "Sleep", StatusSleeping, nil},
+		{StatusSleeping, "TurnOn", StatusOn, nil},
+		{StatusSleeping, "TurnOff", StatusOff, nil},
+		{StatusSleeping, "Sleep", StatusSleeping, nil},
+	}
+	calls := map[string]func(IPhone) error{
+		"TurnOn":  IPhone.TurnOn,
+		"TurnOff": IPhone.TurnOff,
+		"Sleep":   func(p IPhone) error { p.Sleep(); return nil },
+	}
+	for _, tt := range tests {
+		t.Run(tt.from.String()+"/"+tt.call, func(t *testing.T) {
+			phone := NewAndroid()
+			for _, step := range reach[tt.from] {
+				step(phone)
+			}
+			rec := &recordingPublisher{}
+			phone.(*Android).publisher = rec
+
+			if err := calls[tt.call](phone); !errors.Is(err, tt.err) {
+				t.Fatalf("%s from %s = %v, want %v", tt.call, tt.from, err, tt.err)
+			}
+			if got := phone.GetStatus(); got != tt.to {
+				t.Fatalf("%s from %s left the phone %s, want %s", tt.call, tt.from, got, tt.to)
+			}
+			if changed := tt.from != tt.to; changed != (len(rec.events) == 1) {
+				t.Fatalf("published %v for %s -> %s", rec.kinds(), tt.from, tt.to)
+			}
+		})
+	}
+}
+
+func TestElapseDrainsByState(t *testing.T) {
+	tests := []struct {
+		steps []func(IPhone)
+		want  int
+	}{
+		{nil, 100},
+		{[]func(IPhone){turnOn}, 70},
+		{[]func(IPhone){turnOn, IPhone.Sleep}, 94},
+	}
+	for _, tt := range tests {
+		phone := NewGoogle()
+		for _, step := range tt.steps {
+			step(phone)
+		}
+		if err := phone.Elapse(3); err != nil {
+			t.Fatal(err)
+		}
+		if got := phone.BatteryLevel(); got != tt.want {
+			t.Fatalf("%s phone after 3 hours has %d%%, want %d%%", status(phone), got, tt.want)
+		}
+	}
+	if err := NewGoogle().Elapse(-1); err == nil {
+		t.Fatal("Elapse(-1) succeeded")
+	}
+}
+
+func TestZeroValuePhoneIsOff(t *testing.T) {
+	var p Phone
+	if err := p.Elapse(1); err != nil {
+		t.Fatal(err)
+	}
+	var zero Status
+	if zero != StatusOff || p.GetStatus() != StatusOff {
+		t.Fatalf("zero-value phone is %s, want off", p.GetStatus())
+	}
+	if err := p.TurnOff(); !errors.Is(err, ErrAlreadyOff) {
+		t.Fatalf("TurnOff() on a zero-value phone = %v, want %v", err, ErrAlreadyOff)
+	}
+	p.Sleep()
+	if got := p.Save().state.status(); got != StatusOff {
+		t.Fatalf("zero-value phone is %s, want off", got)
+	}
+	// and its battery is empty
+	if err := p.TurnOn(); !errors.Is(err, ErrBatteryDead) {
+		t.Fatalf("TurnOn() on a zero-value phone = %v, want %v", err, ErrBatteryDead)
+	}
+	if err := p.Charge(10); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.TurnOn(); err != nil {
+		t.Fatal(err)
+	}
+	if got := p.GetStatus(); got != StatusOn {
+		t.Fatalf("zero-value phone after charging and TurnOn is %s, want on", got)
+	}
+}
+
+func TestPowerPrintsToSink(t *testing.T) {
+	for _, factory := range []func(opts ...PhoneOption) IPhone{NewAndroid, NewGoogle} {
+		log := output.NewRecorder()
+		phone := factory(WithSink(log))
+		phone.TurnOn()
+		phone.Sleep()
+		phone.Sleep()
+		phone.TurnOn()
+		phone.TurnOff()
+		want := []string{"Turning phone on", "Putting phone to sleep", "Waking phone up", "Turning phone off"}
+		if got := log.Lines(); !reflect.DeepEqual(got, want) {
+			t.Fatalf("%s phone printed %q, want %q", phone.GetOS(), got, want)
+		}
+	}
+}
+
+func ExampleNewAndroid() {
+	phone := NewAndroid()
+	fmt.Println(phone.GetOS())
+	phone.TurnOn()
+	fmt.Println(phone.TurnOn())
+	phone.Sleep()
+	phone.TurnOn()
+	phone.TurnOff()
+	phone.TurnOff()
+	// Output:
+	// android
+	// Turning phone on
+	// factoryMethod: phone is already on
+	// Putting phone to sleep
+	// Waking phone up
+	// Turning phone off
+}
+
+func TestPowerCycle(t *testing.T) {
+	phone := NewSamsung()
+	steps := []struct {
+		call func() error
+		want Status
+		err  error
+	}{
+		{phone.TurnOn, StatusOn, nil},
+		{phone.TurnOn, StatusOn, ErrAlreadyOn},
+		{phone.TurnOff, StatusOff, nil},
+		{phone.TurnOff, StatusOff, ErrAlreadyOff},
+		{phone.TurnOn, StatusOn, nil},
+	}
+	for i, step := range steps {
+		if err := step.call(); !errors.Is(err, step.err) {
+			t.Fatalf("step %d = %v, want %v", i, err, step.err)
+		}
+		if got := phone.GetStatus(); got != step.want {
+			t.Fatalf("after step %d the phone is %s, want %s", i, got, step.want)
+		}
+	}
+}
+
+func TestStatusString(t *testing.T) {
+	for status, want := range map[Status]string{
+		StatusOff:      "off",
+		StatusOn:       "on",
+		StatusSleeping: "sleeping",
+		Status(99):     "Status(unknown)",
+	} {
+		if status.String() != want {
+			t.Fatalf("%d.String() = %q, want %q", int(status), status.String(), want)
+		}
+	}
+}
+
+func TestWithOutput(t *testing.T) {
+	var out bytes.Buffer
+	phone := NewAndroid(WithOutput(&out))
+	steps := []struct {
+		call func() error
+		err  error
+	}{
+		{phone.TurnOn, nil},
+		{phone.TurnOn, ErrAlreadyOn},
+		{phone.TurnOff, nil},
+		{phone.TurnOff, ErrAlreadyOff},
+	}
+	for i, step := range steps {
+		if err := step.call(); !errors.Is(err, step.err) {
+			t.Fatalf("step %d = %v, want %v", i, err, step.err)
+		}
+	}
+	// only the calls that changed anything print
+	if got, want := out.String(), "Turning phone on\nTurning phone off\n"; got != want {
+		t.Fatalf("printed %q, want %q", got, want)
+	}
+}
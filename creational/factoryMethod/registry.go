@@ -0,0 +1,139 @@
+package factoryMethod
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/Antonious-Stewart/15-Most-Common-Design-Patterns/creational/factory"
+)
+
+var (
+	ErrUnknownOS   = errors.New("factoryMethod: unknown os")
+	ErrEmptyOS     = errors.New("factoryMethod: os name is empty")
+	ErrDuplicateOS = errors.New("factoryMethod: os already registered")
+	ErrNilProduct  = errors.New("factoryMethod: nil product constructor")
+	ErrNoOptions   = errors.New("factoryMethod: product takes no options")
+)
+
+// Registry maps os names to the constructors making their phones. It is
+// safe to use from several goroutines.
+type Registry struct {
+	products factory.Factory[IPhone]
+}
+
+// NewRegistry returns a registry of its own holding the package's
+// products, such as for a test that registers more without touching the
+// DefaultRegistry.
+func NewRegistry() *Registry {
+	r := &Registry{}
+	r.Register("android", func() IPhone { return NewAndroid() })
+	r.Register("google", func() IPhone { return NewGoogle() })
+	r.Register("ios", func() IPhone { return NewApple() })
+	r.Register("samsung", func() IPhone { return NewSamsung() })
+	return r
+}
+
+// defaults holds the DefaultRegistry once made.
+var defaults = new(lazyRegistry)
+
+type lazyRegistry struct {
+	once     sync.Once
+	registry *Registry
+}
+
+// DefaultRegistry is the registry the package-level Register, Create and
+// NewPhone use, made on first use.
+func DefaultRegistry() *Registry {
+	d := defaults
+	d.once.Do(func() { d.registry = NewRegistry() })
+	return d.registry
+}
+
+// normalize is how os names are compared: in any case, with surrounding
+// spaces ignored.
+func normalize(os string) string {
+	return strings.ToLower(strings.TrimSpace(os))
+}
+
+// Register makes ctor the product for os, so NewPhone and Create can make
+// phones the package doesn't know about. It fails with ErrEmptyOS for a
+// blank name, ErrNilProduct for a nil ctor and ErrDuplicateOS for a name
+// already registered.
+func (r *Registry) Register(os string, ctor func() IPhone) error {
+	name := normalize(os)
+	if name == "" {
+		return ErrEmptyOS
+	}
+	if ctor == nil {
+		return fmt.Errorf("%w for %q", ErrNilProduct, name)
+	}
+	err := r.products.Register(name, func() (IPhone, error) { return ctor(), nil })
+	if errors.Is(err, factory.ErrDuplicate) {
+		return fmt.Errorf("%w: %q", ErrDuplicateOS, name)
+	}
+	return err
+}
+
+// Unregister removes the product for os, reporting whether there was one.
+func (r *Registry) Unregister(os string) bool {
+	return r.products.Unregister(normalize(os))
+}
+
+// Registered lists the registered os names in order.
+func (r *Registry) Registered() []string {
+	return r.products.Names()
+}
+
+// Create is NewPhone without options.
+func (r *Registry) Create(os string) (IPhone, error) {
+	return r.NewPhone(os)
+}
+
+// NewPhone is the factory method: it makes the phone registered for os, so
+// callers need not know the concrete constructors. An os nobody registered
+// fails with ErrUnknownOS. Options work on any product embedding Phone;
+// others fail with ErrNoOptions when given some.
+func (r *Registry) NewPhone(os string, opts ...PhoneOption) (IPhone, error) {
+	product, err := r.products.Create(normalize(os))
+	if errors.Is(err, factory.ErrUnknown) {
+		return nil, fmt.Errorf("%w %q", ErrUnknownOS, os)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(opts) == 0 {
+		return product, nil
+	}
+	embeds, ok := product.(interface{ phone() *Phone })
+	if !ok {
+		return nil, fmt.Errorf("%w: %T", ErrNoOptions, product)
+	}
+	if err := embeds.phone().setup(product, opts); err != nil {
+		return nil, err
+	}
+	return product, nil
+}
+
+// Register, Unregister, Registered, Create and NewPhone use the
+// DefaultRegistry.
+func Register(os string, ctor func() IPhone) error {
+	return DefaultRegistry().Register(os, ctor)
+}
+
+func Unregister(os string) bool {
+	return DefaultRegistry().Unregister(os)
+}
+
+func Registered() []string {
+	return DefaultRegistry().Registered()
+}
+
+func Create(os string) (IPhone, error) {
+	return DefaultRegistry().Create(os)
+}
+
+func NewPhone(os string, opts ...PhoneOption) (IPhone, error) {
+	return DefaultRegistry().NewPhone(os, opts...)
+}
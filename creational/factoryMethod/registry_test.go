@@ -0,0 +1,280 @@
+package factoryMethod
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/Antonious-Stewart/15-Most-Common-Design-Patterns/creational/factory"
+)
+
+// builtins are the products registered by the package itself.
+var builtins = []string{"android", "google", "ios", "samsung"}
+
+func TestNewPhone(t *testing.T) {
+	tests := []struct {
+		os, want string
+	}{
+		{"android", "android"},
+		{"google", "google"},
+		{"ios", "ios"},
+		{"samsung", "samsung"},
+		{"iOS", "ios"},
+		{"Samsung ", "samsung"},
+		{"Android", "android"},
+		{"GOOGLE", "google"},
+		{"  android\n", "android"},
+		{"\tGoogle ", "google"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.os, func(t *testing.T) {
+			phone, err := NewPhone(tt.os)
+			if err != nil {
+				t.Fatalf("NewPhone(%q) = %v", tt.os, err)
+			}
+			if phone.GetOS() != tt.want || phone.BatteryLevel() != fullBattery {
+				t.Fatalf("NewPhone(%q) made a %s phone at %d%%, want a new %s phone", tt.os, phone.GetOS(), phone.BatteryLevel(), tt.want)
+			}
+		})
+	}
+}
+
+func TestNewPhoneTypes(t *testing.T) {
+	if phone, _ := NewPhone("android"); !isType[*Android](phone) {
+		t.Fatalf("NewPhone(android) = %T, want *Android", phone)
+	}
+	if phone, _ := NewPhone("google"); !isType[*Google](phone) {
+		t.Fatalf("NewPhone(google) = %T, want *Google", phone)
+	}
+	if phone, _ := NewPhone("ios"); !isType[*Apple](phone) {
+		t.Fatalf("NewPhone(ios) = %T, want *Apple", phone)
+	}
+	if phone, _ := NewPhone("samsung"); !isType[*Samsung](phone) {
+		t.Fatalf("NewPhone(samsung) = %T, want *Samsung", phone)
+	}
+	rec := &recordingPublisher{}
+	phone, _ := NewPhone("google", WithPublisher(rec))
+	phone.TurnOn()
+	if len(rec.events) != 1 || rec.events[0].Phone != phone {
+		t.Fatalf("published %+v, want the options applied", rec.events)
+	}
+}
+
+func isType[T IPhone](phone IPhone) bool {
+	_, ok := phone.(T)
+	return ok
+}
+
+func TestNewPhoneUnknownOS(t *testing.T) {
+	for _, os := range []string{"symbian", "", "android 2"} {
+		phone, err := NewPhone(os)
+		if !errors.Is(err, ErrUnknownOS) || phone != nil {
+			t.Fatalf("NewPhone(%q) = %v, %v, want ErrUnknownOS", os, phone, err)
+		}
+	}
+	if _, err := NewPhone("symbian"); err.Error() != `factoryMethod: unknown os "symbian"` {
+		t.Fatalf("error = %q", err)
+	}
+}
+
+// Nokia is a product from outside the package's own set, built on Phone.
+type Nokia struct {
+	Phone
+}
+
+func newNokia() IPhone {
+	n := &Nokia{Phone: Phone{os: "nokia", state: offState, battery: fullBattery}}
+	n.setup(n, nil)
+	return n
+}
+
+// brick is a product with no Phone inside.
+type brick struct {
+	IPhone
+}
+
+func register(t *testing.T, r *Registry, os string, ctor func() IPhone) {
+	t.Helper()
+	if err := r.Register(os, ctor); err != nil {
+		t.Fatalf("Register(%q) = %v", os, err)
+	}
+}
+
+func TestRegister(t *testing.T) {
+	r := NewRegistry()
+	register(t, r, " Nokia", newNokia)
+	if got := r.Registered(); !slices.Equal(got, []string{"android", "google", "ios", "nokia", "samsung"}) {
+		t.Fatalf("Registered() = %q", got)
+	}
+	phone, err := r.Create("NOKIA")
+	if err != nil || !isType[*Nokia](phone) || phone.GetOS() != "nokia" {
+		t.Fatalf("Create(NOKIA) = %v, %v, want a Nokia", phone, err)
+	}
+
+	// options reach the Phone inside the product
+	rec := &recordingPublisher{}
+	phone, err = r.NewPhone("nokia", WithPublisher(rec))
+	if err != nil {
+		t.Fatal(err)
+	}
+	phone.TurnOn()
+	if len(rec.events) != 1 || rec.events[0].Phone != phone {
+		t.Fatalf("published %+v, want the Nokia's own event", rec.events)
+	}
+
+	register(t, r, "brick", func() IPhone { return brick{} })
+	if _, err := r.NewPhone("brick", WithPublisher(rec)); !errors.Is(err, ErrNoOptions) {
+		t.Fatalf("NewPhone(brick, opts) = %v, want %v", err, ErrNoOptions)
+	}
+	if _, err := r.Create("brick"); err != nil {
+		t.Fatalf("Create(brick) = %v", err)
+	}
+
+	// none of it reached the default registry
+	if got := Registered(); !slices.Equal(got, builtins) {
+		t.Fatalf("default Registered() = %q, want %q", got, builtins)
+	}
+	if _, err := Create("nokia"); !errors.Is(err, ErrUnknownOS) {
+		t.Fatalf("default Create(nokia) = %v, want %v", err, ErrUnknownOS)
+	}
+}
+
+func TestRegisterDefault(t *testing.T) {
+	if err := Register("nokia", newNokia); err != nil {
+		t.Fatal(err)
+	}
+	defer Unregister("nokia")
+	if phone, err := Create("nokia"); err != nil || !isType[*Nokia](phone) {
+		t.Fatalf("Create(nokia) = %v, %v, want a Nokia", phone, err)
+	}
+	if _, err := DefaultRegistry().Create("nokia"); err != nil {
+		t.Fatalf("DefaultRegistry().Create(nokia) = %v", err)
+	}
+}
+
+func TestRegisterRejects(t *testing.T) {
+	tests := []struct {
+		name string
+		os   string
+		ctor func() IPhone
+		want error
+	}{
+		{"duplicate", "Android", newNokia, ErrDuplicateOS},
+		{"empty", "", newNokia, ErrEmptyOS},
+		{"blank", "  ", newNokia, ErrEmptyOS},
+		{"nil", "nokia", nil, ErrNilProduct},
+	}
+	r := NewRegistry()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := r.Register(tt.os, tt.ctor); !errors.Is(err, tt.want) {
+				t.Fatalf("Register(%q) = %v, want %v", tt.os, err, tt.want)
+			}
+		})
+	}
+	if phone, _ := r.Create("android"); !isType[*Android](phone) {
+		t.Fatalf("a rejected Register replaced android: Create() = %T", phone)
+	}
+	if got := r.Registered(); !slices.Equal(got, builtins) {
+		t.Fatalf("Registered() = %q after rejected calls", got)
+	}
+}
+
+func TestUnregister(t *testing.T) {
+	r := NewRegistry()
+	register(t, r, "nokia", newNokia)
+	if !r.Unregister("Nokia ") {
+		t.Fatal("Unregister(nokia) = false")
+	}
+	if r.Unregister("nokia") {
+		t.Fatal("second Unregister(nokia) = true")
+	}
+	if _, err := r.Create("nokia"); !errors.Is(err, ErrUnknownOS) {
+		t.Fatalf("Create() after Unregister = %v, want %v", err, ErrUnknownOS)
+	}
+	// the name is free again
+	register(t, r, "nokia", newNokia)
+}
+
+func TestRegistryConcurrent(t *testing.T) {
+	r := NewRegistry()
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			os := fmt.Sprintf("custom-%d", i)
+			for range 50 {
+				r.Register(os, newNokia)
+				if _, err := r.Create("android"); err != nil {
+					t.Error(err)
+					return
+				}
+				r.Create(os)
+				r.Registered()
+				r.Unregister(os)
+			}
+		}()
+	}
+	wg.Wait()
+	if got := r.Registered(); !slices.Equal(got, builtins) {
+		t.Fatalf("Registered() = %q after the goroutines finished", got)
+	}
+}
+
+func TestDefaultRegistryLazyInit(t *testing.T) {
+	before := defaults
+	defaults = new(lazyRegistry)
+	defer func() { defaults = before }()
+
+	var wg sync.WaitGroup
+	registries := make([]*Registry, 50)
+	start := make(chan struct{})
+	for i := range registries {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			phone, err := Create("android")
+			if err != nil || !isType[*Android](phone) {
+				t.Errorf("Create(android) = %v, %v", phone, err)
+			}
+			registries[i] = DefaultRegistry()
+		}()
+	}
+	close(start)
+	wg.Wait()
+	for _, r := range registries {
+		if r != registries[0] {
+			t.Fatal("first uses made more than one DefaultRegistry")
+		}
+	}
+	if got := Registered(); !slices.Equal(got, builtins) {
+		t.Fatalf("Registered() = %q, want each built-in once", got)
+	}
+}
+
+func TestFactoryOfPhones(t *testing.T) {
+	var phones factory.Factory[IPhone]
+	for _, os := range builtins {
+		if err := phones.Register(os, func() (IPhone, error) { return NewPhoneWithOptions(os) }); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, os := range builtins {
+		if phone := phones.MustCreate(os); phone.GetOS() != os {
+			t.Fatalf("MustCreate(%q) made a %s phone", os, phone.GetOS())
+		}
+	}
+	if err := phones.Register("google", func() (IPhone, error) { return NewGoogle(), nil }); !errors.Is(err, factory.ErrDuplicate) {
+		t.Fatalf("Register(google) again = %v, want %v", err, factory.ErrDuplicate)
+	}
+	if _, err := phones.Create("symbian"); !errors.Is(err, factory.ErrUnknown) {
+		t.Fatalf("Create(symbian) = %v, want %v", err, factory.ErrUnknown)
+	}
+	if got := phones.Names(); !slices.Equal(got, builtins) {
+		t.Fatalf("Names() = %q", got)
+	}
+}
@@ -0,0 +1,67 @@
+package factoryMethod
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+var (
+	ErrInvalidPhone = errors.New("factoryMethod: invalid phone")
+	ErrInvalidApps  = errors.New("factoryMethod: apps out of order or repeated")
+)
+
+// Reset puts the phone back to its product's defaults: off, with a full
+// battery, the default model, only the pre-installed apps and no network. A
+// phone that is on or asleep is turned off first, as TurnOff would. Where it
+// prints to and publishes events to are kept. Only the package's own
+// products have defaults to go back to; others fail with ErrUnknownOS.
+func (p *Phone) Reset() error {
+	_, defaults, ok := builtin(p.os)
+	if !ok {
+		return fmt.Errorf("%w %q: no factory defaults to reset to", ErrUnknownOS, p.os)
+	}
+	if p.power() != offState {
+		if err := p.TurnOff(); err != nil {
+			return err
+		}
+	}
+	p.state = defaults.state
+	p.model = defaults.model
+	p.battery = defaults.battery
+	p.apps = defaults.apps
+	p.network = ""
+	return nil
+}
+
+// Validate checks that the phone makes sense, such as one built by hand or
+// read from somewhere: that its status is a known one, its battery within
+// range and not empty while on, that it is on no network while off, and its
+// apps named, sorted and each installed once. The problems are reported
+// wrapping ErrInvalidPhone.
+func (p *Phone) Validate() error {
+	problems := make([]error, 0)
+	if p.state != nil && !slices.Contains([]powerState{offState, onState, sleepState}, p.state) {
+		problems = append(problems, fmt.Errorf("%w: %T", ErrBadStatus, p.state))
+	}
+	if p.battery < 0 || p.battery > fullBattery {
+		problems = append(problems, fmt.Errorf("%w: %d", ErrBadBattery, p.battery))
+	} else if p.battery == 0 && p.power() != offState {
+		problems = append(problems, fmt.Errorf("%w: a phone at 0%% can't be %s", ErrBatteryDead, p.power().status()))
+	}
+	if p.network != "" && p.power() == offState {
+		problems = append(problems, fmt.Errorf("%w: can't be on %q", ErrPhoneOff, p.network))
+	}
+	for i, app := range p.apps {
+		if strings.TrimSpace(app) == "" {
+			problems = append(problems, ErrEmptyApp)
+		} else if i > 0 && p.apps[i-1] >= app {
+			problems = append(problems, fmt.Errorf("%w at %q", ErrInvalidApps, app))
+		}
+	}
+	if len(problems) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%w: %w", ErrInvalidPhone, errors.Join(problems...))
+}
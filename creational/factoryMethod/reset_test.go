@@ -0,0 +1,109 @@
+package factoryMethod
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/Antonious-Stewart/15-Most-Common-Design-Patterns/internal/output"
+)
+
+func TestReset(t *testing.T) {
+	for _, os := range builtins {
+		t.Run(os, func(t *testing.T) {
+			log := output.NewRecorder()
+			rec := &recordingPublisher{}
+			phone, _ := NewPhoneWithOptions(os, WithSink(log), WithPublisher(rec), WithModel("Custom"), WithBattery(90))
+			phone.TurnOn()
+			phone.InstallApp("Maps")
+			phone.UninstallApp(phone.InstalledApps()[0])
+			phone.Elapse(4)
+			phone.Sleep()
+
+			if err := phone.Reset(); err != nil {
+				t.Fatal(err)
+			}
+			if lines := log.Lines(); lines[len(lines)-1] != "Turning phone off" {
+				t.Fatalf("printed %q, want the phone turned off first", lines)
+			}
+			if last := rec.events[len(rec.events)-1]; last.Kind != StatusChanged || last.Status != StatusOff {
+				t.Fatalf("last event %+v, want StatusChanged to off", last)
+			}
+			fresh, _ := NewPhoneWithOptions(os, WithSink(log), WithPublisher(rec))
+			if !reflect.DeepEqual(phone, fresh) {
+				t.Fatalf("reset phone = %+v, want %+v", phone, fresh)
+			}
+			if err := phone.(interface{ Validate() error }).Validate(); err != nil {
+				t.Fatalf("Validate() after Reset = %v", err)
+			}
+		})
+	}
+}
+
+func TestResetOffPhone(t *testing.T) {
+	log := output.NewRecorder()
+	phone := NewAndroid(WithSink(log), WithBattery(10))
+	if err := phone.Reset(); err != nil {
+		t.Fatal(err)
+	}
+	if len(log.Lines()) != 0 || phone.BatteryLevel() != fullBattery {
+		t.Fatalf("printed %q at %d%%, want a quiet reset to full", log.Lines(), phone.BatteryLevel())
+	}
+	// the reset phone's apps are its own
+	phone.TurnOn()
+	phone.InstallApp("Maps")
+	if got := NewAndroid().InstalledApps(); slices.Contains(got, "Maps") {
+		t.Fatal("a reset phone shares its apps with new ones")
+	}
+}
+
+func TestResetUnknownProduct(t *testing.T) {
+	phone := newNokia()
+	if err := phone.Reset(); !errors.Is(err, ErrUnknownOS) {
+		t.Fatalf("Reset() of a Nokia = %v, want %v", err, ErrUnknownOS)
+	}
+}
+
+// brokenState is a power state the package doesn't know.
+type brokenState struct{ poweredOn }
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name  string
+		phone Phone
+		want  []error // nil for a valid phone
+	}{
+		{"zero value", Phone{}, nil},
+		{"built", *NewGoogle().(*Google).phone(), nil},
+		{"hand built", Phone{os: "android", state: sleepState, battery: 50, apps: []string{"A", "B"}, network: "home"}, nil},
+		{"battery over full", Phone{battery: 130}, []error{ErrBadBattery}},
+		{"negative battery", Phone{battery: -4}, []error{ErrBadBattery}},
+		{"dead and on", Phone{state: onState}, []error{ErrBatteryDead}},
+		{"unknown state", Phone{state: &brokenState{}, battery: 50}, []error{ErrBadStatus}},
+		{"on a network while off", Phone{battery: 50, network: "home"}, []error{ErrPhoneOff}},
+		{"empty app", Phone{apps: []string{""}}, []error{ErrEmptyApp}},
+		{"unsorted apps", Phone{apps: []string{"B", "A"}}, []error{ErrInvalidApps}},
+		{"repeated app", Phone{apps: []string{"A", "A"}}, []error{ErrInvalidApps}},
+		{"several", Phone{state: sleepState, battery: 0, apps: []string{" "}}, []error{ErrBatteryDead, ErrEmptyApp}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.phone.Validate()
+			if tt.want == nil {
+				if err != nil {
+					t.Fatalf("Validate() = %v, want nil", err)
+				}
+				return
+			}
+			if !errors.Is(err, ErrInvalidPhone) {
+				t.Fatalf("Validate() = %v, want %v", err, ErrInvalidPhone)
+			}
+			for _, want := range tt.want {
+				if !errors.Is(err, want) {
+					t.Fatalf("Validate() = %v, want %v", err, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package factoryMethod
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Antonious-Stewart/15-Most-Common-Design-Patterns/internal/output"
+)
+
+func TestPhoneString(t *testing.T) {
+	tests := []struct {
+		os, before, after string
+	}{
+		{"android", "Android phone (os=android, status=off, battery=100%)", "Android phone (os=android, status=on, battery=70%)"},
+		{"google", "Pixel phone (os=google, status=off, battery=100%)", "Pixel phone (os=google, status=on, battery=70%)"},
+		{"ios", "iPhone phone (os=ios, status=off, battery=100%)", "iPhone phone (os=ios, status=on, battery=70%)"},
+		{"samsung", "Galaxy phone (os=samsung, status=off, battery=100%)", "Galaxy phone (os=samsung, status=on, battery=70%)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.os, func(t *testing.T) {
+			phone, err := NewPhone(tt.os, WithSink(output.NewRecorder()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := fmt.Sprint(phone); got != tt.before {
+				t.Fatalf("new phone prints %q, want %q", got, tt.before)
+			}
+			phone.TurnOn()
+			if err := phone.Elapse(3); err != nil {
+				t.Fatal(err)
+			}
+			if got := fmt.Sprint(phone); got != tt.after {
+				t.Fatalf("phone in use prints %q, want %q", got, tt.after)
+			}
+		})
+	}
+	var zero Phone
+	if got, want := zero.String(), "Phone (os=, status=off, battery=0%)"; got != want {
+		t.Fatalf("zero-value phone prints %q, want %q", got, want)
+	}
+}
+
+func TestGetSpec(t *testing.T) {
+	phone := NewGoogle(WithModel("Pixel 9"), WithBattery(60), WithSink(output.NewRecorder()))
+	want := Spec{OS: "google", Model: "Pixel 9", Status: StatusOff, Battery: 60}
+	if got := phone.GetSpec(); got != want {
+		t.Fatalf("GetSpec() = %+v, want %+v", got, want)
+	}
+	phone.TurnOn()
+	phone.Sleep()
+	want.Status = StatusSleeping
+	if got := phone.GetSpec(); got != want {
+		t.Fatalf("GetSpec() after sleeping = %+v, want %+v", got, want)
+	}
+}
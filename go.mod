@@ -0,0 +1,3 @@
+module github.com/Antonious-Stewart/15-Most-Common-Design-Patterns
+
+go 1.23
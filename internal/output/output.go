@@ -0,0 +1,141 @@
+// Package output is where the examples print to. Printing through a Sink
+// instead of straight to stdout lets tests and the patterns command capture
+// what an example says.
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+)
+
+// Sink takes the lines an example prints. Printf and Println format like
+// their fmt counterparts.
+type Sink interface {
+	Printf(format string, args ...any)
+	Println(args ...any)
+}
+
+// Stdout prints to os.Stdout, exactly as fmt.Printf and fmt.Println do. It
+// is what the examples use unless told otherwise.
+var Stdout Sink = stdout{}
+
+type stdout struct{}
+
+func (stdout) Printf(format string, args ...any) {
+	fmt.Printf(format, args...)
+}
+
+func (stdout) Println(args ...any) {
+	fmt.Println(args...)
+}
+
+// Writer prints to w. Write errors are dropped, as with fmt.Println. It is
+// safe for concurrent use: every Printf and Println reaches w in one piece.
+type Writer struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{w: w}
+}
+
+func (s *Writer) Printf(format string, args ...any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	fmt.Fprintf(s.w, format, args...)
+}
+
+func (s *Writer) Println(args ...any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	fmt.Fprintln(s.w, args...)
+}
+
+// Recorder keeps everything printed to it, for tests. It is safe for
+// concurrent use.
+type Recorder struct {
+	mu  sync.Mutex
+	out strings.Builder
+}
+
+func NewRecorder() *Recorder {
+	return &Recorder{}
+}
+
+func (r *Recorder) Printf(format string, args ...any) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	fmt.Fprintf(&r.out, format, args...)
+}
+
+func (r *Recorder) Println(args ...any) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	fmt.Fprintln(&r.out, args...)
+}
+
+// String returns everything printed so far.
+func (r *Recorder) String() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.out.String()
+}
+
+// Lines returns the lines printed so far without their newlines. A line
+// still waiting for its newline is included.
+func (r *Recorder) Lines() []string {
+	out := strings.TrimSuffix(r.String(), "\n")
+	if out == "" {
+		return make([]string, 0)
+	}
+	return strings.Split(out, "\n")
+}
+
+// Prefixed puts prefix in front of every line printed through it before
+// passing it on to next. Lines may be printed in pieces; the prefix goes in
+// front of each line once.
+type Prefixed struct {
+	mu     sync.Mutex
+	prefix string
+	next   Sink
+	midway bool
+}
+
+func NewPrefixed(prefix string, next Sink) *Prefixed {
+	return &Prefixed{
+		prefix: prefix,
+		next:   next,
+	}
+}
+
+func (p *Prefixed) Printf(format string, args ...any) {
+	p.print(fmt.Sprintf(format, args...))
+}
+
+func (p *Prefixed) Println(args ...any) {
+	p.print(fmt.Sprintln(args...))
+}
+
+func (p *Prefixed) print(text string) {
+	if text == "" {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var b strings.Builder
+	for _, line := range strings.SplitAfter(text, "\n") {
+		if line == "" {
+			continue
+		}
+		if !p.midway {
+			b.WriteString(p.prefix)
+		}
+		b.WriteString(line)
+		p.midway = !strings.HasSuffix(line, "\n")
+	}
+	// %s so a % in the text isn't taken for a verb
+	p.next.Printf("%s", b.String())
+}
@@ -0,0 +1,122 @@
+package output
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestStdoutMatchesFmt(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	saved := os.Stdout
+	os.Stdout = w
+	Stdout.Printf("%d items\n", 3)
+	Stdout.Println("Dispensing", "item", 1)
+	os.Stdout = saved
+	w.Close()
+	got, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "3 items\nDispensing item 1\n"; string(got) != want {
+		t.Fatalf("Stdout printed %q, want %q", got, want)
+	}
+}
+
+func TestWriter(t *testing.T) {
+	var buf bytes.Buffer
+	s := NewWriter(&buf)
+	s.Printf("%s=%d ", "a", 1)
+	s.Println("done")
+	if want := "a=1 done\n"; buf.String() != want {
+		t.Fatalf("Writer printed %q, want %q", buf.String(), want)
+	}
+}
+
+func TestRecorderLines(t *testing.T) {
+	r := NewRecorder()
+	if got := r.Lines(); len(got) != 0 {
+		t.Fatalf("Lines() = %q, want none", got)
+	}
+	r.Println("one")
+	r.Printf("two\nthr")
+	r.Printf("ee\n")
+	r.Printf("four")
+	if want := []string{"one", "two", "three", "four"}; !reflect.DeepEqual(r.Lines(), want) {
+		t.Fatalf("Lines() = %q, want %q", r.Lines(), want)
+	}
+	if want := "one\ntwo\nthree\nfour"; r.String() != want {
+		t.Fatalf("String() = %q, want %q", r.String(), want)
+	}
+}
+
+func TestRecorderConcurrentUse(t *testing.T) {
+	r := NewRecorder()
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 100 {
+				r.Println("line")
+			}
+		}()
+	}
+	wg.Wait()
+	if got := len(r.Lines()); got != 800 {
+		t.Fatalf("recorded %d lines, want 800", got)
+	}
+}
+
+func TestPrefixed(t *testing.T) {
+	r := NewRecorder()
+	p := NewPrefixed("[a] ", r)
+	p.Println("one")
+	p.Printf("two\nthr")
+	p.Printf("ee\n")
+	p.Printf("")
+	p.Printf("100%%\n")
+	want := "[a] one\n[a] two\n[a] three\n[a] 100%\n"
+	if r.String() != want {
+		t.Fatalf("Prefixed printed %q, want %q", r.String(), want)
+	}
+}
+
+func TestPrefixedNests(t *testing.T) {
+	r := NewRecorder()
+	p := NewPrefixed("1st > ", NewPrefixed("log: ", r))
+	p.Println("Briefing")
+	if want := "log: 1st > Briefing\n"; r.String() != want {
+		t.Fatalf("nested Prefixed printed %q, want %q", r.String(), want)
+	}
+}
+
+func TestWriterConcurrentLines(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	var wg sync.WaitGroup
+	for i := range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			w.Println("line", i)
+		}()
+	}
+	wg.Wait()
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != 20 {
+		t.Fatalf("got %d lines, want 20", len(lines))
+	}
+	for _, line := range lines {
+		if !strings.HasPrefix(line, "line ") {
+			t.Fatalf("line %q was interleaved", line)
+		}
+	}
+}
@@ -0,0 +1,187 @@
+package composite
+
+import (
+	"errors"
+	"slices"
+	"strings"
+)
+
+var (
+	ErrEmptyOrders = errors.New("composite: no orders given")
+	ErrUnavailable = errors.New("composite: soldier unavailable")
+)
+
+// BriefError is a soldier that couldn't act on its orders. Path lists the
+// unit names from the unit that was briefed down to the soldier.
+type BriefError struct {
+	Path []string
+	Err  error
+}
+
+func (e *BriefError) Error() string {
+	return strings.Join(e.Path, " > ") + ": " + e.Err.Error()
+}
+
+func (e *BriefError) Unwrap() error {
+	return e.Err
+}
+
+// BriefErrors lists every soldier that failed during one Brief, in the
+// order they were briefed.
+type BriefErrors []*BriefError
+
+func (e BriefErrors) Error() string {
+	lines := make([]string, 0, len(e))
+	for _, err := range e {
+		lines = append(lines, err.Error())
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (e BriefErrors) Unwrap() []error {
+	errs := make([]error, 0, len(e))
+	for _, err := range e {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+// briefChildren briefs every child, carrying on past the ones that fail,
+// and returns the failures with u's name put in front of their paths.
+func (u *unit) briefChildren(orders string) error {
+	children := u.snapshot()
+	errs := make([]error, len(children))
+	for i, child := range children {
+		errs[i] = child.Brief(orders)
+	}
+	return u.collect(children, errs)
+}
+
+// collect turns what children's briefings returned into BriefErrors under
+// u, or nil if they all succeeded.
+func (u *unit) collect(children []Soldier, errs []error) error {
+	failed := make(BriefErrors, 0)
+	for i, err := range errs {
+		if err != nil {
+			failed = append(failed, under([]string{u.name}, children[i], err)...)
+		}
+	}
+	if len(failed) == 0 {
+		return nil
+	}
+	return failed
+}
+
+// under puts path in front of the paths in err, which briefing s returned.
+func under(path []string, s Soldier, err error) BriefErrors {
+	switch err := err.(type) {
+	case BriefErrors:
+		failed := make(BriefErrors, 0, len(err))
+		for _, e := range err {
+			failed = append(failed, &BriefError{Path: slices.Concat(path, e.Path), Err: e.Err})
+		}
+		return failed
+	case *BriefError:
+		return BriefErrors{{Path: slices.Concat(path, err.Path), Err: err.Err}}
+	}
+	// a soldier from another package
+	full := slices.Clone(path)
+	if name := s.Name(); name != "" {
+		full = append(full, name)
+	}
+	return BriefErrors{{Path: full, Err: err}}
+}
+
+// BriefCount is Brief that also says how many soldiers the orders reached:
+// the enlisted soldiers below the unit, or the unit itself if it is one,
+// that were briefed without failing. A soldier from another package that
+// took the orders counts as its Count. Zero means the orders reached
+// nobody, such as when briefing a division with no soldiers in it, even
+// though its units still announced themselves.
+func (u *unit) BriefCount(orders string) (int, error) {
+	return briefCount(u.self, orders)
+}
+
+func briefCount(s Soldier, orders string) (int, error) {
+	c, ok := s.(container)
+	if !ok {
+		if err := s.Brief(orders); err != nil {
+			return 0, err
+		}
+		return s.Count(), nil
+	}
+	u := baseOf(c)
+	u.sink().Println(c.briefing())
+	children := u.snapshot()
+	errs := make([]error, len(children))
+	reached := 0
+	for i, child := range children {
+		n, err := briefCount(child, orders)
+		reached += n
+		errs[i] = err
+	}
+	return reached, u.collect(children, errs)
+}
+
+// BriefToDepth is Brief that stops maxDepth levels down: 0 is only the
+// unit, 1 its children too, and so on, and a negative maxDepth means no
+// limit. Units at the last level still announce themselves, but nothing
+// below them is visited.
+func (u *unit) BriefToDepth(orders string, maxDepth int) error {
+	return briefToDepth(u.self, orders, maxDepth)
+}
+
+func briefToDepth(s Soldier, orders string, levels int) error {
+	c, ok := s.(container)
+	if !ok {
+		return s.Brief(orders)
+	}
+	u := baseOf(c)
+	u.sink().Println(c.briefing())
+	if levels == 0 {
+		return nil
+	}
+	children := u.snapshot()
+	errs := make([]error, len(children))
+	for i, child := range children {
+		errs[i] = briefToDepth(child, orders, levels-1)
+	}
+	return u.collect(children, errs)
+}
+
+// BriefBreadthFirst is Brief going level by level rather than down one
+// branch at a time: every unit at one depth announces itself, and every
+// soldier there hears the orders, before anyone deeper does. Failures are
+// reported in that order too.
+func (u *unit) BriefBreadthFirst(orders string) error {
+	if _, ok := u.self.(container); !ok {
+		return u.self.Brief(orders)
+	}
+	type queued struct {
+		s     Soldier
+		above []string
+	}
+	queue := []queued{{s: u.self}}
+	failed := make(BriefErrors, 0)
+	for len(queue) > 0 {
+		next := queue[0]
+		queue = queue[1:]
+		c, ok := next.s.(container)
+		if !ok {
+			if err := next.s.Brief(orders); err != nil {
+				failed = append(failed, under(next.above, next.s, err)...)
+			}
+			continue
+		}
+		base := baseOf(c)
+		base.sink().Println(c.briefing())
+		above := append(slices.Clip(next.above), base.name)
+		for _, child := range base.snapshot() {
+			queue = append(queue, queued{child, above})
+		}
+	}
+	if len(failed) == 0 {
+		return nil
+	}
+	return failed
+}
@@ -0,0 +1,264 @@
+package composite
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/Antonious-Stewart/15-Most-Common-Design-Patterns/internal/output"
+)
+
+// briefTree is a division with brigades Alpha and Bravo, each with one
+// platoon and squad of two.
+func briefTree() (*Division, map[string]*Enlisted) {
+	soldiers := make(map[string]*Enlisted)
+	division := NewDivision("1st Division", WithSink(output.NewRecorder()))
+	for _, b := range []struct{ brigade, squad, first, second string }{
+		{"1st Brigade", "A", "Ortiz", "Kim"},
+		{"2nd Brigade", "B", "Lee", "Park"},
+	} {
+		squad := NewSquad(b.squad)
+		for _, name := range []string{b.first, b.second} {
+			soldiers[name] = NewEnlisted(name)
+			squad.Add(soldiers[name])
+		}
+		platoon := NewPlatoon("1st Platoon")
+		platoon.Add(squad)
+		brigade := NewBrigade(b.brigade)
+		brigade.Add(platoon)
+		division.Add(brigade)
+	}
+	return division, soldiers
+}
+
+func TestBriefHealthyTree(t *testing.T) {
+	division, _ := briefTree()
+	if err := division.Brief("Advance"); err != nil {
+		t.Fatalf("Brief() = %v, want nil", err)
+	}
+}
+
+func TestBriefCarriesOnPastFailures(t *testing.T) {
+	division, soldiers := briefTree()
+	log := output.NewRecorder()
+	division.out = log
+	soldiers["Ortiz"].SetAvailable(false)
+	soldiers["Park"].SetAvailable(false)
+
+	err := division.Brief("Advance")
+	var failed BriefErrors
+	if !errors.As(err, &failed) {
+		t.Fatalf("Brief() = %v, want BriefErrors", err)
+	}
+	want := "1st Division > 1st Brigade > 1st Platoon > A > Ortiz: composite: soldier unavailable\n" +
+		"1st Division > 2nd Brigade > 1st Platoon > B > Park: composite: soldier unavailable"
+	if err.Error() != want {
+		t.Fatalf("Brief() = %q, want %q", err, want)
+	}
+	if !errors.Is(err, ErrUnavailable) {
+		t.Fatal("errors.Is(err, ErrUnavailable) = false")
+	}
+	if got := failed[1].Path; !reflect.DeepEqual(got, []string{"1st Division", "2nd Brigade", "1st Platoon", "B", "Park"}) {
+		t.Fatalf("Path = %q", got)
+	}
+
+	// Kim and Lee still heard the orders, and every unit reported in
+	heard := 0
+	for _, line := range log.Lines() {
+		if line == "Advance" {
+			heard++
+		}
+	}
+	if heard != 2 || len(log.Lines()) != 2+2+2+2+1 {
+		t.Fatalf("sink got %q, want the two available soldiers and every unit", log.Lines())
+	}
+
+	// available again
+	soldiers["Ortiz"].SetAvailable(true)
+	soldiers["Park"].SetAvailable(true)
+	if err := division.Brief("Advance"); err != nil {
+		t.Fatalf("Brief() = %v after the soldiers returned", err)
+	}
+}
+
+func TestBriefEmptyOrders(t *testing.T) {
+	division, _ := briefTree()
+	err := division.Brief("")
+	var failed BriefErrors
+	if !errors.As(err, &failed) || len(failed) != 4 || !errors.Is(err, ErrEmptyOrders) {
+		t.Fatalf("Brief(\"\") = %v, want all four soldiers failing with ErrEmptyOrders", err)
+	}
+
+	enlisted := NewEnlisted("Jones", WithSink(output.NewRecorder()))
+	var single *BriefError
+	if err := enlisted.Brief(""); !errors.As(err, &single) || err.Error() != "Jones: composite: no orders given" {
+		t.Fatalf("Enlisted.Brief(\"\") = %v", err)
+	}
+}
+
+type failing struct {
+	stranger
+	err error
+}
+
+func (f failing) Brief(string) error {
+	return f.err
+}
+
+func (f failing) Name() string {
+	return "Ghost"
+}
+
+func TestBriefForeignSoldierError(t *testing.T) {
+	squad := NewSquad("A", WithSink(output.NewRecorder()))
+	lost := errors.New("radio silent")
+	squad.Add(failing{err: lost}, NewEnlisted("Kim"))
+	err := squad.Brief("Hold")
+	if !errors.Is(err, lost) || err.Error() != "A > Ghost: radio silent" {
+		t.Fatalf("Brief() = %v, want the foreign error under A > Ghost", err)
+	}
+}
+
+// twoLevels is platoon 1 with squads A (Ortiz, Kim) and B (Lee), every
+// soldier printing to log behind their own name.
+func twoLevels(log *output.Recorder) (*Platoon, map[string]*Enlisted) {
+	soldiers := make(map[string]*Enlisted)
+	enlist := func(name string) *Enlisted {
+		soldiers[name] = NewEnlisted(name, WithSink(output.NewPrefixed(name+": ", log)))
+		return soldiers[name]
+	}
+	a, b := NewSquad("A"), NewSquad("B")
+	a.Add(enlist("Ortiz"), enlist("Kim"))
+	b.Add(enlist("Lee"))
+	platoon := NewPlatoon("1", WithSink(log))
+	platoon.Add(a, b)
+	return platoon, soldiers
+}
+
+func TestBriefOrders(t *testing.T) {
+	tests := []struct {
+		name  string
+		brief func(*Platoon) error
+		want  []string
+	}{
+		{"depth first", func(p *Platoon) error { return p.Brief("Hold") }, []string{
+			"1: Briefing 2 Squads",
+			"A: Briefing 2 Enlistees", "Ortiz: Hold", "Kim: Hold",
+			"B: Briefing 1 Enlistees", "Lee: Hold",
+		}},
+		{"breadth first", func(p *Platoon) error { return p.BriefBreadthFirst("Hold") }, []string{
+			"1: Briefing 2 Squads",
+			"A: Briefing 2 Enlistees", "B: Briefing 1 Enlistees",
+			"Ortiz: Hold", "Kim: Hold", "Lee: Hold",
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			log := output.NewRecorder()
+			platoon, _ := twoLevels(log)
+			if err := tt.brief(platoon); err != nil {
+				t.Fatal(err)
+			}
+			if got := log.Lines(); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("briefing order = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBriefBreadthFirstErrors(t *testing.T) {
+	log := output.NewRecorder()
+	platoon, soldiers := twoLevels(log)
+	soldiers["Ortiz"].SetAvailable(false)
+	lost := errors.New("radio silent")
+	platoon.Add(failing{err: lost})
+
+	err := platoon.BriefBreadthFirst("Hold")
+	want := "1 > Ghost: radio silent\n1 > A > Ortiz: composite: soldier unavailable"
+	if !errors.Is(err, lost) || !errors.Is(err, ErrUnavailable) || err.Error() != want {
+		t.Fatalf("BriefBreadthFirst() = %q, want %q", err, want)
+	}
+	if got := len(log.Lines()); got != 5 {
+		t.Fatalf("sink got %q, want the three units and Kim and Lee", log.Lines())
+	}
+
+	jones := NewEnlisted("Jones", WithSink(log))
+	if err := jones.BriefBreadthFirst(""); !errors.Is(err, ErrEmptyOrders) {
+		t.Fatalf("Enlisted.BriefBreadthFirst(\"\") = %v, want %v", err, ErrEmptyOrders)
+	}
+}
+
+func TestBriefCountEmptyArmy(t *testing.T) {
+	log := output.NewRecorder()
+	division := NewDivision("1st", WithSink(log))
+	brigade := NewBrigade("Alpha")
+	platoon := NewPlatoon("1st Platoon")
+	platoon.Add(NewSquad("A"), NewSquad("B"))
+	brigade.Add(platoon)
+	division.Add(brigade, NewBrigade("Bravo"))
+
+	n, err := division.BriefCount("Advance")
+	if n != 0 || err != nil {
+		t.Fatalf("BriefCount() = %d, %v, want 0, nil", n, err)
+	}
+	if got := len(log.Lines()); got != 6 {
+		t.Fatalf("sink got %q, want every unit announcing itself", log.Lines())
+	}
+}
+
+func TestBriefCountMixedTree(t *testing.T) {
+	division, soldiers := briefTree()
+	soldiers["Park"].SetAvailable(false)
+	division.Find("A").Add(stranger{})
+	division.Find("1st Brigade").Add(NewPlatoon("empty"))
+
+	n, err := division.BriefCount("Advance")
+	if n != 4 {
+		t.Fatalf("BriefCount() reached %d, want Ortiz, Kim, Lee and the stranger", n)
+	}
+	if !errors.Is(err, ErrUnavailable) {
+		t.Fatalf("BriefCount() = %v, want Park unavailable", err)
+	}
+	for orders, want := range map[string]int{"Advance": 1, "": 0} {
+		if n, _ := soldiers["Kim"].BriefCount(orders); n != want {
+			t.Fatalf("Enlisted.BriefCount(%q) = %d, want %d", orders, n, want)
+		}
+	}
+}
+
+func TestBriefToDepth(t *testing.T) {
+	all := []string{
+		"1st Division: Briefing 2 Brigades",
+		"1st Brigade: Briefing 1 Platoons",
+		"1st Platoon: Briefing 1 Squads",
+		"A: Briefing 2 Enlistees", "Advance", // Kim is unavailable
+		"2nd Brigade: Briefing 1 Platoons",
+		"1st Platoon: Briefing 1 Squads",
+		"B: Briefing 2 Enlistees", "Advance", "Advance",
+	}
+	tests := []struct {
+		depth int
+		want  []string
+	}{
+		{0, all[:1]},
+		{1, []string{all[0], all[1], all[5]}},
+		{2, []string{all[0], all[1], all[2], all[5], all[6]}},
+		{3, []string{all[0], all[1], all[2], all[3], all[5], all[6], all[7]}},
+		{4, all},
+		{-1, all},
+	}
+	for _, tt := range tests {
+		division, soldiers := briefTree()
+		soldiers["Kim"].SetAvailable(false)
+		log := output.NewRecorder()
+		division.out = log
+		err := division.BriefToDepth("Advance", tt.depth)
+		if got := log.Lines(); !reflect.DeepEqual(got, tt.want) {
+			t.Fatalf("BriefToDepth(%d) printed %q, want %q", tt.depth, got, tt.want)
+		}
+		// Kim is four levels down and only fails once reached
+		if reached := tt.depth < 0 || tt.depth >= 4; errors.Is(err, ErrUnavailable) != reached {
+			t.Fatalf("BriefToDepth(%d) = %v", tt.depth, err)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package composite
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrNoDivision   = errors.New("composite: builder has no division yet")
+	ErrTwoDivisions = errors.New("composite: builder already has a division")
+	ErrAboveRoot    = errors.New("composite: builder is already at the division")
+)
+
+// ArmyBuilder puts a tree together top down:
+//
+//	NewArmyBuilder().Division("1st").Brigade("Alpha").Platoon("1").
+//		Squad("A").Enlisted("Jones", "Smith").Up().
+//		Squad("B").Enlisted("Lee").Build()
+//
+// Division, Brigade, Platoon and Squad add a unit below the current one and
+// move down into it; Enlisted adds soldiers and stays put; Up moves back to
+// the parent. The first call that doesn't fit, such as a Squad straight
+// below the Division, is remembered, the calls after it do nothing, and
+// Build returns the error.
+type ArmyBuilder struct {
+	root *Division
+	at   Soldier
+	err  error
+}
+
+func NewArmyBuilder() *ArmyBuilder {
+	return &ArmyBuilder{}
+}
+
+func (b *ArmyBuilder) Division(name string) *ArmyBuilder {
+	if b.err == nil && b.root != nil {
+		b.err = fmt.Errorf("composite: Division(%q): %w", name, ErrTwoDivisions)
+	}
+	if b.err == nil {
+		b.root = NewDivision(name)
+		b.at = b.root
+	}
+	return b
+}
+
+func (b *ArmyBuilder) Brigade(name string) *ArmyBuilder {
+	return b.push("Brigade", NewBrigade(name))
+}
+
+func (b *ArmyBuilder) Platoon(name string) *ArmyBuilder {
+	return b.push("Platoon", NewPlatoon(name))
+}
+
+func (b *ArmyBuilder) Squad(name string) *ArmyBuilder {
+	return b.push("Squad", NewSquad(name))
+}
+
+// Enlisted adds a soldier for each name to the current unit, which has to
+// be a squad.
+func (b *ArmyBuilder) Enlisted(names ...string) *ArmyBuilder {
+	for _, name := range names {
+		b.attach("Enlisted", NewEnlisted(name))
+	}
+	return b
+}
+
+// Up moves back to the unit above the current one.
+func (b *ArmyBuilder) Up() *ArmyBuilder {
+	switch {
+	case b.err != nil:
+	case b.at == nil:
+		b.err = fmt.Errorf("composite: Up(): %w", ErrNoDivision)
+	case b.at == Soldier(b.root):
+		b.err = fmt.Errorf("composite: Up(): %w", ErrAboveRoot)
+	default:
+		b.at = b.at.Parent()
+	}
+	return b
+}
+
+// Build returns the division, or the first error made while building it.
+func (b *ArmyBuilder) Build() (*Division, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	if b.root == nil {
+		return nil, fmt.Errorf("composite: Build(): %w", ErrNoDivision)
+	}
+	return b.root, nil
+}
+
+// push attaches s and moves down into it.
+func (b *ArmyBuilder) push(call string, s Soldier) *ArmyBuilder {
+	if b.attach(call, s) {
+		b.at = s
+	}
+	return b
+}
+
+// attach adds s to the current unit, and reports whether it did.
+func (b *ArmyBuilder) attach(call string, s Soldier) bool {
+	if b.err != nil {
+		return false
+	}
+	if b.at == nil {
+		b.err = fmt.Errorf("composite: %s(%q): %w", call, s.Name(), ErrNoDivision)
+		return false
+	}
+	if err := b.at.Add(s); err != nil {
+		b.err = fmt.Errorf("composite: %s(%q): %w", call, s.Name(), err)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,76 @@
+package composite
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestArmyBuilder(t *testing.T) {
+	division, err := NewArmyBuilder().
+		Division("1st").
+		Brigade("Alpha").
+		Platoon("1").
+		Squad("A").Enlisted("Jones", "Smith").Up().
+		Squad("B").Enlisted("Lee").Up().Up().
+		Platoon("2").Up().Up().
+		Brigade("Bravo").
+		Build()
+	if err != nil {
+		t.Fatalf("Build() = %v", err)
+	}
+	want := `Division: 1st
+  Brigade: Alpha
+    Platoon: 1
+      Squad: A
+        Enlisted: Jones
+        Enlisted: Smith
+      Squad: B
+        Enlisted: Lee
+    Platoon: 2
+  Brigade: Bravo
+`
+	if got := division.Render(); got != want {
+		t.Fatalf("built\n%s\nwant\n%s", got, want)
+	}
+	lee := division.Find("Lee")
+	if got := pathOf(lee); len(got) != 5 || got[3] != "B" {
+		t.Fatalf("Lee is at %v, want under 1st > Alpha > 1 > B", got)
+	}
+}
+
+func TestArmyBuilderErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		build func(*ArmyBuilder) *ArmyBuilder
+		want  error
+		msg   string
+	}{
+		{"nothing", func(b *ArmyBuilder) *ArmyBuilder { return b }, ErrNoDivision, "composite: Build(): composite: builder has no division yet"},
+		{"enlisted first", func(b *ArmyBuilder) *ArmyBuilder {
+			return b.Enlisted("Jones").Division("1st")
+		}, ErrNoDivision, `composite: Enlisted("Jones"): composite: builder has no division yet`},
+		{"enlisted before any squad", func(b *ArmyBuilder) *ArmyBuilder {
+			return b.Division("1st").Brigade("Alpha").Enlisted("Jones")
+		}, ErrInvalidChild, `composite: Enlisted("Jones"): composite: Brigade Alpha takes Platoon soldiers, not Enlisted Jones`},
+		{"skipped level", func(b *ArmyBuilder) *ArmyBuilder {
+			return b.Division("1st").Squad("A")
+		}, ErrInvalidChild, `composite: Squad("A"): composite: Division 1st takes Brigade soldiers, not Squad A`},
+		{"two divisions", func(b *ArmyBuilder) *ArmyBuilder {
+			return b.Division("1st").Division("2nd")
+		}, ErrTwoDivisions, `composite: Division("2nd"): composite: builder already has a division`},
+		{"up from the root", func(b *ArmyBuilder) *ArmyBuilder {
+			return b.Division("1st").Brigade("Alpha").Up().Up().Brigade("Bravo")
+		}, ErrAboveRoot, "composite: Up(): composite: builder is already at the division"},
+		{"up with nothing", func(b *ArmyBuilder) *ArmyBuilder {
+			return b.Up()
+		}, ErrNoDivision, "composite: Up(): composite: builder has no division yet"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			division, err := tt.build(NewArmyBuilder()).Build()
+			if division != nil || !errors.Is(err, tt.want) || err.Error() != tt.msg {
+				t.Fatalf("Build() = %v, %v, want %q", division, err, tt.msg)
+			}
+		})
+	}
+}
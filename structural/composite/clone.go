@@ -0,0 +1,52 @@
+package composite
+
+// clone makes c a copy of u with a copy of every child below it. The copy
+// has no parent, publisher or hooks, but keeps u's name, sink and rules.
+func (u *unit) clone(c *unit, self Soldier) {
+	c.self = self
+	c.name = u.name
+	c.out = u.out
+	c.relaxed = u.relaxed
+	c.capacity = u.capacity
+	c.unique = u.unique
+	c.rank = u.rank
+	children := u.snapshot()
+	c.children = make([]Soldier, 0, len(children))
+	for _, child := range children {
+		copied := child.Clone()
+		if b := baseOf(copied); b != nil {
+			b.parent = self
+		}
+		c.children = append(c.children, copied)
+	}
+}
+
+func (d *Division) Clone() Soldier {
+	c := &Division{}
+	d.clone(&c.unit, c)
+	return c
+}
+
+func (b *Brigade) Clone() Soldier {
+	c := &Brigade{}
+	b.clone(&c.unit, c)
+	return c
+}
+
+func (p *Platoon) Clone() Soldier {
+	c := &Platoon{}
+	p.clone(&c.unit, c)
+	return c
+}
+
+func (s *Squad) Clone() Soldier {
+	c := &Squad{}
+	s.clone(&c.unit, c)
+	return c
+}
+
+func (e *Enlisted) Clone() Soldier {
+	c := &Enlisted{unavailable: e.unavailable}
+	e.clone(&c.unit, c)
+	return c
+}
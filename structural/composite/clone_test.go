@@ -0,0 +1,125 @@
+package composite
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/Antonious-Stewart/15-Most-Common-Design-Patterns/internal/output"
+)
+
+// staffedBrigade is Alpha: 1st Platoon: A: Ortiz Kim, B: Lee, printing to a
+// recorder.
+func staffedBrigade() *Brigade {
+	a, b := NewSquad("A"), NewSquad("B")
+	a.Add(NewEnlisted("Ortiz"), NewEnlisted("Kim"))
+	b.Add(NewEnlisted("Lee"))
+	platoon := NewPlatoon("1st Platoon")
+	platoon.Add(a, b)
+	brigade := NewBrigade("Alpha", WithSink(output.NewRecorder()))
+	brigade.Add(platoon)
+	return brigade
+}
+
+func TestCloneBriefsLikeTheOriginal(t *testing.T) {
+	original := staffedBrigade()
+	clone := original.Clone().(*Brigade)
+	if clone.Render() != original.Render() {
+		t.Fatalf("clone =\n%s\nwant\n%s", clone.Render(), original.Render())
+	}
+
+	var want, got []string
+	for _, tt := range []struct {
+		s   Soldier
+		out *[]string
+	}{{original, &want}, {clone, &got}} {
+		log := output.NewRecorder()
+		baseOf(tt.s).out = log
+		if err := tt.s.Brief("Hold"); err != nil {
+			t.Fatal(err)
+		}
+		*tt.out = log.Lines()
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("clone briefed %q, want %q", got, want)
+	}
+}
+
+func TestCloneIsIndependent(t *testing.T) {
+	original := staffedBrigade()
+	before := original.Render()
+	clone := original.Clone().(*Brigade)
+
+	clone.Add(NewPlatoon("2nd Platoon"))
+	cloneA := clone.Find("A").(*Squad)
+	cloneA.Add(NewEnlisted("Park"))
+	clone.RemoveRecursive(clone.Find("Lee"))
+	clone.Find("Kim").(*Enlisted).SetAvailable(false)
+	clone.Find("Ortiz").(*Enlisted).Rename("Ortega")
+
+	if got := original.Render(); got != before {
+		t.Fatalf("original after changing the clone =\n%s\nwant\n%s", got, before)
+	}
+	if got := original.Count(); got != 3 {
+		t.Fatalf("original Count() = %d, want 3", got)
+	}
+	if got := clone.Count(); got != 3 {
+		t.Fatalf("clone Count() = %d, want 3", got)
+	}
+	if err := original.Brief("Hold"); err != nil {
+		t.Fatalf("original Brief() = %v after Kim's clone was marked unavailable", err)
+	}
+
+	if clone.Parent() != nil {
+		t.Fatalf("clone Parent() = %v, want nil", clone.Parent())
+	}
+	if cloneA.Parent().Parent() != Soldier(clone) {
+		t.Fatal("clone's squad is not under the cloned brigade")
+	}
+}
+
+func TestCloneUnderManyDivisions(t *testing.T) {
+	template := staffedBrigade()
+	first, second := NewDivision("1st"), NewDivision("2nd")
+	first.Add(template.Clone())
+	second.Add(template.Clone())
+	if len(template.Children()) != 1 || template.Parent() != nil {
+		t.Fatal("stamping out copies changed the template")
+	}
+	for _, d := range []*Division{first, second} {
+		if got := d.Count(); got != 3 {
+			t.Fatalf("%s Count() = %d, want 3", d.Name(), got)
+		}
+	}
+	if first.Find("Ortiz") == second.Find("Ortiz") {
+		t.Fatal("the two copies share a soldier")
+	}
+}
+
+func TestCloneEnlisted(t *testing.T) {
+	kim := NewEnlisted("Kim", WithSink(output.NewRecorder()))
+	kim.SetAvailable(false)
+	squad := NewSquad("A")
+	squad.Add(kim)
+
+	clone := kim.Clone().(*Enlisted)
+	if clone == kim || clone.Name() != "Kim" || clone.Parent() != nil {
+		t.Fatalf("Clone() = %v with parent %v, want a new Kim with no parent", clone, clone.Parent())
+	}
+	if err := clone.Brief("Hold"); !errors.Is(err, ErrUnavailable) {
+		t.Fatalf("clone Brief() = %v, want it unavailable like the original", err)
+	}
+	clone.SetAvailable(true)
+	if err := kim.Brief("Hold"); !errors.Is(err, ErrUnavailable) {
+		t.Fatalf("original Brief() = %v after the clone became available", err)
+	}
+}
+
+func TestCloneKeepsRules(t *testing.T) {
+	squad := NewSquad("A", WithCapacity(1), Relaxed())
+	squad.Add(NewPlatoon("odd"))
+	clone := squad.Clone()
+	if err := clone.Add(NewEnlisted("Kim")); !errors.Is(err, ErrCapacityExceeded) {
+		t.Fatalf("Add() to a full clone = %v, want %v", err, ErrCapacityExceeded)
+	}
+}
@@ -1,6 +1,13 @@
 package composite
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/Antonious-Stewart/15-Most-Common-Design-Patterns/internal/output"
+)
 
 //Composite is a structural design pattern that lets you compose objects into tree structures and then work with these structures as if they were individual objects.
 //Composite became a pretty popular solution for the most problems that require building a tree structure.
@@ -27,129 +34,550 @@ import "fmt"
 //This would violate the Interface Segregation Principle because the methods will be empty in the leaf class.
 //However, the client will be able to treat all the elements equally, even when composing the tree.
 
+// ErrCycle is returned by Add when a soldier would end up inside itself.
+var ErrCycle = errors.New("composite: soldier would contain itself")
+
+// Soldier is anything that can take a place in a tree. The units in this
+// package can be added, removed and moved by several goroutines while
+// others brief, count, walk or search the same trees. Rename, SetOutput,
+// SetPublisher, SetRank and SetAvailable are not guarded that way: call
+// them before a tree is shared.
 type Soldier interface {
-	Brief(orders string)
-	Add(component ...Soldier)
+	Brief(orders string) error
+	// Add attaches soldiers below this one. A soldier that already belongs
+	// to a container is moved: it is detached from the old one first.
+	// Add fails, adding none of the soldiers, with
+	//   - ErrCycle for a soldier added to itself or one of its descendants,
+	//   - an *InvalidChildError for the wrong kind of soldier, such as a
+	//     Platoon added to a Division,
+	//   - ErrCapacityExceeded for more soldiers than WithCapacity allows,
+	//   - ErrDuplicateName for a name clash under WithUniqueNames.
+	// Enlisted soldiers take no children and fail with an *InvalidChildError.
+	Add(component ...Soldier) error
+	Name() string
+	// Count is the number of enlisted soldiers in the subtree.
+	Count() int
+	// Parent is the container the soldier was added to, or nil at the root
+	// of a tree.
+	Parent() Soldier
+	// Rank is the soldier's rank, or for a unit that of whoever leads it.
+	Rank() Rank
+	// Report rolls up how many enlisted soldiers in the subtree are ready
+	// for orders.
+	Report() Readiness
+	// Clone copies the soldier and everything below it into a new tree
+	// with no parent; changing one tree leaves the other alone. Sinks are
+	// shared; change publishers and hooks are not copied.
+	Clone() Soldier
 }
 
-type Division struct {
-	name     string
-	brigades []Soldier
+// unit holds what every soldier shares. self is the concrete type embedding
+// it, which is what gets recorded as the parent of added children.
+type unit struct {
+	name      string
+	self      Soldier
+	parent    Soldier
+	children  []Soldier
+	publisher ChangePublisher
+	out       output.Sink
+	relaxed   bool
+	capacity  int
+	rank      Rank
+	changes   int
+	unique    bool
+	onAdd     []func(parent, child Soldier)
+	onRemove  []func(parent, child Soldier)
 }
 
-func NewDivision(name string) *Division {
-	return &Division{
-		name:     name,
-		brigades: make([]Soldier, 0),
+type UnitOption func(u *unit)
+
+// WithSink makes the unit, and every unit below it that has no sink of its
+// own, print its briefings to s instead of stdout.
+func WithSink(s output.Sink) UnitOption {
+	return func(u *unit) {
+		u.out = s
 	}
 }
 
-func (d *Division) Brief(orders string) {
-	// should call each brigade and give them order
-	message := fmt.Sprintf("Briefing %d Brigades", len(d.brigades))
-	for _, brigade := range d.brigades {
-		brigade.Brief(orders)
+// node is implemented by every soldier in this package through unit.
+type node interface {
+	base() *unit
+}
+
+func (u *unit) init(self Soldier, name string, opts []UnitOption) {
+	u.self = self
+	u.name = name
+	u.children = make([]Soldier, 0)
+	for _, opt := range opts {
+		opt(u)
 	}
-	fmt.Println(message)
 }
 
-func (d *Division) Add(brigades ...Soldier) {
-	d.brigades = append(d.brigades, brigades...)
+func (u *unit) base() *unit {
+	return u
 }
 
-type Brigade struct {
-	name     string
-	platoons []Soldier
+func (u *unit) Name() string {
+	return u.name
 }
 
-func NewBrigade(name string) *Brigade {
-	return &Brigade{
-		name:     name,
-		platoons: make([]Soldier, 0),
+func (u *unit) Parent() Soldier {
+	structure.RLock()
+	defer structure.RUnlock()
+	return u.parent
+}
+
+// Detach removes the unit, with everything below it, from its parent and
+// reports whether it had one. The unit becomes the root of its own tree.
+func (u *unit) Detach() bool {
+	parent := baseOf(u.Parent())
+	if parent == nil {
+		return false
 	}
+	return parent.remove(u.self)
 }
 
-func (b *Brigade) Brief(orders string) {
-	message := fmt.Sprintf("Briefing %d Platoons", len(b.platoons))
+// Rename changes the unit's name. Change events published afterwards use
+// the new name in their paths.
+func (u *unit) Rename(name string) {
+	u.name = name
+}
 
-	// should call each platoon and give them order
-	for _, platoon := range b.platoons {
-		platoon.Brief(orders)
+// SetOutput prints the unit's briefings, and those of every unit below it
+// without an output of its own, to w. A nil w goes back to the parent's
+// output, or stdout at the root.
+func (u *unit) SetOutput(w io.Writer) {
+	if w == nil {
+		u.out = nil
+		return
 	}
-	fmt.Println(message)
+	u.out = output.NewWriter(w)
 }
 
-func (b *Brigade) Add(platoons ...Soldier) {
-	b.platoons = append(b.platoons, platoons...)
+// sink is the nearest sink set on the unit or one of its ancestors.
+func (u *unit) sink() output.Sink {
+	structure.RLock()
+	defer structure.RUnlock()
+	for n := u; n != nil; n = baseOf(n.parent) {
+		if n.out != nil {
+			return n.out
+		}
+	}
+	return output.Stdout
 }
 
-type Platoon struct {
-	name   string
-	squads []Soldier
+// Count adds up the children's counts, so an empty container has none.
+func (u *unit) Count() int {
+	n := 0
+	for _, child := range u.snapshot() {
+		n += child.Count()
+	}
+	return n
 }
 
-func NewPlatoon(name string) *Platoon {
-	return &Platoon{
-		name:   name,
-		squads: make([]Soldier, 0),
+// CountUnits counts every unit in the subtree, the receiver and the
+// containers as well as the enlisted. A soldier from another package counts
+// as one unless it has a CountUnits of its own.
+func (u *unit) CountUnits() int {
+	n := 1
+	for _, child := range u.snapshot() {
+		if c, ok := child.(interface{ CountUnits() int }); ok {
+			n += c.CountUnits()
+		} else {
+			n++
+		}
 	}
+	return n
 }
 
-func (p *Platoon) Brief(orders string) {
-	message := fmt.Sprintf("Briefing %d Squads", len(p.squads))
+// childList copies the children so callers can't reorder the real slice.
+func (u *unit) childList() []Soldier {
+	return slices.Clone(u.snapshot())
+}
 
-	// should call each squad and give them order
-	for _, squad := range p.squads {
-		squad.Brief(orders)
+// add attaches children, moving any that already belong to another container.
+func (u *unit) add(children []Soldier) error {
+	structure.Lock()
+	movedFrom, err := u.attach(children)
+	structure.Unlock()
+	if err != nil {
+		return err
+	}
+	for i, child := range children {
+		if old := movedFrom[i]; old != nil {
+			old.notify(ChildRemoved, child)
+			old.removed(child)
+		}
+		u.notifyAttached(child)
+		u.added(child)
 	}
-	fmt.Println(message)
+	return nil
 }
 
-func (p *Platoon) Add(squads ...Soldier) {
-	p.squads = append(p.squads, squads...)
+// attach is add's checks and changes, made with structure locked. It
+// returns the unit each child was taken from, if any.
+func (u *unit) attach(children []Soldier) ([]*unit, error) {
+	for _, child := range children {
+		for above := u; above != nil; above = baseOf(above.parent) {
+			if above.self == child {
+				return nil, fmt.Errorf("composite: adding %s to %s: %w", above.name, u.name, ErrCycle)
+			}
+		}
+		if err := u.accepts(child); err != nil {
+			return nil, err
+		}
+	}
+	if err := u.fits(children); err != nil {
+		return nil, err
+	}
+	if err := u.distinct(children); err != nil {
+		return nil, err
+	}
+	movedFrom := make([]*unit, len(children))
+	for i, child := range children {
+		if c := baseOf(child); c != nil {
+			if old := baseOf(c.parent); old != nil {
+				old.unlink(child)
+				movedFrom[i] = old
+			}
+			c.parent = u.self
+		}
+		u.children = append(u.children, child)
+		u.changes++
+	}
+	return movedFrom, nil
 }
 
-type Squad struct {
-	name      string
-	enlistees []Soldier
+// remove detaches target if it is a direct child and reports whether it was.
+func (u *unit) remove(target Soldier) bool {
+	structure.Lock()
+	removed := u.unlink(target)
+	structure.Unlock()
+	if removed {
+		u.notify(ChildRemoved, target)
+		u.removed(target)
+	}
+	return removed
 }
 
-func NewSquad(name string) *Squad {
-	return &Squad{
-		name:      name,
-		enlistees: make([]Soldier, 0),
+// unlink is remove made with structure locked, without telling anyone.
+// The slice is rebuilt rather than shifted so a Brief already ranging over
+// the old one isn't disturbed.
+func (u *unit) unlink(target Soldier) bool {
+	for i, child := range u.children {
+		if child != target {
+			continue
+		}
+		u.children = append(u.children[:i:i], u.children[i+1:]...)
+		u.changes++
+		if c := baseOf(child); c != nil {
+			c.parent = nil
+		}
+		return true
 	}
+	return false
 }
 
-func (s *Squad) Brief(orders string) {
-	message := fmt.Sprintf("Briefing %d Enlistees", len(s.enlistees))
-	// should call each enlistee and give them order
-	for _, enlistee := range s.enlistees {
-		enlistee.Brief(orders)
+// removeByName detaches the first direct child called name.
+func (u *unit) removeByName(name string) bool {
+	for _, child := range u.snapshot() {
+		if nameOf(child) == name {
+			return u.remove(child)
+		}
+	}
+	return false
+}
+
+// removeRecursive detaches target from whichever container below u holds it.
+// A unit's parent links lead up to u if it is anywhere in the subtree;
+// soldiers from other packages have none and are searched for.
+func (u *unit) removeRecursive(target Soldier) bool {
+	c := baseOf(target)
+	if c == nil {
+		if u.remove(target) {
+			return true
+		}
+		for _, child := range u.snapshot() {
+			if b := baseOf(child); b != nil && b.removeRecursive(target) {
+				return true
+			}
+		}
+		return false
+	}
+	structure.RLock()
+	var holder *unit
+	for n := baseOf(c.parent); n != nil; n = baseOf(n.parent) {
+		if n == u {
+			holder = baseOf(c.parent)
+			break
+		}
 	}
-	fmt.Println(message)
+	structure.RUnlock()
+	return holder != nil && holder.remove(target)
+}
+
+type Division struct {
+	unit
+}
+
+func NewDivision(name string, opts ...UnitOption) *Division {
+	d := &Division{}
+	d.init(d, name, opts)
+	return d
+}
+
+// Brief announces the unit, then passes orders to every brigade and reports
+// the soldiers that couldn't act on them; one failing doesn't keep the rest
+// from hearing.
+func (d *Division) Brief(orders string) error {
+	d.sink().Println(d.briefing())
+	// should call each brigade and give them order
+	return d.briefChildren(orders)
+}
+
+func (d *Division) briefing() string {
+	return fmt.Sprintf("%s: Briefing %d Brigades", d.name, len(d.snapshot()))
+}
+
+func (d *Division) Add(brigades ...Soldier) error {
+	return d.add(brigades)
+}
+
+// Remove detaches target, and everything below it, if it is a direct child.
+func (d *Division) Remove(target Soldier) bool {
+	return d.remove(target)
+}
+
+// RemoveByName detaches the first direct child called name.
+func (d *Division) RemoveByName(name string) bool {
+	return d.removeByName(name)
+}
+
+// RemoveRecursive detaches target wherever it is below d.
+func (d *Division) RemoveRecursive(target Soldier) bool {
+	return d.removeRecursive(target)
+}
+
+// Children returns the direct children in the order they were added.
+func (d *Division) Children() []Soldier {
+	return d.childList()
+}
+
+// String describes the division, e.g. "Division(1st Infantry, 2 brigades)".
+func (d *Division) String() string {
+	return fmt.Sprintf("Division(%s, %s)", d.name, plural(len(d.snapshot()), "brigade", "brigades"))
+}
+
+type Brigade struct {
+	unit
+}
+
+func NewBrigade(name string, opts ...UnitOption) *Brigade {
+	b := &Brigade{}
+	b.init(b, name, opts)
+	return b
+}
+
+// Brief announces the unit, then passes orders to every platoon and reports
+// the soldiers that couldn't act on them; one failing doesn't keep the rest
+// from hearing.
+func (b *Brigade) Brief(orders string) error {
+	b.sink().Println(b.briefing())
+	// should call each platoon and give them order
+	return b.briefChildren(orders)
+}
+
+func (b *Brigade) briefing() string {
+	return fmt.Sprintf("%s: Briefing %d Platoons", b.name, len(b.snapshot()))
+}
+
+func (b *Brigade) Add(platoons ...Soldier) error {
+	return b.add(platoons)
 }
 
-func (s *Squad) Add(enlistees ...Soldier) {
-	s.enlistees = append(s.enlistees, enlistees...)
+// Remove detaches target, and everything below it, if it is a direct child.
+func (b *Brigade) Remove(target Soldier) bool {
+	return b.remove(target)
+}
+
+// RemoveByName detaches the first direct child called name.
+func (b *Brigade) RemoveByName(name string) bool {
+	return b.removeByName(name)
+}
+
+// RemoveRecursive detaches target wherever it is below b.
+func (b *Brigade) RemoveRecursive(target Soldier) bool {
+	return b.removeRecursive(target)
+}
+
+// Children returns the direct children in the order they were added.
+func (b *Brigade) Children() []Soldier {
+	return b.childList()
+}
+
+// String describes the brigade, e.g. "Brigade(Alpha, 2 platoons)".
+func (b *Brigade) String() string {
+	return fmt.Sprintf("Brigade(%s, %s)", b.name, plural(len(b.snapshot()), "platoon", "platoons"))
+}
+
+type Platoon struct {
+	unit
+}
+
+func NewPlatoon(name string, opts ...UnitOption) *Platoon {
+	p := &Platoon{}
+	p.init(p, name, opts)
+	return p
+}
+
+// Brief announces the unit, then passes orders to every squad and reports
+// the soldiers that couldn't act on them; one failing doesn't keep the rest
+// from hearing.
+func (p *Platoon) Brief(orders string) error {
+	p.sink().Println(p.briefing())
+	// should call each squad and give them order
+	return p.briefChildren(orders)
+}
+
+func (p *Platoon) briefing() string {
+	return fmt.Sprintf("%s: Briefing %d Squads", p.name, len(p.snapshot()))
+}
+
+func (p *Platoon) Add(squads ...Soldier) error {
+	return p.add(squads)
+}
+
+// Remove detaches target, and everything below it, if it is a direct child.
+func (p *Platoon) Remove(target Soldier) bool {
+	return p.remove(target)
+}
+
+// RemoveByName detaches the first direct child called name.
+func (p *Platoon) RemoveByName(name string) bool {
+	return p.removeByName(name)
+}
+
+// RemoveRecursive detaches target wherever it is below p.
+func (p *Platoon) RemoveRecursive(target Soldier) bool {
+	return p.removeRecursive(target)
+}
+
+// Children returns the direct children in the order they were added.
+func (p *Platoon) Children() []Soldier {
+	return p.childList()
+}
+
+// String describes the platoon, e.g. "Platoon(1st, 2 squads)".
+func (p *Platoon) String() string {
+	return fmt.Sprintf("Platoon(%s, %s)", p.name, plural(len(p.snapshot()), "squad", "squads"))
+}
+
+type Squad struct {
+	unit
+}
+
+func NewSquad(name string, opts ...UnitOption) *Squad {
+	s := &Squad{}
+	s.init(s, name, opts)
+	return s
+}
+
+// Brief announces the unit, then passes orders to every enlistee and reports
+// the soldiers that couldn't act on them; one failing doesn't keep the rest
+// from hearing.
+func (s *Squad) Brief(orders string) error {
+	s.sink().Println(s.briefing())
+	// should call each enlistee and give them order
+	return s.briefChildren(orders)
+}
+
+func (s *Squad) briefing() string {
+	return fmt.Sprintf("%s: Briefing %d Enlistees", s.name, len(s.snapshot()))
+}
+
+func (s *Squad) Add(enlistees ...Soldier) error {
+	return s.add(enlistees)
+}
+
+// Remove detaches target, and everything below it, if it is a direct child.
+func (s *Squad) Remove(target Soldier) bool {
+	return s.remove(target)
+}
+
+// RemoveByName detaches the first direct child called name.
+func (s *Squad) RemoveByName(name string) bool {
+	return s.removeByName(name)
+}
+
+// RemoveRecursive detaches target wherever it is below s.
+func (s *Squad) RemoveRecursive(target Soldier) bool {
+	return s.removeRecursive(target)
+}
+
+// Children returns the direct children in the order they were added.
+func (s *Squad) Children() []Soldier {
+	return s.childList()
+}
+
+// String describes the squad, e.g. "Squad(A, 2 enlistees)".
+func (s *Squad) String() string {
+	return fmt.Sprintf("Squad(%s, %s)", s.name, plural(len(s.snapshot()), "enlistee", "enlistees"))
 }
 
 type Enlisted struct {
-	name string
+	unit
+	unavailable bool
+}
+
+func NewEnlisted(name string, opts ...UnitOption) *Enlisted {
+	e := &Enlisted{}
+	e.init(e, name, opts)
+	return e
 }
 
-func NewEnlisted(name string) *Enlisted {
-	return &Enlisted{
-		name: name,
+// Brief fails with ErrEmptyOrders when there is nothing to act on and with
+// ErrUnavailable when the soldier has been marked unavailable.
+func (e *Enlisted) Brief(orders string) error {
+	if e.unavailable {
+		return &BriefError{Path: []string{e.name}, Err: ErrUnavailable}
+	}
+	if orders == "" {
+		return &BriefError{Path: []string{e.name}, Err: ErrEmptyOrders}
 	}
+	e.sink().Println(orders)
+	return nil
 }
 
-func (e *Enlisted) Brief(orders string) {
-	// should call each enlistee and give them order
-	fmt.Println(orders)
+// SetAvailable marks the soldier as able to take orders or not. Soldiers
+// start out available.
+func (e *Enlisted) SetAvailable(available bool) {
+	e.unavailable = !available
+}
+
+// Add refuses every soldier with an *InvalidChildError: an Enlisted is a
+// leaf and has nowhere to keep them.
+func (e *Enlisted) Add(enlistees ...Soldier) error {
+	if len(enlistees) == 0 {
+		return nil
+	}
+	return &InvalidChildError{Parent: e, Child: enlistees[0]}
+}
+
+func (e *Enlisted) Count() int {
+	return 1
 }
 
-func (e *Enlisted) Add(enlistees ...Soldier) {}
+// String describes the soldier, e.g. "Enlisted(Pvt. Jones)".
+func (e *Enlisted) String() string {
+	return fmt.Sprintf("Enlisted(%s)", e.name)
+}
+
+func plural(n int, one, many string) string {
+	if n == 1 {
+		return "1 " + one
+	}
+	return fmt.Sprintf("%d %s", n, many)
+}
 
 //Pros and Cons
 //
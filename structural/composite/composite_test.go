@@ -0,0 +1,359 @@
+package composite
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/Antonious-Stewart/15-Most-Common-Design-Patterns/internal/output"
+)
+
+func TestChildrenListsDirectChildrenInOrder(t *testing.T) {
+	brigade := NewBrigade("Alpha")
+	first, second := NewPlatoon("1st"), NewPlatoon("2nd")
+	brigade.Add(first, second)
+	first.Add(NewSquad("A"))
+
+	children := brigade.Children()
+	if len(children) != 2 || children[0] != first || children[1] != second {
+		t.Fatalf("Children() = %v, want the two platoons in order", children)
+	}
+	children[0] = nil
+	if brigade.Children()[0] != first {
+		t.Fatal("editing the returned slice changed the brigade")
+	}
+	if n := len(NewSquad("empty").Children()); n != 0 {
+		t.Fatalf("empty squad has %d children", n)
+	}
+	if brigade.Name() != "Alpha" || NewEnlisted("Jones").Name() != "Jones" {
+		t.Fatal("Name() does not return the constructor's name")
+	}
+}
+
+func TestRename(t *testing.T) {
+	platoon := NewPlatoon("1st")
+	squad := NewSquad("A")
+	platoon.Add(squad)
+	squad.Rename("Alpha")
+	if squad.Name() != "Alpha" {
+		t.Fatalf("Name() = %q after Rename, want Alpha", squad.Name())
+	}
+	if children := platoon.Children(); len(children) != 1 || children[0] != squad {
+		t.Fatal("Rename changed the tree")
+	}
+}
+
+func TestBriefPrintsToTheNearestSink(t *testing.T) {
+	brigadeLog := output.NewRecorder()
+	brigade := NewBrigade("Alpha", WithSink(brigadeLog))
+	platoon := NewPlatoon("1st Platoon")
+	squad := NewSquad("A")
+	squad.Add(NewEnlisted("Ortiz"), NewEnlisted("Kim"))
+	platoon.Add(squad)
+	brigade.Add(platoon)
+
+	brigade.Brief("Hold")
+	want := []string{"Alpha: Briefing 1 Platoons", "1st Platoon: Briefing 1 Squads", "A: Briefing 2 Enlistees", "Hold", "Hold"}
+	if got := brigadeLog.Lines(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("brigade sink got %q, want %q", got, want)
+	}
+
+	// a squad moved out from under the brigade prints to its new parent's sink
+	secondLog := output.NewRecorder()
+	second := NewPlatoon("2nd Platoon", WithSink(secondLog))
+	second.Add(squad)
+	squad.Brief("Move")
+	want = []string{"A: Briefing 2 Enlistees", "Move", "Move"}
+	if got := secondLog.Lines(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("2nd Platoon sink got %q, want %q", got, want)
+	}
+	if got := len(brigadeLog.Lines()); got != 5 {
+		t.Fatalf("brigade sink has %d lines after the move, want 5", got)
+	}
+}
+
+func TestBriefPrefixedSink(t *testing.T) {
+	log := output.NewRecorder()
+	squad := NewSquad("A", WithSink(output.NewPrefixed("> ", log)))
+	squad.Add(NewEnlisted("Ortiz"))
+	squad.Brief("Hold")
+	if want := "> A: Briefing 1 Enlistees\n> Hold\n"; log.String() != want {
+		t.Fatalf("sink got %q, want %q", log.String(), want)
+	}
+}
+
+func ExampleSquad_Brief() {
+	squad := NewSquad("A")
+	squad.Add(NewEnlisted("Ortiz"), NewEnlisted("Kim"))
+	squad.Brief("Hold the line")
+	// Output:
+	// A: Briefing 2 Enlistees
+	// Hold the line
+	// Hold the line
+}
+
+// tree is a division with two brigades; Alpha has platoon 1st with squad A
+// of Ortiz and Kim, Bravo is empty.
+func tree() (division *Division, alpha, bravo *Brigade, squad *Squad, ortiz *Enlisted) {
+	division = NewDivision("1st")
+	alpha, bravo = NewBrigade("Alpha"), NewBrigade("Bravo")
+	platoon := NewPlatoon("1st")
+	squad = NewSquad("A")
+	ortiz = NewEnlisted("Ortiz")
+	squad.Add(ortiz, NewEnlisted("Kim"))
+	platoon.Add(squad)
+	alpha.Add(platoon)
+	division.Add(alpha, bravo)
+	return division, alpha, bravo, squad, ortiz
+}
+
+func names(soldiers []Soldier) []string {
+	found := make([]string, 0, len(soldiers))
+	for _, s := range soldiers {
+		found = append(found, nameOf(s))
+	}
+	return found
+}
+
+func TestRemoveByName(t *testing.T) {
+	division, alpha, _, squad, ortiz := tree()
+	if !squad.RemoveByName("Ortiz") || ortiz.parent != nil {
+		t.Fatal("RemoveByName(Ortiz) left the enlistee attached")
+	}
+	if got := names(squad.Children()); !reflect.DeepEqual(got, []string{"Kim"}) {
+		t.Fatalf("squad children = %q, want [Kim]", got)
+	}
+
+	// a brigade leaves with everything below it
+	if !division.RemoveByName("Alpha") || alpha.parent != nil {
+		t.Fatal("RemoveByName(Alpha) left the brigade attached")
+	}
+	if got := names(division.Children()); !reflect.DeepEqual(got, []string{"Bravo"}) {
+		t.Fatalf("division children = %q, want [Bravo]", got)
+	}
+	if got := names(alpha.Children()); !reflect.DeepEqual(got, []string{"1st"}) {
+		t.Fatalf("removed brigade lost its platoon: %q", got)
+	}
+
+	if division.RemoveByName("Charlie") || division.RemoveByName("A") {
+		t.Fatal("RemoveByName found a unit that is not a direct child")
+	}
+	if got := names(division.Children()); !reflect.DeepEqual(got, []string{"Bravo"}) {
+		t.Fatalf("failed RemoveByName changed the children: %q", got)
+	}
+}
+
+func TestRemoveByNameTakesTheFirstMatch(t *testing.T) {
+	squad := NewSquad("A")
+	first, second := NewEnlisted("Lee"), NewEnlisted("Lee")
+	squad.Add(first, second)
+	if !squad.RemoveByName("Lee") || first.parent != nil || second.parent == nil {
+		t.Fatal("RemoveByName did not remove the first Lee only")
+	}
+}
+
+func TestRemoveRecursive(t *testing.T) {
+	division, alpha, bravo, squad, ortiz := tree()
+	if !division.RemoveRecursive(ortiz) || ortiz.parent != nil {
+		t.Fatal("RemoveRecursive(Ortiz) left the enlistee attached")
+	}
+	if got := names(squad.Children()); !reflect.DeepEqual(got, []string{"Kim"}) {
+		t.Fatalf("squad children = %q, want [Kim]", got)
+	}
+	if !division.RemoveRecursive(squad) || len(alpha.Children()[0].(*Platoon).Children()) != 0 {
+		t.Fatal("RemoveRecursive(squad) left the squad attached")
+	}
+
+	// outside the subtree, or already gone
+	platoon := alpha.Children()[0]
+	if bravo.RemoveRecursive(platoon) || division.RemoveRecursive(ortiz) || division.RemoveRecursive(NewEnlisted("Kim")) {
+		t.Fatal("RemoveRecursive removed a soldier that isn't below the container")
+	}
+	if squad.RemoveRecursive(squad) {
+		t.Fatal("a container removed itself")
+	}
+	if !division.RemoveRecursive(alpha) || len(division.Children()) != 1 {
+		t.Fatal("RemoveRecursive did not remove a direct child")
+	}
+}
+
+func TestRemoveRecursiveFindsForeignSoldiers(t *testing.T) {
+	division, _, _, squad, _ := tree()
+	outsider := &stranger{}
+	squad.Add(outsider)
+	if !division.RemoveRecursive(outsider) {
+		t.Fatal("RemoveRecursive did not find the foreign soldier")
+	}
+	if division.RemoveRecursive(outsider) {
+		t.Fatal("second RemoveRecursive = true")
+	}
+	if got := names(squad.Children()); !reflect.DeepEqual(got, []string{"Ortiz", "Kim"}) {
+		t.Fatalf("squad children = %q, want [Ortiz Kim]", got)
+	}
+}
+
+// captureStdout returns what fn printed to os.Stdout.
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	saved := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = saved }()
+	fn()
+	w.Close()
+	out, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(out)
+}
+
+func TestSetOutput(t *testing.T) {
+	var buf bytes.Buffer
+	division := NewDivision("1st")
+	division.SetOutput(&buf)
+	brigade := NewBrigade("Alpha")
+	platoon := NewPlatoon("1st Platoon")
+	squad := NewSquad("A")
+	squad.Add(NewEnlisted("Ortiz"), NewEnlisted("Kim"))
+	platoon.Add(squad)
+	brigade.Add(platoon)
+	division.Add(brigade)
+
+	leaked := captureStdout(t, func() {
+		if err := division.Brief("Hold"); err != nil {
+			t.Fatal(err)
+		}
+	})
+	want := "1st: Briefing 1 Brigades\nAlpha: Briefing 1 Platoons\n1st Platoon: Briefing 1 Squads\nA: Briefing 2 Enlistees\nHold\nHold\n"
+	if buf.String() != want {
+		t.Fatalf("output = %q, want %q", buf.String(), want)
+	}
+	if leaked != "" {
+		t.Fatalf("%q leaked to stdout", leaked)
+	}
+
+	// a writer further down takes over its subtree
+	var squadOut bytes.Buffer
+	squad.SetOutput(&squadOut)
+	buf.Reset()
+	captureStdout(t, func() { division.Brief("Move") })
+	if want := "A: Briefing 2 Enlistees\nMove\nMove\n"; squadOut.String() != want {
+		t.Fatalf("squad output = %q, want %q", squadOut.String(), want)
+	}
+	if want := "1st: Briefing 1 Brigades\nAlpha: Briefing 1 Platoons\n1st Platoon: Briefing 1 Squads\n"; buf.String() != want {
+		t.Fatalf("division output = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestSetOutputNilRestoresStdout(t *testing.T) {
+	var buf bytes.Buffer
+	jones := NewEnlisted("Jones")
+	jones.SetOutput(&buf)
+	jones.SetOutput(nil)
+	if got := captureStdout(t, func() { jones.Brief("Dig in") }); got != "Dig in\n" {
+		t.Fatalf("stdout = %q, want the orders", got)
+	}
+	if buf.Len() != 0 {
+		t.Fatalf("old writer got %q", buf.String())
+	}
+}
+
+func TestCount(t *testing.T) {
+	empty := NewDivision("empty")
+	if empty.Count() != 0 || empty.CountUnits() != 1 {
+		t.Fatalf("empty division: Count() = %d, CountUnits() = %d, want 0 and 1", empty.Count(), empty.CountUnits())
+	}
+
+	hollow := NewDivision("hollow")
+	brigade := NewBrigade("Alpha")
+	platoon := NewPlatoon("1st")
+	platoon.Add(NewSquad("A"), NewSquad("B"))
+	brigade.Add(platoon)
+	hollow.Add(brigade, NewBrigade("Bravo"))
+	if hollow.Count() != 0 || hollow.CountUnits() != 6 {
+		t.Fatalf("containers only: Count() = %d, CountUnits() = %d, want 0 and 6", hollow.Count(), hollow.CountUnits())
+	}
+
+	// 1st: Alpha: 1st: A (3), B (1)
+	//             2nd: C (2)
+	//      Bravo: 3rd: D (0)
+	//      Charlie
+	squad := func(name string, size int) *Squad {
+		s := NewSquad(name)
+		for i := range size {
+			s.Add(NewEnlisted(fmt.Sprintf("%s%d", name, i)))
+		}
+		return s
+	}
+	first, second, third := NewPlatoon("1st"), NewPlatoon("2nd"), NewPlatoon("3rd")
+	a := squad("A", 3)
+	first.Add(a, squad("B", 1))
+	second.Add(squad("C", 2), &outpost{name: "Outpost"})
+	third.Add(squad("D", 0))
+	alpha, bravo := NewBrigade("Alpha"), NewBrigade("Bravo")
+	alpha.Add(first, second)
+	bravo.Add(third)
+	division := NewDivision("1st")
+	division.Add(alpha, bravo, NewBrigade("Charlie"))
+
+	tests := []struct {
+		unit interface {
+			Count() int
+			CountUnits() int
+		}
+		count, units int
+	}{
+		{division, 7, 18},
+		{alpha, 7, 13},
+		{second, 3, 5},
+		{bravo, 0, 3},
+		{a, 3, 4},
+		{a.Children()[0].(*Enlisted), 1, 1},
+	}
+	for _, tt := range tests {
+		if got := tt.unit.Count(); got != tt.count {
+			t.Fatalf("%s: Count() = %d, want %d", nameOf(tt.unit.(Soldier)), got, tt.count)
+		}
+		if got := tt.unit.CountUnits(); got != tt.units {
+			t.Fatalf("%s: CountUnits() = %d, want %d", nameOf(tt.unit.(Soldier)), got, tt.units)
+		}
+	}
+}
+
+func TestNameAndString(t *testing.T) {
+	division := NewDivision("1st Infantry")
+	brigade := NewBrigade("Alpha")
+	platoon := NewPlatoon("1st")
+	squad := NewSquad("A")
+	jones := NewEnlisted("Pvt. Jones")
+	squad.Add(jones, NewEnlisted("Pvt. Kim"))
+	platoon.Add(squad)
+	division.Add(brigade, NewBrigade("Bravo"), NewBrigade("Charlie"))
+
+	tests := []struct {
+		soldier Soldier
+		name    string
+		str     string
+	}{
+		{division, "1st Infantry", "Division(1st Infantry, 3 brigades)"},
+		{brigade, "Alpha", "Brigade(Alpha, 0 platoons)"},
+		{platoon, "1st", "Platoon(1st, 1 squad)"},
+		{squad, "A", "Squad(A, 2 enlistees)"},
+		{jones, "Pvt. Jones", "Enlisted(Pvt. Jones)"},
+	}
+	for _, tt := range tests {
+		if got := tt.soldier.Name(); got != tt.name {
+			t.Fatalf("Name() = %q, want %q", got, tt.name)
+		}
+		if got := fmt.Sprint(tt.soldier); got != tt.str {
+			t.Fatalf("String() = %q, want %q", got, tt.str)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package composite
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ContextSoldier is a Soldier whose briefing can be cancelled. Every unit
+// in this package is one.
+type ContextSoldier interface {
+	Soldier
+	BriefContext(ctx context.Context, orders string) error
+}
+
+// CancelError reports a briefing that stopped because its context was done.
+// Path leads from the soldier that was briefed to the container that
+// stopped, which had briefed Briefed of its Of children by then.
+type CancelError struct {
+	Path    []string
+	Briefed int
+	Of      int
+	Err     error
+}
+
+func (e *CancelError) Error() string {
+	return fmt.Sprintf("composite: briefing stopped at %s after %d of %d: %v",
+		strings.Join(e.Path, " > "), e.Briefed, e.Of, e.Err)
+}
+
+func (e *CancelError) Unwrap() error {
+	return e.Err
+}
+
+// BriefContext is Brief that checks ctx before briefing each child and
+// stops at the first check that finds it done, returning a *CancelError
+// that wraps ctx.Err(). Soldiers briefed before that keep their orders,
+// and failures among them are not reported. Children that aren't ContextSoldiers are briefed with Brief.
+func (u *unit) BriefContext(ctx context.Context, orders string) error {
+	return briefContext(ctx, u.self, orders)
+}
+
+func briefContext(ctx context.Context, s Soldier, orders string) error {
+	c, ok := s.(container)
+	if !ok {
+		if err := ctx.Err(); err != nil {
+			return &CancelError{Path: []string{s.Name()}, Of: 1, Err: err}
+		}
+		return s.Brief(orders)
+	}
+	u := baseOf(c)
+	children := u.snapshot()
+	if err := ctx.Err(); err != nil {
+		return &CancelError{Path: []string{u.name}, Of: len(children), Err: err}
+	}
+	u.sink().Println(c.briefing())
+	errs := make([]error, len(children))
+	for i, child := range children {
+		if err := ctx.Err(); err != nil {
+			return &CancelError{Path: []string{u.name}, Briefed: i, Of: len(children), Err: err}
+		}
+		if cs, ok := child.(ContextSoldier); ok {
+			errs[i] = cs.BriefContext(ctx, orders)
+		} else {
+			errs[i] = child.Brief(orders)
+		}
+		var cancelled *CancelError
+		if errors.As(errs[i], &cancelled) {
+			cancelled.Path = append([]string{u.name}, cancelled.Path...)
+			return cancelled
+		}
+	}
+	return u.collect(children, errs)
+}
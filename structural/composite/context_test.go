@@ -0,0 +1,153 @@
+package composite
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/Antonious-Stewart/15-Most-Common-Design-Patterns/internal/output"
+)
+
+// callback is a foreign soldier that runs fn when it is briefed.
+type callback struct {
+	stranger
+	name string
+	fn   func(name string)
+}
+
+func (c callback) Brief(string) error {
+	c.fn(c.name)
+	return nil
+}
+
+func (c callback) Name() string {
+	return c.name
+}
+
+// callbackTree builds
+//
+//	1st: Alpha: 1: A: a1 a2 a3
+//	               B: b1 b2
+//	     Bravo: 2: C: c1
+//
+// with every leaf a callback running fn.
+func callbackTree(fn func(string)) *Division {
+	leaf := func(name string) Soldier { return callback{name: name, fn: fn} }
+	a, b, c := NewSquad("A"), NewSquad("B"), NewSquad("C")
+	a.Add(leaf("a1"), leaf("a2"), leaf("a3"))
+	b.Add(leaf("b1"), leaf("b2"))
+	c.Add(leaf("c1"))
+	first, second := NewPlatoon("1"), NewPlatoon("2")
+	first.Add(a, b)
+	second.Add(c)
+	alpha, bravo := NewBrigade("Alpha"), NewBrigade("Bravo")
+	alpha.Add(first)
+	bravo.Add(second)
+	division := NewDivision("1st", WithSink(output.NewRecorder()))
+	division.Add(alpha, bravo)
+	return division
+}
+
+func TestBriefContextCancel(t *testing.T) {
+	tests := []struct {
+		after   int
+		briefed []string
+		path    []string
+		done    int
+		of      int
+	}{
+		{1, []string{"a1"}, []string{"1st", "Alpha", "1", "A"}, 1, 3},
+		{3, []string{"a1", "a2", "a3"}, []string{"1st", "Alpha", "1"}, 1, 2},
+		{4, []string{"a1", "a2", "a3", "b1"}, []string{"1st", "Alpha", "1", "B"}, 1, 2},
+		{5, []string{"a1", "a2", "a3", "b1", "b2"}, []string{"1st"}, 1, 2},
+	}
+	for _, tt := range tests {
+		ctx, cancel := context.WithCancel(context.Background())
+		briefed := make([]string, 0)
+		division := callbackTree(func(name string) {
+			briefed = append(briefed, name)
+			if len(briefed) == tt.after {
+				cancel()
+			}
+		})
+		err := division.BriefContext(ctx, "Advance")
+		cancel()
+
+		var cancelled *CancelError
+		if !errors.As(err, &cancelled) || !errors.Is(err, context.Canceled) {
+			t.Fatalf("cancel after %d: BriefContext() = %v, want a CancelError", tt.after, err)
+		}
+		if !slices.Equal(briefed, tt.briefed) {
+			t.Fatalf("cancel after %d: briefed %v, want %v", tt.after, briefed, tt.briefed)
+		}
+		if !reflect.DeepEqual(cancelled.Path, tt.path) || cancelled.Briefed != tt.done || cancelled.Of != tt.of {
+			t.Fatalf("cancel after %d: stopped at %v after %d of %d, want %v after %d of %d",
+				tt.after, cancelled.Path, cancelled.Briefed, cancelled.Of, tt.path, tt.done, tt.of)
+		}
+	}
+}
+
+func TestBriefContextErrorMessage(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	division := callbackTree(func(name string) {
+		if name == "a2" {
+			cancel()
+		}
+	})
+	want := "composite: briefing stopped at 1st > Alpha > 1 > A after 2 of 3: context canceled"
+	if err := division.BriefContext(ctx, "Advance"); err == nil || err.Error() != want {
+		t.Fatalf("BriefContext() = %v, want %q", err, want)
+	}
+}
+
+func TestBriefContextDeadline(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	briefed := make([]string, 0)
+	division := callbackTree(func(name string) {
+		briefed = append(briefed, name)
+		<-ctx.Done()
+	})
+	err := division.BriefContext(ctx, "Advance")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("BriefContext() = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if !slices.Equal(briefed, []string{"a1"}) {
+		t.Fatalf("briefed %v after the deadline, want only a1", briefed)
+	}
+}
+
+func TestBriefContextMatchesBrief(t *testing.T) {
+	want, soldiers := briefTree()
+	soldiers["Kim"].SetAvailable(false)
+	wantLog := output.NewRecorder()
+	want.out = wantLog
+	wantErr := want.Brief("Advance")
+
+	got, soldiers := briefTree()
+	soldiers["Kim"].SetAvailable(false)
+	gotLog := output.NewRecorder()
+	got.out = gotLog
+	err := got.BriefContext(context.Background(), "Advance")
+
+	if err == nil || err.Error() != wantErr.Error() {
+		t.Fatalf("BriefContext() = %v, want %v", err, wantErr)
+	}
+	if !slices.Equal(gotLog.Lines(), wantLog.Lines()) {
+		t.Fatalf("BriefContext() printed %q, want %q", gotLog.Lines(), wantLog.Lines())
+	}
+}
+
+func TestBriefContextDoneBeforeStart(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	jones := NewEnlisted("Jones", WithSink(output.NewRecorder()))
+	var cancelled *CancelError
+	if err := jones.BriefContext(ctx, "Advance"); !errors.As(err, &cancelled) || cancelled.Briefed != 0 {
+		t.Fatalf("BriefContext() on a done context = %v, want nobody briefed", err)
+	}
+	var _ ContextSoldier = jones
+}
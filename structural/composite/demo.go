@@ -0,0 +1,97 @@
+package composite
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/Antonious-Stewart/15-Most-Common-Design-Patterns/internal/output"
+)
+
+// changeLog writes every structural change as it is published.
+type changeLog struct {
+	w io.Writer
+}
+
+func (l changeLog) Publish(e ChangeEvent) {
+	fmt.Fprintf(l.w, "%s %s under %s\n", e.Kind, e.Child, e.ParentPath)
+}
+
+// outline prints the tree as an indented list.
+type outline struct {
+	w     io.Writer
+	depth int
+}
+
+func (o *outline) line(name string) {
+	fmt.Fprintf(o.w, "%s%s\n", strings.Repeat("  ", o.depth), name)
+}
+
+func (o *outline) enter(name string) error {
+	o.line(name)
+	o.depth++
+	return nil
+}
+
+func (o *outline) VisitDivision(d *Division) error {
+	return o.enter(d.Name())
+}
+
+func (o *outline) VisitBrigade(b *Brigade) error {
+	return o.enter(b.Name())
+}
+
+func (o *outline) VisitPlatoon(p *Platoon) error {
+	return o.enter(p.Name())
+}
+
+func (o *outline) VisitSquad(s *Squad) error {
+	return o.enter(s.Name())
+}
+
+func (o *outline) VisitEnlisted(e *Enlisted) error {
+	o.line(e.Name())
+	return nil
+}
+
+func (o *outline) Leave(s Soldier) {
+	o.depth--
+}
+
+// Demo builds a division while logging the changes, prints it as an
+// outline, then briefs the whole tree at once while one soldier is away.
+func Demo(w io.Writer) error {
+	division := NewDivision("1st", WithSink(output.NewWriter(w)))
+	division.SetPublisher(changeLog{w: w})
+
+	squad := NewSquad("A")
+	kim := NewEnlisted("Kim")
+	if err := squad.Add(NewEnlisted("Ortiz"), kim); err != nil {
+		return err
+	}
+	platoon := NewPlatoon("1st Platoon")
+	if err := platoon.Add(squad); err != nil {
+		return err
+	}
+	brigade := NewBrigade("Alpha")
+	if err := brigade.Add(platoon); err != nil {
+		return err
+	}
+	if err := division.Add(brigade); err != nil {
+		return err
+	}
+	spare := NewBrigade("Bravo")
+	if err := division.Add(spare); err != nil {
+		return err
+	}
+	division.Remove(spare)
+
+	if err := division.Accept(&outline{w: w}); err != nil {
+		return err
+	}
+	kim.SetAvailable(false)
+	if err := division.Brief("Hold the line"); err != nil {
+		fmt.Fprintf(w, "not everyone was briefed:\n%v\n", err)
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package composite
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+var dotEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// WriteDOT writes the tree under root as a Graphviz digraph, one node per
+// soldier labelled as Render would, e.g. "Squad: A", and an edge from each
+// unit to each of its children. Nodes are numbered in the order Walk visits
+// them, so soldiers with the same name stay apart.
+func WriteDOT(w io.Writer, root Soldier) error {
+	bw := bufio.NewWriter(w)
+	bw.WriteString("digraph army {\n")
+	ids := make([]int, 0) // the node at each depth along the current branch
+	next := 0
+	walk(root, 0, func(s Soldier, depth int) error {
+		label := s.Name()
+		if rank := rankOf(s); rank != "" {
+			label = rank + ": " + label
+		}
+		fmt.Fprintf(bw, "\tn%d [label=\"%s\"];\n", next, dotEscaper.Replace(label))
+		ids = append(ids[:depth], next)
+		if depth > 0 {
+			fmt.Fprintf(bw, "\tn%d -> n%d;\n", ids[depth-1], next)
+		}
+		next++
+		return nil
+	})
+	bw.WriteString("}\n")
+	return bw.Flush()
+}
@@ -0,0 +1,98 @@
+package composite
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files")
+
+func TestWriteDOTGolden(t *testing.T) {
+	a, b := NewSquad("Alpha"), NewSquad("Alpha")
+	a.Add(NewEnlisted(`Pvt. "Ace" Jones`), &outpost{name: "Outpost"})
+	b.Add(NewEnlisted(`C:\Kim`))
+	first, second := NewPlatoon("1st"), NewPlatoon("2nd")
+	first.Add(a)
+	second.Add(b)
+	brigade := NewBrigade("Alpha")
+	brigade.Add(first, second)
+	division := NewDivision("1st Division")
+	division.Add(brigade)
+
+	var out bytes.Buffer
+	if err := WriteDOT(&out, division); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join("testdata", "army.dot")
+	if *update {
+		if err := os.WriteFile(path, out.Bytes(), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(out.Bytes(), want) {
+		t.Fatalf("WriteDOT() =\n%s\nwant\n%s", out.Bytes(), want)
+	}
+	checkDOT(t, out.String(), 9)
+}
+
+// checkDOT checks that dot is one digraph with nodes distinct nodes, every
+// edge between two of them.
+func checkDOT(t *testing.T, dot string, nodes int) {
+	t.Helper()
+	if !strings.HasPrefix(dot, "digraph army {\n") || !strings.HasSuffix(dot, "\n}\n") {
+		t.Fatalf("not a digraph:\n%s", dot)
+	}
+	node := regexp.MustCompile(`^\t(n\d+) \[label="(?:[^"\\]|\\.)*"\];$`)
+	edge := regexp.MustCompile(`^\t(n\d+) -> (n\d+);$`)
+	seen := make(map[string]bool)
+	lines := strings.Split(strings.TrimSuffix(dot, "\n"), "\n")
+	for _, line := range lines[1 : len(lines)-1] {
+		if m := node.FindStringSubmatch(line); m != nil {
+			if seen[m[1]] {
+				t.Fatalf("node %s declared twice", m[1])
+			}
+			seen[m[1]] = true
+			continue
+		}
+		m := edge.FindStringSubmatch(line)
+		if m == nil {
+			t.Fatalf("unexpected line %q", line)
+		}
+		if !seen[m[1]] || !seen[m[2]] {
+			t.Fatalf("edge %q joins undeclared nodes", line)
+		}
+	}
+	if len(seen) != nodes {
+		t.Fatalf("%d nodes, want %d", len(seen), nodes)
+	}
+}
+
+func TestWriteDOTSingleSoldier(t *testing.T) {
+	var out bytes.Buffer
+	if err := WriteDOT(&out, NewEnlisted("Lee")); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := out.String(), "digraph army {\n\tn0 [label=\"Enlisted: Lee\"];\n}\n"; got != want {
+		t.Fatalf("WriteDOT() = %q, want %q", got, want)
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errors.New("disk full") }
+
+func TestWriteDOTWriteError(t *testing.T) {
+	if err := WriteDOT(failingWriter{}, findTree()); err == nil || err.Error() != "disk full" {
+		t.Fatalf("WriteDOT() = %v, want the writer's error", err)
+	}
+}
@@ -0,0 +1,59 @@
+package composite
+
+import "reflect"
+
+// Equal reports whether two trees have the same shape: soldiers of the same
+// types with the same names, and the same children in the same order, all
+// the way down. Soldiers from other packages are looked into if they have
+// Children. Ranks, availability, sinks and other settings are not compared.
+func Equal(a, b Soldier) bool {
+	return equal(a, b, false)
+}
+
+// EqualUnordered is Equal with the order of siblings ignored.
+func EqualUnordered(a, b Soldier) bool {
+	return equal(a, b, true)
+}
+
+func equal(a, b Soldier, unordered bool) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	if reflect.TypeOf(a) != reflect.TypeOf(b) || a.Name() != b.Name() {
+		return false
+	}
+	as, bs := childrenOf(a), childrenOf(b)
+	if len(as) != len(bs) {
+		return false
+	}
+	if !unordered {
+		for i := range as {
+			if !equal(as[i], bs[i], false) {
+				return false
+			}
+		}
+		return true
+	}
+	// equal trees are interchangeable, so any match will do
+	matched := make([]bool, len(bs))
+	for _, x := range as {
+		found := false
+		for j, y := range bs {
+			if !matched[j] && equal(x, y, true) {
+				matched[j], found = true, true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func childrenOf(s Soldier) []Soldier {
+	if c, ok := s.(interface{ Children() []Soldier }); ok {
+		return c.Children()
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package composite
+
+import "testing"
+
+func TestEqual(t *testing.T) {
+	build := func(b *ArmyBuilder) Soldier {
+		d, err := b.Build()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return d
+	}
+	base := func() *ArmyBuilder {
+		return NewArmyBuilder().Division("1st").
+			Brigade("Alpha").Platoon("1").Squad("A").Enlisted("Kim", "Lee").Up().Squad("B").Up().Up().Up().
+			Brigade("Bravo")
+	}
+	tree := build(base())
+	tests := []struct {
+		name             string
+		other            Soldier
+		equal, unordered bool
+	}{
+		{"identical", build(base()), true, true},
+		{"clone", tree.Clone(), true, true},
+		{"renamed unit", build(NewArmyBuilder().Division("1st").
+			Brigade("Alpha").Platoon("2").Squad("A").Enlisted("Kim", "Lee").Up().Squad("B").Up().Up().Up().
+			Brigade("Bravo")), false, false},
+		{"brigades swapped", build(NewArmyBuilder().Division("1st").
+			Brigade("Bravo").Up().
+			Brigade("Alpha").Platoon("1").Squad("A").Enlisted("Kim", "Lee").Up().Squad("B")), false, true},
+		{"soldiers swapped", build(NewArmyBuilder().Division("1st").
+			Brigade("Alpha").Platoon("1").Squad("A").Enlisted("Lee", "Kim").Up().Squad("B").Up().Up().Up().
+			Brigade("Bravo")), false, true},
+		{"deep leaf differs", build(NewArmyBuilder().Division("1st").
+			Brigade("Alpha").Platoon("1").Squad("A").Enlisted("Kim", "Li").Up().Squad("B").Up().Up().Up().
+			Brigade("Bravo")), false, false},
+		{"extra soldier", build(base().Up().Brigade("Charlie")), false, false},
+		{"only part of it", tree.(*Division).Find("Alpha"), false, false},
+		{"nil", nil, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Equal(tree, tt.other); got != tt.equal {
+				t.Fatalf("Equal() = %v, want %v", got, tt.equal)
+			}
+			if got := EqualUnordered(tree, tt.other); got != tt.unordered {
+				t.Fatalf("EqualUnordered() = %v, want %v", got, tt.unordered)
+			}
+			if Equal(tt.other, tree) != tt.equal || EqualUnordered(tt.other, tree) != tt.unordered {
+				t.Fatal("comparison isn't symmetric")
+			}
+		})
+	}
+}
+
+func TestEqualTypes(t *testing.T) {
+	// same names and shape, different unit types
+	brigade, platoon := NewBrigade("A", Relaxed()), NewPlatoon("A", Relaxed())
+	brigade.Add(NewEnlisted("Kim"))
+	platoon.Add(NewEnlisted("Kim"))
+	if Equal(brigade, platoon) || EqualUnordered(brigade, platoon) {
+		t.Fatal("a brigade and a platoon compared equal")
+	}
+	if !Equal(nil, nil) {
+		t.Fatal("Equal(nil, nil) = false")
+	}
+	if !Equal(stranger{}, stranger{}) || Equal(stranger{}, NewEnlisted("stranger")) {
+		t.Fatal("foreign soldiers compared wrong")
+	}
+}
@@ -0,0 +1,118 @@
+package composite
+
+import "strings"
+
+type ChangeKind int
+
+const (
+	// ChildAdded is published for the attached unit and then for every unit
+	// already below it, so a subscriber learns about the whole subtree.
+	ChildAdded ChangeKind = iota
+	// ChildRemoved is published once for the detached unit; everything below
+	// it left with it.
+	ChildRemoved
+)
+
+func (k ChangeKind) String() string {
+	switch k {
+	case ChildAdded:
+		return "ChildAdded"
+	case ChildRemoved:
+		return "ChildRemoved"
+	}
+	return "ChangeKind(unknown)"
+}
+
+// ChangeEvent describes a structural change. ParentPath lists unit names
+// from the root down to the container that changed, separated by "/".
+type ChangeEvent struct {
+	Kind       ChangeKind
+	ParentPath string
+	Child      string
+}
+
+// ChangePublisher is told about every change in the subtree of the unit it
+// is set on, in the order the changes happen. An observer.Topic will do.
+type ChangePublisher interface {
+	Publish(e ChangeEvent)
+}
+
+// SetPublisher publishes every structural change in this unit's subtree
+// through p, including changes made after the subtree was attached.
+func (u *unit) SetPublisher(p ChangePublisher) {
+	u.publisher = p
+}
+
+// notify publishes to this unit and every ancestor that has a publisher, so
+// subscribing at the root sees mutations made anywhere below it.
+func (u *unit) notify(kind ChangeKind, child Soldier) {
+	u.publishUp(ChangeEvent{
+		Kind:       kind,
+		ParentPath: u.path(),
+		Child:      nameOf(child),
+	})
+}
+
+// notifyAttached announces child and then its existing descendants, top down.
+// Publishers set inside the child's subtree already saw those descendants
+// arrive, so only u and its ancestors hear about them.
+func (u *unit) notifyAttached(child Soldier) {
+	u.notify(ChildAdded, child)
+	var walk func(parent *unit)
+	walk = func(parent *unit) {
+		for _, c := range parent.snapshot() {
+			u.publishUp(ChangeEvent{
+				Kind:       ChildAdded,
+				ParentPath: parent.path(),
+				Child:      nameOf(c),
+			})
+			if b := baseOf(c); b != nil {
+				walk(b)
+			}
+		}
+	}
+	if c := baseOf(child); c != nil {
+		walk(c)
+	}
+}
+
+func (u *unit) publishUp(e ChangeEvent) {
+	publishers := make([]ChangePublisher, 0)
+	structure.RLock()
+	for n := u; n != nil; n = baseOf(n.parent) {
+		if n.publisher != nil {
+			publishers = append(publishers, n.publisher)
+		}
+	}
+	structure.RUnlock()
+	for _, p := range publishers {
+		p.Publish(e)
+	}
+}
+
+func (u *unit) path() string {
+	structure.RLock()
+	defer structure.RUnlock()
+	names := make([]string, 0)
+	for n := u; n != nil; n = baseOf(n.parent) {
+		names = append(names, n.name)
+	}
+	for i, j := 0, len(names)-1; i < j; i, j = i+1, j-1 {
+		names[i], names[j] = names[j], names[i]
+	}
+	return strings.Join(names, "/")
+}
+
+func baseOf(s Soldier) *unit {
+	if n, ok := s.(node); ok {
+		return n.base()
+	}
+	return nil
+}
+
+func nameOf(s Soldier) string {
+	if u := baseOf(s); u != nil {
+		return u.name
+	}
+	return ""
+}
@@ -0,0 +1,129 @@
+package composite
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+)
+
+type recordingPublisher struct {
+	events []string
+}
+
+func (r *recordingPublisher) Publish(e ChangeEvent) {
+	r.events = append(r.events, fmt.Sprintf("%s %s/%s", e.Kind, e.ParentPath, e.Child))
+}
+
+func (r *recordingPublisher) take() []string {
+	events := r.events
+	r.events = nil
+	return events
+}
+
+func TestNestedChangesReachRootPublisher(t *testing.T) {
+	rec := &recordingPublisher{}
+	division := NewDivision("1st")
+	division.SetPublisher(rec)
+
+	brigade, platoon, squad := NewBrigade("Alpha"), NewPlatoon("2nd"), NewSquad("S")
+	division.Add(brigade)
+	brigade.Add(platoon)
+	platoon.Add(squad)
+	squad.Add(NewEnlisted("Jones"))
+
+	want := []string{
+		"ChildAdded 1st/Alpha",
+		"ChildAdded 1st/Alpha/2nd",
+		"ChildAdded 1st/Alpha/2nd/S",
+		"ChildAdded 1st/Alpha/2nd/S/Jones",
+	}
+	if got := rec.take(); !slices.Equal(got, want) {
+		t.Fatalf("events = %v, want %v", got, want)
+	}
+
+	if !platoon.Remove(squad) {
+		t.Fatal("Remove(squad) = false")
+	}
+	if platoon.Remove(squad) {
+		t.Fatal("second Remove(squad) = true")
+	}
+	if got, want := rec.take(), []string{"ChildRemoved 1st/Alpha/2nd/S"}; !slices.Equal(got, want) {
+		t.Fatalf("events = %v, want %v", got, want)
+	}
+}
+
+func TestAttachingSubtreeAnnouncesDescendants(t *testing.T) {
+	rec := &recordingPublisher{}
+	division := NewDivision("1st")
+	division.SetPublisher(rec)
+
+	brigade, platoon := NewBrigade("Alpha"), NewPlatoon("2nd")
+	brigade.Add(platoon, NewPlatoon("3rd"))
+	platoon.Add(NewSquad("S"))
+	division.Add(brigade)
+
+	want := []string{
+		"ChildAdded 1st/Alpha",
+		"ChildAdded 1st/Alpha/2nd",
+		"ChildAdded 1st/Alpha/2nd/S",
+		"ChildAdded 1st/Alpha/3rd",
+	}
+	if got := rec.take(); !slices.Equal(got, want) {
+		t.Fatalf("events = %v, want %v", got, want)
+	}
+}
+
+func TestPublisherInsideSubtreeIsNotToldTwice(t *testing.T) {
+	inner := &recordingPublisher{}
+	brigade := NewBrigade("Alpha")
+	brigade.SetPublisher(inner)
+	brigade.Add(NewPlatoon("2nd"))
+
+	NewDivision("1st").Add(brigade)
+
+	if got, want := inner.take(), []string{"ChildAdded Alpha/2nd"}; !slices.Equal(got, want) {
+		t.Fatalf("events = %v, want %v", got, want)
+	}
+}
+
+func TestAddMovesAttachedUnit(t *testing.T) {
+	rec := &recordingPublisher{}
+	division := NewDivision("1st")
+	division.SetPublisher(rec)
+	alpha, bravo, platoon := NewBrigade("Alpha"), NewBrigade("Bravo"), NewPlatoon("2nd")
+	division.Add(alpha, bravo)
+	alpha.Add(platoon)
+	rec.take()
+
+	bravo.Add(platoon)
+
+	want := []string{
+		"ChildRemoved 1st/Alpha/2nd",
+		"ChildAdded 1st/Bravo/2nd",
+	}
+	if got := rec.take(); !slices.Equal(got, want) {
+		t.Fatalf("events = %v, want %v", got, want)
+	}
+	if len(alpha.children) != 0 || len(bravo.children) != 1 {
+		t.Fatalf("children = %d and %d, want the platoon only under Bravo", len(alpha.children), len(bravo.children))
+	}
+	if platoon.parent != Soldier(bravo) {
+		t.Fatal("moved platoon's parent is not Bravo")
+	}
+}
+
+func TestRemoveMissingLeavesChildrenUntouched(t *testing.T) {
+	squad := NewSquad("S")
+	jones, smith := NewEnlisted("Jones"), NewEnlisted("Smith")
+	squad.Add(jones)
+
+	if squad.Remove(smith) {
+		t.Fatal("Remove of a stranger = true")
+	}
+	if len(squad.children) != 1 || squad.children[0] != Soldier(jones) {
+		t.Fatalf("children = %v after a failed Remove", squad.children)
+	}
+	if !squad.Remove(jones) || jones.parent != nil {
+		t.Fatal("Remove left the enlistee attached")
+	}
+}
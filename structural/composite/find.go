@@ -0,0 +1,34 @@
+package composite
+
+import "errors"
+
+// errFound ends a Find walk at the first match.
+var errFound = errors.New("composite: found")
+
+// Find returns the first soldier called name in a depth-first search of the
+// unit's subtree, the unit itself included and checked first, or nil if
+// there is none.
+func (u *unit) Find(name string) Soldier {
+	var found Soldier
+	u.Walk(func(s Soldier, depth int) error {
+		if s.Name() == name {
+			found = s
+			return errFound
+		}
+		return nil
+	})
+	return found
+}
+
+// FindAll returns every soldier called name in the unit's subtree, the unit
+// itself included, in depth-first order.
+func (u *unit) FindAll(name string) []Soldier {
+	found := make([]Soldier, 0)
+	u.Walk(func(s Soldier, depth int) error {
+		if s.Name() == name {
+			found = append(found, s)
+		}
+		return nil
+	})
+	return found
+}
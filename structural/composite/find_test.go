@@ -0,0 +1,122 @@
+package composite
+
+import (
+	"reflect"
+	"testing"
+)
+
+// findTree has "1st" at three depths:
+//
+//	1st: Alpha: 1st: A: Ortiz Kim
+//	                 B: Kim
+//	     Bravo: 3rd Platoon: 1st
+func findTree() *Division {
+	a, b := NewSquad("A"), NewSquad("B")
+	a.Add(NewEnlisted("Ortiz"), NewEnlisted("Kim"))
+	b.Add(NewEnlisted("Kim"))
+	first := NewPlatoon("1st")
+	first.Add(a, b)
+	third := NewPlatoon("3rd Platoon")
+	third.Add(NewSquad("1st"))
+	alpha, bravo := NewBrigade("Alpha"), NewBrigade("Bravo")
+	alpha.Add(first)
+	bravo.Add(third)
+	division := NewDivision("1st")
+	division.Add(alpha, bravo)
+	return division
+}
+
+// pathOf names the units from the root down to s.
+func pathOf(s Soldier) []string {
+	path := make([]string, 0)
+	for u := baseOf(s); u != nil; u = baseOf(u.parent) {
+		path = append([]string{u.name}, path...)
+	}
+	return path
+}
+
+func TestFind(t *testing.T) {
+	division := findTree()
+	tests := []struct {
+		name string
+		want []string
+	}{
+		{"3rd Platoon", []string{"1st", "Bravo", "3rd Platoon"}},
+		{"Kim", []string{"1st", "Alpha", "1st", "A", "Kim"}},
+		// the receiver is checked before anything below it
+		{"1st", []string{"1st"}},
+	}
+	for _, tt := range tests {
+		found := division.Find(tt.name)
+		if found == nil {
+			t.Fatalf("Find(%q) = nil", tt.name)
+		}
+		if got := pathOf(found); !reflect.DeepEqual(got, tt.want) {
+			t.Fatalf("Find(%q) found %q, want %q", tt.name, got, tt.want)
+		}
+	}
+	if found := division.Find("Charlie"); found != nil {
+		t.Fatalf("Find(Charlie) = %v, want nil", found)
+	}
+}
+
+func TestFindAll(t *testing.T) {
+	division := findTree()
+	var got [][]string
+	for _, s := range division.FindAll("1st") {
+		got = append(got, pathOf(s))
+	}
+	want := [][]string{
+		{"1st"},
+		{"1st", "Alpha", "1st"},
+		{"1st", "Bravo", "3rd Platoon", "1st"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("FindAll(1st) = %q, want %q", got, want)
+	}
+	if n := len(division.Find("Alpha").(*Brigade).FindAll("Kim")); n != 2 {
+		t.Fatalf("Alpha has %d Kims, want 2", n)
+	}
+	if found := division.FindAll("Charlie"); found == nil || len(found) != 0 {
+		t.Fatalf("FindAll(Charlie) = %v, want an empty slice", found)
+	}
+}
+
+func TestFindEmptyTree(t *testing.T) {
+	squad := NewSquad("A")
+	if squad.Find("Ortiz") != nil || len(squad.FindAll("Ortiz")) != 0 {
+		t.Fatal("an empty squad found Ortiz")
+	}
+	if squad.Find("A") != Soldier(squad) {
+		t.Fatal("Find did not match the empty squad itself")
+	}
+	jones := NewEnlisted("Jones")
+	if jones.Find("Jones") != Soldier(jones) || jones.Find("Smith") != nil {
+		t.Fatal("Find on an enlisted soldier")
+	}
+}
+
+// outpost is a foreign container.
+type outpost struct {
+	stranger
+	name     string
+	children []Soldier
+}
+
+func (o *outpost) Name() string {
+	return o.name
+}
+
+func (o *outpost) Children() []Soldier {
+	return o.children
+}
+
+func TestFindThroughForeignSoldiers(t *testing.T) {
+	squad := NewSquad("A")
+	lee := NewEnlisted("Lee")
+	post := &outpost{name: "Outpost", children: []Soldier{lee}}
+	squad.Add(post)
+	if squad.Find("Outpost") != Soldier(post) || squad.Find("Lee") != Soldier(lee) {
+		t.Fatal("Find did not search the foreign container")
+	}
+}
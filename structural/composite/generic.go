@@ -0,0 +1,83 @@
+package composite
+
+// Component is anything in a generic tree that can be handed a T.
+type Component[T any] interface {
+	Operate(T)
+}
+
+// Composite is a container in a generic tree: operating on it calls its
+// announce, if any, then operates on each child in the order they were
+// added.
+type Composite[T any] struct {
+	children []Component[T]
+	announce func(T)
+}
+
+// NewComposite returns an empty container. announce runs before the
+// children on every Operate and may be nil.
+func NewComposite[T any](announce func(T)) *Composite[T] {
+	return &Composite[T]{announce: announce}
+}
+
+func (c *Composite[T]) Add(children ...Component[T]) {
+	c.children = append(c.children, children...)
+}
+
+// Remove detaches child if it is a direct child.
+func (c *Composite[T]) Remove(child Component[T]) bool {
+	for i, existing := range c.children {
+		if existing == child {
+			c.children = append(c.children[:i], c.children[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// Children returns the direct children in the order they were added.
+func (c *Composite[T]) Children() []Component[T] {
+	children := make([]Component[T], len(c.children))
+	copy(children, c.children)
+	return children
+}
+
+func (c *Composite[T]) Operate(v T) {
+	if c.announce != nil {
+		c.announce(v)
+	}
+	for _, child := range c.children {
+		child.Operate(v)
+	}
+}
+
+// Leaf is the end of a generic tree, doing whatever its function does.
+type Leaf[T any] struct {
+	fn func(T)
+}
+
+func NewLeaf[T any](fn func(T)) *Leaf[T] {
+	return &Leaf[T]{fn: fn}
+}
+
+func (l *Leaf[T]) Operate(v T) {
+	l.fn(v)
+}
+
+// AsComponent copies the tree under s into a generic one taking orders:
+// each container becomes a Composite that announces itself as Brief does, and
+// every other soldier a Leaf that is briefed. Later changes to s don't show
+// up in the copy. Operate has no way to fail, so briefing errors are
+// dropped.
+func AsComponent(s Soldier) Component[string] {
+	c, ok := s.(container)
+	if !ok {
+		return NewLeaf(func(orders string) { s.Brief(orders) })
+	}
+	u := baseOf(c)
+	message := c.briefing()
+	generic := NewComposite(func(string) { u.sink().Println(message) })
+	for _, child := range u.snapshot() {
+		generic.Add(AsComponent(child))
+	}
+	return generic
+}
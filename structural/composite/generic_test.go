@@ -0,0 +1,81 @@
+package composite
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/Antonious-Stewart/15-Most-Common-Design-Patterns/internal/output"
+)
+
+// order is a payload with more to it than a string.
+type order struct {
+	objective string
+	priority  int
+}
+
+// heard is what one leaf received.
+type heard struct {
+	by string
+	order
+}
+
+func TestCompositeOperatesInOrder(t *testing.T) {
+	log := make([]heard, 0)
+	leaf := func(name string) *Leaf[order] {
+		return NewLeaf(func(o order) { log = append(log, heard{name, o}) })
+	}
+	unit := func(name string) *Composite[order] {
+		return NewComposite(func(o order) { log = append(log, heard{name, o}) })
+	}
+	a, b := unit("A"), unit("B")
+	a.Add(leaf("a1"), leaf("a2"))
+	b.Add(leaf("b1"))
+	root := NewComposite[order](nil)
+	root.Add(a, b)
+
+	root.Operate(order{"hill 203", 1})
+	hill := order{"hill 203", 1}
+	want := []heard{{"A", hill}, {"a1", hill}, {"a2", hill}, {"B", hill}, {"b1", hill}}
+	if !slices.Equal(log, want) {
+		t.Fatalf("Operate() reached %v, want %v", log, want)
+	}
+}
+
+func TestCompositeRemove(t *testing.T) {
+	total := 0
+	add := func(o order) { total += o.priority }
+	first, second := NewLeaf(add), NewLeaf(add)
+	root := NewComposite[order](nil)
+	root.Add(first, second)
+
+	if !root.Remove(first) {
+		t.Fatal("Remove() = false for a direct child")
+	}
+	if root.Remove(first) {
+		t.Fatal("Remove() = true for a removed child")
+	}
+	if len(root.Children()) != 1 || root.Children()[0] != Component[order](second) {
+		t.Fatalf("Children() = %v, want only the second leaf", root.Children())
+	}
+	root.Operate(order{"hold", 5})
+	if total != 5 {
+		t.Fatalf("priority total = %d, want 5 from the remaining leaf", total)
+	}
+}
+
+func TestAsComponentBriefsLikeBrief(t *testing.T) {
+	want, _ := briefTree()
+	wantLog := output.NewRecorder()
+	want.out = wantLog
+	if err := want.Brief("Advance"); err != nil {
+		t.Fatal(err)
+	}
+
+	tree, _ := briefTree()
+	got := output.NewRecorder()
+	tree.out = got
+	AsComponent(tree).Operate("Advance")
+	if !slices.Equal(got.Lines(), wantLog.Lines()) {
+		t.Fatalf("Operate() printed %q, want %q", got.Lines(), wantLog.Lines())
+	}
+}
@@ -0,0 +1,35 @@
+package composite
+
+// OnAdd calls fn, right after it happens, for every child the unit gains,
+// including each soldier of a variadic Add and soldiers moved in from
+// another container. It only hears about the unit's direct children; to
+// follow a whole tree use SetPublisher. A nil fn is ignored.
+func OnAdd(fn func(parent, child Soldier)) UnitOption {
+	return func(u *unit) {
+		if fn != nil {
+			u.onAdd = append(u.onAdd, fn)
+		}
+	}
+}
+
+// OnRemove is OnAdd for children the unit loses, whether through one of
+// the Remove methods, Detach, or Add moving them to another container.
+func OnRemove(fn func(parent, child Soldier)) UnitOption {
+	return func(u *unit) {
+		if fn != nil {
+			u.onRemove = append(u.onRemove, fn)
+		}
+	}
+}
+
+func (u *unit) added(child Soldier) {
+	for _, fn := range u.onAdd {
+		fn(u.self, child)
+	}
+}
+
+func (u *unit) removed(child Soldier) {
+	for _, fn := range u.onRemove {
+		fn(u.self, child)
+	}
+}
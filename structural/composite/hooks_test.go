@@ -0,0 +1,95 @@
+package composite
+
+import (
+	"reflect"
+	"testing"
+)
+
+// hookLog records hook calls as "parent+child" and "parent-child".
+type hookLog []string
+
+func (l *hookLog) options() []UnitOption {
+	return []UnitOption{
+		OnAdd(func(parent, child Soldier) { *l = append(*l, parent.Name()+"+"+child.Name()) }),
+		OnRemove(func(parent, child Soldier) { *l = append(*l, parent.Name()+"-"+child.Name()) }),
+	}
+}
+
+func TestHooksOnAddAndRemove(t *testing.T) {
+	var log hookLog
+	squad := NewSquad("A", log.options()...)
+	ortiz, kim, lee := NewEnlisted("Ortiz"), NewEnlisted("Kim"), NewEnlisted("Lee")
+
+	squad.Add(ortiz)
+	squad.Add(kim, lee)
+	squad.Remove(kim)
+	squad.RemoveByName("Lee")
+	ortiz.Detach()
+	squad.Remove(kim) // not a child any more: no call
+
+	want := hookLog{"A+Ortiz", "A+Kim", "A+Lee", "A-Kim", "A-Lee", "A-Ortiz"}
+	if !reflect.DeepEqual(log, want) {
+		t.Fatalf("hooks saw %q, want %q", log, want)
+	}
+}
+
+func TestHooksArguments(t *testing.T) {
+	var parents, children []Soldier
+	squad := NewSquad("A", OnAdd(func(parent, child Soldier) {
+		parents = append(parents, parent)
+		children = append(children, child)
+	}))
+	ortiz, kim := NewEnlisted("Ortiz"), NewEnlisted("Kim")
+	squad.Add(ortiz, kim)
+	if !reflect.DeepEqual(parents, []Soldier{squad, squad}) || !reflect.DeepEqual(children, []Soldier{ortiz, kim}) {
+		t.Fatalf("OnAdd got parents %v and children %v", parents, children)
+	}
+	if ortiz.Parent() != Soldier(squad) {
+		t.Fatal("OnAdd ran before the child was attached")
+	}
+}
+
+func TestHooksOnlyDirectChildren(t *testing.T) {
+	var log hookLog
+	division := NewDivision("1st", log.options()...)
+	alpha, bravo := NewBrigade("Alpha"), NewBrigade("Bravo")
+	platoon := NewPlatoon("1st Platoon")
+	alpha.Add(platoon)
+	division.Add(alpha, bravo)
+
+	platoon.Add(NewSquad("A"))
+	bravo.Add(platoon) // moves between grandchildren
+	division.RemoveRecursive(platoon)
+	if want := (hookLog{"1st+Alpha", "1st+Bravo"}); !reflect.DeepEqual(log, want) {
+		t.Fatalf("division hooks saw %q, want only its brigades", log)
+	}
+}
+
+func TestHooksOnMove(t *testing.T) {
+	var log hookLog
+	first, second := NewSquad("A", log.options()...), NewSquad("B", log.options()...)
+	kim := NewEnlisted("Kim")
+	first.Add(kim)
+	second.Add(kim)
+	if want := (hookLog{"A+Kim", "A-Kim", "B+Kim"}); !reflect.DeepEqual(log, want) {
+		t.Fatalf("hooks saw %q, want %q", log, want)
+	}
+}
+
+func TestHooksRejectedAddAndNil(t *testing.T) {
+	var log hookLog
+	squad := NewSquad("A", append(log.options(), OnAdd(nil), OnRemove(nil), WithCapacity(1))...)
+	squad.Add(NewEnlisted("Ortiz"), NewEnlisted("Kim"))
+	if len(log) != 0 {
+		t.Fatalf("hooks saw %q for a rejected Add", log)
+	}
+	kim := NewEnlisted("Kim")
+	squad.Add(kim)
+	squad.Remove(kim)
+	if want := (hookLog{"A+Kim", "A-Kim"}); !reflect.DeepEqual(log, want) {
+		t.Fatalf("hooks saw %q, want %q", log, want)
+	}
+	if len(squad.Clone().(*Squad).onAdd) != 0 {
+		t.Fatal("Clone() copied the hooks")
+	}
+}
@@ -0,0 +1,108 @@
+package composite
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrUnknownType = errors.New("composite: unknown soldier type")
+	ErrNotAUnit    = errors.New("composite: enlisted soldiers have no children")
+)
+
+// jsonSoldier is how MarshalJSON writes a unit. Children is nil for
+// enlisted soldiers, so only containers have the field, empty or not.
+type jsonSoldier struct {
+	Type        string     `json:"type"`
+	Name        string     `json:"name"`
+	Unavailable bool       `json:"unavailable,omitempty"`
+	Children    *[]Soldier `json:"children,omitempty"`
+}
+
+// MarshalJSON writes the unit and everything below it as nested objects,
+// e.g. {"type":"squad","name":"A","children":[{"type":"enlisted","name":"Kim"}]}.
+// Soldiers from other packages are written however they marshal themselves.
+func (u *unit) MarshalJSON() ([]byte, error) {
+	out := jsonSoldier{
+		Type: strings.ToLower(rankOf(u.self)),
+		Name: u.name,
+	}
+	if e, ok := u.self.(*Enlisted); ok {
+		out.Unavailable = e.unavailable
+	} else {
+		children := u.snapshot()
+		out.Children = &children
+	}
+	return json.Marshal(out)
+}
+
+// UnmarshalSoldier rebuilds a tree written by MarshalJSON, with the unit
+// types named by the "type" fields.
+func UnmarshalSoldier(data []byte) (Soldier, error) {
+	return unmarshalSoldier(data, nil)
+}
+
+// jsonInput is what UnmarshalSoldier and Load read for each soldier.
+type jsonInput struct {
+	Type        string            `json:"type"`
+	Name        string            `json:"name"`
+	Unavailable bool              `json:"unavailable"`
+	Children    []json.RawMessage `json:"children"`
+}
+
+// newSoldier makes an empty soldier of the type MarshalJSON calls typ.
+func newSoldier(typ, name string, unavailable bool) (Soldier, bool) {
+	switch typ {
+	case "division":
+		return NewDivision(name), true
+	case "brigade":
+		return NewBrigade(name), true
+	case "platoon":
+		return NewPlatoon(name), true
+	case "squad":
+		return NewSquad(name), true
+	case "enlisted":
+		e := NewEnlisted(name)
+		e.SetAvailable(!unavailable)
+		return e, true
+	}
+	return nil, false
+}
+
+func unmarshalSoldier(data []byte, path []string) (Soldier, error) {
+	var in jsonInput
+	if err := json.Unmarshal(data, &in); err != nil {
+		where := "the root"
+		if len(path) > 0 {
+			where = "a child of " + describe(path)
+		}
+		return nil, fmt.Errorf("composite: reading %s: %w", where, err)
+	}
+	path = append(path, in.Name)
+
+	if in.Type == "enlisted" && len(in.Children) > 0 {
+		return nil, fmt.Errorf("%w: %s", ErrNotAUnit, describe(path))
+	}
+	s, ok := newSoldier(in.Type, in.Name, in.Unavailable)
+	if !ok {
+		return nil, fmt.Errorf("%w %q at %s", ErrUnknownType, in.Type, describe(path))
+	}
+
+	for _, raw := range in.Children {
+		child, err := unmarshalSoldier(raw, path)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.Add(child); err != nil {
+			return nil, fmt.Errorf("%w at %s", err, describe(path))
+		}
+	}
+	return s, nil
+}
+
+// describe names a position in the tree being read, e.g. "1st > Alpha".
+func describe(path []string) string {
+	return strings.Join(path, " > ")
+}
@@ -0,0 +1,89 @@
+package composite
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestJSONRoundTrip(t *testing.T) {
+	division := findTree()
+	division.Find("Ortiz").(*Enlisted).SetAvailable(false)
+	division.Add(NewBrigade("Charlie"))
+
+	data, err := json.Marshal(division)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := UnmarshalSoldier(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := s.(*Division).Render(), division.Render(); got != want {
+		t.Fatalf("round trip =\n%s\nwant\n%s", got, want)
+	}
+	if !s.(*Division).Find("Ortiz").(*Enlisted).unavailable {
+		t.Fatal("Ortiz is available again after the round trip")
+	}
+	charlie := s.(*Division).Find("Charlie").(*Brigade)
+	if charlie.children == nil || len(charlie.children) != 0 {
+		t.Fatalf("empty brigade came back with children %#v", charlie.children)
+	}
+	if charlie.parent != s {
+		t.Fatal("unmarshalled brigade isn't attached to its division")
+	}
+	again, err := json.Marshal(s)
+	if err != nil || string(again) != string(data) {
+		t.Fatalf("marshalling the copy = %s, %v, want %s", again, err, data)
+	}
+}
+
+func TestMarshalJSON(t *testing.T) {
+	squad := NewSquad("A")
+	squad.Add(NewEnlisted("Kim"))
+	platoon := NewPlatoon("1st")
+	platoon.Add(squad, NewSquad("B"))
+	data, err := json.Marshal(platoon)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"type":"platoon","name":"1st","children":[` +
+		`{"type":"squad","name":"A","children":[{"type":"enlisted","name":"Kim"}]},` +
+		`{"type":"squad","name":"B","children":[]}]}`
+	if string(data) != want {
+		t.Fatalf("Marshal() = %s, want %s", data, want)
+	}
+}
+
+func TestUnmarshalSoldierErrors(t *testing.T) {
+	tests := []struct {
+		input string
+		want  error
+		msg   string
+	}{
+		{`{"type":"general","name":"Patton"}`, ErrUnknownType, `unknown soldier type "general" at Patton`},
+		{`{"type":"division","name":"1st","children":[{"type":"brigade","name":"Alpha","children":[{"name":"Ghost"}]}]}`,
+			ErrUnknownType, `unknown soldier type "" at 1st > Alpha > Ghost`},
+		{`{"type":"enlisted","name":"Kim","children":[{"type":"enlisted","name":"Lee"}]}`, ErrNotAUnit, "Kim"},
+		{`{"type":"division","name":"1st","children":[{"type":"squad","name":"A"}]}`,
+			ErrInvalidChild, "Division 1st takes Brigade soldiers, not Squad A at 1st"},
+	}
+	for _, tt := range tests {
+		_, err := UnmarshalSoldier([]byte(tt.input))
+		if !errors.Is(err, tt.want) || !strings.Contains(err.Error(), tt.msg) {
+			t.Fatalf("UnmarshalSoldier(%s) = %v, want %v mentioning %q", tt.input, err, tt.want, tt.msg)
+		}
+	}
+
+	for input, msg := range map[string]string{
+		`[1, 2]`: "reading the root",
+		`{"type":"squad","name":"A","children":[7]}`: "reading a child of A",
+	} {
+		_, err := UnmarshalSoldier([]byte(input))
+		var syntax *json.UnmarshalTypeError
+		if !errors.As(err, &syntax) || !strings.Contains(err.Error(), msg) {
+			t.Fatalf("UnmarshalSoldier(%s) = %v, want a json error mentioning %q", input, err, msg)
+		}
+	}
+}
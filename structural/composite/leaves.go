@@ -0,0 +1,26 @@
+package composite
+
+import (
+	"errors"
+	"iter"
+)
+
+var errStopped = errors.New("composite: iteration stopped")
+
+// Leaves yields every enlisted soldier in the subtree, the unit included,
+// depth first in the order they were added, finding each as it goes rather
+// than collecting them first:
+//
+//	for e := range division.Leaves() {
+//		...
+//	}
+func (u *unit) Leaves() iter.Seq[*Enlisted] {
+	return func(yield func(*Enlisted) bool) {
+		walk(u.self, 0, func(s Soldier, depth int) error {
+			if e, ok := s.(*Enlisted); ok && !yield(e) {
+				return errStopped
+			}
+			return nil
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package composite
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestLeaves(t *testing.T) {
+	division := findTree()
+	division.Find("Bravo").Add(NewPlatoon("empty"))
+	got := make([]string, 0)
+	for e := range division.Leaves() {
+		got = append(got, e.Name())
+	}
+	if want := []string{"Ortiz", "Kim", "Kim"}; !slices.Equal(got, want) {
+		t.Fatalf("Leaves() = %v, want %v", got, want)
+	}
+}
+
+func TestLeavesBreak(t *testing.T) {
+	division := findTree()
+	got := make([]string, 0)
+	for e := range division.Leaves() {
+		got = append(got, e.Name())
+		if len(got) == 2 {
+			break
+		}
+	}
+	if want := []string{"Ortiz", "Kim"}; !slices.Equal(got, want) {
+		t.Fatalf("Leaves() up to a break = %v, want %v", got, want)
+	}
+
+	// a yield that says stop is not called again
+	calls := 0
+	division.Leaves()(func(*Enlisted) bool {
+		calls++
+		return false
+	})
+	if calls != 1 {
+		t.Fatalf("yield called %d times after returning false, want 1", calls)
+	}
+}
+
+func TestLeavesOfALeaf(t *testing.T) {
+	kim := NewEnlisted("Kim")
+	got := slices.Collect(kim.Leaves())
+	if len(got) != 1 || got[0] != kim {
+		t.Fatalf("Leaves() of an enlisted soldier = %v, want itself", got)
+	}
+	if got := slices.Collect(NewDivision("empty").Leaves()); len(got) != 0 {
+		t.Fatalf("Leaves() of an empty division = %v", got)
+	}
+}
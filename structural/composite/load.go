@@ -0,0 +1,62 @@
+package composite
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+var ErrNoName = errors.New("composite: soldier has no name")
+
+// Load reads a tree from a JSON document of nested objects with "type",
+// "name" and, for units, "children" keys, the form MarshalJSON writes:
+//
+//	{"type": "squad", "name": "A", "children": [
+//		{"type": "enlisted", "name": "Kim"},
+//		{"type": "enlisted", "name": "Lee", "unavailable": true}
+//	]}
+//
+// Children are attached with Add, so the hierarchy rules hold. Unlike
+// UnmarshalSoldier it insists on names, and its errors give the JSON path
+// of the object at fault, e.g. "$.children[1].children[0]".
+func Load(r io.Reader) (Soldier, error) {
+	dec := json.NewDecoder(r)
+	var doc json.RawMessage
+	if err := dec.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("composite: loading: %w", err)
+	}
+	if dec.More() {
+		return nil, errors.New("composite: loading: more than one tree in the document")
+	}
+	return load(doc, "$")
+}
+
+func load(data json.RawMessage, at string) (Soldier, error) {
+	var in jsonInput
+	if err := json.Unmarshal(data, &in); err != nil {
+		return nil, fmt.Errorf("composite: loading %s: %w", at, err)
+	}
+	if in.Name == "" {
+		return nil, fmt.Errorf("composite: loading %s: %w", at, ErrNoName)
+	}
+	if in.Type == "enlisted" && len(in.Children) > 0 {
+		return nil, fmt.Errorf("composite: loading %s: %w", at, ErrNotAUnit)
+	}
+	s, ok := newSoldier(in.Type, in.Name, in.Unavailable)
+	if !ok {
+		return nil, fmt.Errorf("composite: loading %s: %w %q", at, ErrUnknownType, in.Type)
+	}
+
+	for i, raw := range in.Children {
+		childAt := fmt.Sprintf("%s.children[%d]", at, i)
+		child, err := load(raw, childAt)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.Add(child); err != nil {
+			return nil, fmt.Errorf("composite: loading %s: %w", childAt, err)
+		}
+	}
+	return s, nil
+}
@@ -0,0 +1,84 @@
+package composite
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestLoad(t *testing.T) {
+	f, err := os.Open("testdata/army.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	s, err := Load(f)
+	if err != nil {
+		t.Fatalf("Load() = %v", err)
+	}
+
+	want, err := NewArmyBuilder().Division("1st").
+		Brigade("Alpha").Platoon("1st Platoon").
+		Squad("A").Enlisted("Ortiz", "Kim").Up().
+		Squad("B").Up().Up().Up().
+		Brigade("Bravo").
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !Equal(s, want) {
+		t.Fatalf("Load() =\n%s\nwant\n%s", s.(*Division).Render(), want.Render())
+	}
+	if s.(*Division).Render() != want.Render() {
+		t.Fatalf("Load() renders as\n%s\nwant\n%s", s.(*Division).Render(), want.Render())
+	}
+	if !s.(*Division).Find("Kim").(*Enlisted).unavailable {
+		t.Fatal("Kim was loaded available")
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	tests := []struct {
+		doc  string
+		want error
+		msg  string
+	}{
+		{`{"type":"general","name":"Patton"}`, ErrUnknownType,
+			`composite: loading $: composite: unknown soldier type "general"`},
+		{`{"type":"division","name":"1st","children":[{"type":"brigade","name":"Alpha"},{"type":"brigade"}]}`, ErrNoName,
+			"composite: loading $.children[1]: composite: soldier has no name"},
+		{`{"type":"division","name":"1st","children":[{"type":"brigade","name":"Alpha","children":[{"type":"squad","name":"A"}]}]}`, ErrInvalidChild,
+			"composite: loading $.children[0].children[0]: composite: Brigade Alpha takes Platoon soldiers, not Squad A"},
+		{`{"type":"squad","name":"A","children":[{"type":"enlisted","name":"Lee","children":[{"type":"enlisted","name":"Jr"}]}]}`, ErrNotAUnit,
+			"composite: loading $.children[0]: composite: enlisted soldiers have no children"},
+	}
+	for _, tt := range tests {
+		_, err := Load(strings.NewReader(tt.doc))
+		if !errors.Is(err, tt.want) || err.Error() != tt.msg {
+			t.Fatalf("Load(%s) = %v, want %q", tt.doc, err, tt.msg)
+		}
+	}
+
+	for doc, msg := range map[string]string{
+		`{"type":"squad","name":"A","children":[7]}`: "composite: loading $.children[0]: json: cannot unmarshal number",
+		`{"type":"squad"`: "composite: loading: unexpected EOF",
+		`{"type":"squad","name":"A"} {"type":"squad"}`: "composite: loading: more than one tree in the document",
+	} {
+		if _, err := Load(strings.NewReader(doc)); err == nil || !strings.HasPrefix(err.Error(), msg) {
+			t.Fatalf("Load(%s) = %v, want %q", doc, err, msg)
+		}
+	}
+}
+
+func TestLoadReadsMarshalJSON(t *testing.T) {
+	division := findTree()
+	data, err := division.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := Load(strings.NewReader(string(data)))
+	if err != nil || !Equal(s, division) {
+		t.Fatalf("Load(MarshalJSON()) = %v, %v, want the same tree", s, err)
+	}
+}
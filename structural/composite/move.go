@@ -0,0 +1,46 @@
+package composite
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrNotFound = errors.New("composite: soldier not found")
+
+// Move transfers child, with everything below it, from its parent from to
+// the end of to. It checks what Add checks, so a Platoon only moves under a
+// Brigade and nothing moves below itself; if the move fails, or child isn't
+// directly under from, both containers are left as they were. Moving a
+// soldier to the parent it already has does nothing.
+func Move(child, from, to Soldier) error {
+	if !isChild(from, child) {
+		return fmt.Errorf("%w: %s is not under %s", ErrNotFound, child.Name(), from.Name())
+	}
+	if from == to {
+		return nil
+	}
+	if err := to.Add(child); err != nil {
+		return err
+	}
+	// Add only takes soldiers of this package away from their old parent
+	if b := baseOf(from); b != nil && baseOf(child) == nil {
+		b.remove(child)
+	}
+	return nil
+}
+
+func isChild(parent, child Soldier) bool {
+	if baseOf(child) != nil {
+		return child.Parent() == parent
+	}
+	b := baseOf(parent)
+	if b == nil {
+		return false
+	}
+	for _, c := range b.snapshot() {
+		if c == child {
+			return true
+		}
+	}
+	return false
+}
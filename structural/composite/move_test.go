@@ -0,0 +1,97 @@
+package composite
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+// reorg is 1st: Alpha: 1st Platoon, 2nd Platoon: A; Bravo: 3rd Platoon.
+func reorg() (division *Division, alpha, bravo *Brigade, second *Platoon) {
+	second = NewPlatoon("2nd Platoon")
+	second.Add(NewSquad("A"))
+	alpha, bravo = NewBrigade("Alpha"), NewBrigade("Bravo")
+	alpha.Add(NewPlatoon("1st Platoon"), second)
+	bravo.Add(NewPlatoon("3rd Platoon"))
+	division = NewDivision("1st")
+	division.Add(alpha, bravo)
+	return division, alpha, bravo, second
+}
+
+func TestMove(t *testing.T) {
+	_, alpha, bravo, second := reorg()
+	if err := Move(second, alpha, bravo); err != nil {
+		t.Fatalf("Move() = %v", err)
+	}
+	if got := names(alpha.Children()); !slices.Equal(got, []string{"1st Platoon"}) {
+		t.Fatalf("Alpha has %v after the move", got)
+	}
+	if got := names(bravo.Children()); !slices.Equal(got, []string{"3rd Platoon", "2nd Platoon"}) {
+		t.Fatalf("Bravo has %v after the move", got)
+	}
+	if second.Parent() != Soldier(bravo) || second.Find("A") == nil {
+		t.Fatal("the platoon didn't move with its subtree")
+	}
+}
+
+func TestMoveToSameParent(t *testing.T) {
+	_, alpha, _, second := reorg()
+	if err := Move(second, alpha, alpha); err != nil {
+		t.Fatalf("Move() to the same parent = %v, want nil", err)
+	}
+	if got := names(alpha.Children()); !slices.Equal(got, []string{"1st Platoon", "2nd Platoon"}) {
+		t.Fatalf("Alpha has %v, want the order unchanged", got)
+	}
+}
+
+func TestMoveErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		move func(d *Division, alpha, bravo *Brigade, second *Platoon) error
+		want error
+	}{
+		{"not under from", func(d *Division, alpha, bravo *Brigade, second *Platoon) error {
+			return Move(second, bravo, d)
+		}, ErrNotFound},
+		{"wrong kind of parent", func(d *Division, alpha, bravo *Brigade, second *Platoon) error {
+			return Move(second, alpha, d)
+		}, ErrInvalidChild},
+		{"into itself", func(d *Division, alpha, bravo *Brigade, second *Platoon) error {
+			return Move(alpha, d, second.Find("A"))
+		}, ErrCycle},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			division, alpha, bravo, second := reorg()
+			before := division.Render()
+			if err := tt.move(division, alpha, bravo, second); !errors.Is(err, tt.want) {
+				t.Fatalf("Move() = %v, want %v", err, tt.want)
+			}
+			if got := division.Render(); got != before {
+				t.Fatalf("tree after a failed move =\n%s\nwant\n%s", got, before)
+			}
+		})
+	}
+}
+
+func TestMoveNotFoundMessage(t *testing.T) {
+	_, alpha, bravo, second := reorg()
+	want := "composite: soldier not found: 2nd Platoon is not under Bravo"
+	if err := Move(second, bravo, alpha); err == nil || err.Error() != want {
+		t.Fatalf("Move() = %v, want %q", err, want)
+	}
+}
+
+func TestMoveForeignSoldier(t *testing.T) {
+	first, second := NewSquad("A"), NewSquad("B")
+	first.Add(stranger{}, NewEnlisted("Kim"))
+	if err := Move(stranger{}, first, second); err != nil {
+		t.Fatalf("Move() = %v", err)
+	}
+	if got := names(first.Children()); !slices.Equal(got, []string{"Kim"}) {
+		t.Fatalf("A has %v after the move", got)
+	}
+	if got := second.Children(); len(got) != 1 || got[0] != Soldier(stranger{}) {
+		t.Fatalf("B has %v after the move, want the stranger", got)
+	}
+}
@@ -0,0 +1,52 @@
+package composite
+
+import "sync"
+
+// container is implemented by the package's units that have children.
+type container interface {
+	Soldier
+	briefing() string
+}
+
+// BriefParallel is Brief with children briefed concurrently, at most
+// workers at a time across the whole subtree; fewer than 1 means 1, which
+// briefs in the same order as Brief. Each container still announces
+// itself before any of its children hear the orders, but the order of
+// lines between siblings is not defined. Failures are collected as Brief collects them, in the order the
+// children were added.
+//
+// A child only gets a goroutine of its own while fewer than workers are
+// busy; otherwise the goroutine that got to it briefs it itself, so no
+// more than workers goroutines ever run briefings and none of them waits
+// for a free slot.
+func (u *unit) BriefParallel(orders string, workers int) error {
+	slots := make(chan struct{}, max(workers, 1)-1)
+	return briefParallel(u.self, orders, slots)
+}
+
+func briefParallel(s Soldier, orders string, slots chan struct{}) error {
+	c, ok := s.(container)
+	if !ok {
+		return s.Brief(orders)
+	}
+	u := baseOf(c)
+	u.sink().Println(c.briefing())
+	children := u.snapshot()
+	errs := make([]error, len(children))
+	var wg sync.WaitGroup
+	for i, child := range children {
+		select {
+		case slots <- struct{}{}:
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				defer func() { <-slots }()
+				errs[i] = briefParallel(child, orders, slots)
+			}()
+		default:
+			errs[i] = briefParallel(child, orders, slots)
+		}
+	}
+	wg.Wait()
+	return u.collect(children, errs)
+}
@@ -0,0 +1,97 @@
+package composite
+
+import (
+	"errors"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Antonious-Stewart/15-Most-Common-Design-Patterns/internal/output"
+)
+
+func TestBriefParallelMatchesBrief(t *testing.T) {
+	for _, workers := range []int{-1, 1, 2, 8} {
+		sequential, soldiers := briefTree()
+		soldiers["Kim"].SetAvailable(false)
+		want := output.NewRecorder()
+		sequential.out = want
+		wantErr := sequential.Brief("Advance")
+
+		parallel, soldiers := briefTree()
+		soldiers["Kim"].SetAvailable(false)
+		got := output.NewRecorder()
+		parallel.out = got
+		err := parallel.BriefParallel("Advance", workers)
+
+		if err == nil || err.Error() != wantErr.Error() || !errors.Is(err, ErrUnavailable) {
+			t.Fatalf("BriefParallel(%d) = %v, want %v", workers, err, wantErr)
+		}
+		lines, wantLines := got.Lines(), want.Lines()
+		if workers > 1 {
+			slices.Sort(lines)
+			slices.Sort(wantLines)
+		}
+		if !slices.Equal(lines, wantLines) {
+			t.Fatalf("BriefParallel(%d) printed %q, want %q", workers, lines, wantLines)
+		}
+	}
+}
+
+func TestBriefParallelAnnouncesFirst(t *testing.T) {
+	division, _ := briefTree()
+	log := output.NewRecorder()
+	division.out = log
+	if err := division.BriefParallel("Advance", 4); err != nil {
+		t.Fatalf("BriefParallel() = %v, want nil", err)
+	}
+	if first := log.Lines()[0]; first != "1st Division: Briefing 2 Brigades" {
+		t.Fatalf("first line = %q, want the division announcing itself", first)
+	}
+}
+
+// probe is a foreign soldier that records how many probes are being
+// briefed at once.
+type probe struct {
+	stranger
+	active, peak *atomic.Int32
+}
+
+func (p probe) Brief(string) error {
+	n := p.active.Add(1)
+	defer p.active.Add(-1)
+	for {
+		peak := p.peak.Load()
+		if n <= peak || p.peak.CompareAndSwap(peak, n) {
+			break
+		}
+	}
+	time.Sleep(5 * time.Millisecond)
+	return nil
+}
+
+func TestBriefParallelLimit(t *testing.T) {
+	for _, workers := range []int{1, 3} {
+		var active, peak atomic.Int32
+		division := NewDivision("1st", WithSink(output.NewRecorder()))
+		for range 3 {
+			platoon := NewPlatoon("1st Platoon")
+			for range 2 {
+				squad := NewSquad("A")
+				for range 3 {
+					squad.Add(probe{active: &active, peak: &peak})
+				}
+				platoon.Add(squad)
+			}
+			brigade := NewBrigade("Alpha")
+			brigade.Add(platoon)
+			division.Add(brigade)
+		}
+		if err := division.BriefParallel("Advance", workers); err != nil {
+			t.Fatalf("BriefParallel() = %v, want nil", err)
+		}
+		if got := peak.Load(); got != int32(workers) {
+			t.Fatalf("BriefParallel(%d) briefed %d at once, want %d", workers, got, workers)
+		}
+	}
+}
@@ -0,0 +1,225 @@
+package composite
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParent(t *testing.T) {
+	division := findTree()
+	if division.Parent() != nil {
+		t.Fatalf("root Parent() = %v, want nil", division.Parent())
+	}
+	if NewEnlisted("Lone").Parent() != nil {
+		t.Fatal("a soldier never added has a parent")
+	}
+	third := division.Find("3rd Platoon")
+	if third.Parent() != division.Find("Bravo") {
+		t.Fatalf("3rd Platoon's Parent() = %v, want Bravo", third.Parent())
+	}
+	kim := division.FindAll("Kim")[1]
+	var path []string
+	for s := Soldier(kim); s != nil; s = s.Parent() {
+		path = append([]string{s.Name()}, path...)
+	}
+	if got := len(path); got != 5 || path[0] != "1st" || path[3] != "B" {
+		t.Fatalf("walking up from Kim gave %q", path)
+	}
+}
+
+func TestDetach(t *testing.T) {
+	division := findTree()
+	alpha := division.Find("Alpha").(*Brigade)
+	platoon := alpha.Find("1st").(*Platoon)
+	before := division.Count()
+
+	if !platoon.Detach() {
+		t.Fatal("Detach() of an attached platoon = false")
+	}
+	if platoon.Parent() != nil || len(alpha.Children()) != 0 {
+		t.Fatal("Detach left the platoon in the brigade")
+	}
+	if platoon.Count() != 3 || division.Count() != before-3 {
+		t.Fatalf("counts after Detach: platoon %d, division %d", platoon.Count(), division.Count())
+	}
+	if platoon.Detach() {
+		t.Fatal("Detach() of a root = true")
+	}
+
+	// re-attaching elsewhere
+	bravo := division.Find("Bravo").(*Brigade)
+	bravo.Add(platoon)
+	if platoon.Parent() != Soldier(bravo) || division.Count() != before {
+		t.Fatal("re-attached platoon is not under Bravo")
+	}
+}
+
+func TestAddMovesAttachedSoldiers(t *testing.T) {
+	division := findTree()
+	alpha, bravo := division.Find("Alpha").(*Brigade), division.Find("Bravo").(*Brigade)
+	squad := division.Find("A").(*Squad)
+	third := bravo.Find("3rd Platoon").(*Platoon)
+
+	third.Add(squad)
+	if squad.Parent() != Soldier(third) {
+		t.Fatalf("moved squad's Parent() = %v, want 3rd Platoon", squad.Parent())
+	}
+	if alpha.Find("A") != nil || len(division.FindAll("A")) != 1 {
+		t.Fatal("the squad is still under its old platoon")
+	}
+}
+
+func TestParentAfterRemove(t *testing.T) {
+	division := findTree()
+	alpha := division.Find("Alpha").(*Brigade)
+	squad := division.Find("A").(*Squad)
+	ortiz := squad.Find("Ortiz").(*Enlisted)
+
+	division.RemoveRecursive(ortiz)
+	squad.RemoveByName("Kim")
+	division.Remove(alpha)
+	for _, s := range []Soldier{ortiz, alpha} {
+		if s.Parent() != nil {
+			t.Fatalf("%s still has parent %v after removal", s.Name(), s.Parent())
+		}
+	}
+	if squad.Parent() == nil || squad.Parent().Parent() != Soldier(alpha) {
+		t.Fatal("removing Alpha broke the links inside its subtree")
+	}
+	for _, s := range append(squad.Children(), alpha.Children()...) {
+		if s.Parent() == nil {
+			t.Fatalf("%s lost its parent", s.Name())
+		}
+	}
+}
+
+func TestAddRejectsCycles(t *testing.T) {
+	tests := []struct {
+		name  string
+		to    string
+		child string // empty for the division
+		want  string
+	}{
+		{"self", "Alpha", "Alpha", "composite: adding Alpha to Alpha: composite: soldier would contain itself"},
+		{"direct", "Alpha", "", "composite: adding 1st to Alpha: composite: soldier would contain itself"},
+		{"three levels deep", "A", "", "composite: adding 1st to A: composite: soldier would contain itself"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			division := findTree()
+			to := division.Find(tt.to)
+			child := Soldier(division)
+			if tt.child != "" {
+				child = division.Find(tt.child)
+			}
+			before := division.Render()
+
+			err := to.Add(stranger{}, child)
+			if !errors.Is(err, ErrCycle) || err.Error() != tt.want {
+				t.Fatalf("Add() = %v, want %q", err, tt.want)
+			}
+			if after := division.Render(); after != before {
+				t.Fatalf("tree after a rejected Add =\n%s\nwant it unchanged\n%s", after, before)
+			}
+		})
+	}
+}
+
+func TestAddWithinTreeStillAllowed(t *testing.T) {
+	division := findTree()
+	alpha, bravo := division.Find("Alpha").(*Brigade), division.Find("Bravo").(*Brigade)
+	first := alpha.Find("1st").(*Platoon)
+
+	if err := bravo.Add(first); err != nil {
+		t.Fatalf("moving a platoon to its uncle: Add() = %v", err)
+	}
+	if err := first.Find("A").Add(NewEnlisted("Lee")); err != nil {
+		t.Fatalf("adding a new soldier: Add() = %v", err)
+	}
+	if err := alpha.Add(bravo.Find("3rd Platoon")); err != nil {
+		t.Fatalf("moving a platoon back: Add() = %v", err)
+	}
+	if got := division.Count(); got != 4 {
+		t.Fatalf("Count() = %d after the moves, want 4", got)
+	}
+}
+
+func TestAddEnforcesHierarchy(t *testing.T) {
+	makers := map[string]func() Soldier{
+		"Division": func() Soldier { return NewDivision("d") },
+		"Brigade":  func() Soldier { return NewBrigade("b") },
+		"Platoon":  func() Soldier { return NewPlatoon("p") },
+		"Squad":    func() Soldier { return NewSquad("s") },
+		"Enlisted": func() Soldier { return NewEnlisted("e") },
+	}
+	for _, parent := range []string{"Division", "Brigade", "Platoon", "Squad"} {
+		for child, makeChild := range makers {
+			t.Run(parent+"/"+child, func(t *testing.T) {
+				p, c := makers[parent](), makeChild()
+				err := p.Add(c)
+				if child == childRanks[parent] {
+					if err != nil || c.Parent() != p {
+						t.Fatalf("Add() = %v, want %s attached", err, child)
+					}
+					return
+				}
+				var invalid *InvalidChildError
+				if !errors.As(err, &invalid) || !errors.Is(err, ErrInvalidChild) || invalid.Child != c {
+					t.Fatalf("Add() = %v, want an InvalidChildError", err)
+				}
+				if c.Parent() != nil || p.Count() != 0 {
+					t.Fatalf("rejected %s was attached", child)
+				}
+			})
+		}
+	}
+}
+
+func TestEnlistedTakesNoChildren(t *testing.T) {
+	kim := NewEnlisted("Kim")
+	for _, child := range []Soldier{NewEnlisted("Lee"), NewSquad("A"), NewDivision("1st"), stranger{}} {
+		err := kim.Add(child)
+		var invalid *InvalidChildError
+		if !errors.As(err, &invalid) || !errors.Is(err, ErrInvalidChild) || invalid.Child != child {
+			t.Fatalf("Add(%s) = %v, want an InvalidChildError", child.Name(), err)
+		}
+		if child.Parent() != nil || kim.Count() != 1 {
+			t.Fatalf("rejected %s was attached", child.Name())
+		}
+	}
+	if err := kim.Add(NewSquad("A")); err.Error() != "composite: Enlisted Kim takes no soldiers, not Squad A" {
+		t.Fatalf("Add() = %q", err)
+	}
+	if err := kim.Add(stranger{}); err.Error() != "composite: Enlisted Kim takes no soldiers, not stranger" {
+		t.Fatalf("Add(foreign) = %q", err)
+	}
+	if err := kim.Add(); err != nil {
+		t.Fatalf("Add() with nobody = %v", err)
+	}
+}
+
+func TestAddRejectsTheWholeCall(t *testing.T) {
+	squad := NewSquad("A")
+	err := squad.Add(NewEnlisted("Ortiz"), NewPlatoon("1st"), NewEnlisted("Kim"))
+	want := "composite: Squad A takes Enlisted soldiers, not Platoon 1st"
+	if err == nil || err.Error() != want {
+		t.Fatalf("Add() = %v, want %q", err, want)
+	}
+	if n := len(squad.Children()); n != 0 {
+		t.Fatalf("squad has %d children after a rejected Add, want 0", n)
+	}
+}
+
+func TestAddAcceptsForeignAndRelaxed(t *testing.T) {
+	if err := NewDivision("1st").Add(stranger{}); err != nil {
+		t.Fatalf("adding a foreign soldier: Add() = %v", err)
+	}
+	squad := NewSquad("A", Relaxed())
+	if err := squad.Add(NewDivision("oops"), NewEnlisted("Kim")); err != nil {
+		t.Fatalf("relaxed squad: Add() = %v", err)
+	}
+	// the relaxed squad's children still follow the rules
+	if err := squad.Children()[0].Add(NewSquad("B")); !errors.Is(err, ErrInvalidChild) {
+		t.Fatalf("Add() below a relaxed unit = %v, want %v", err, ErrInvalidChild)
+	}
+}
@@ -0,0 +1,91 @@
+package composite
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var ErrBadPath = errors.New("composite: malformed path")
+
+var nameEscaper = strings.NewReplacer(`\`, `\\`, "/", `\/`)
+
+// FindByPath looks a soldier up by the names leading to it from this unit,
+// separated by "/", such as "1st Division/Alpha Brigade/2nd Platoon/Kim".
+// The first name is the unit's own. A "/" or "\" that is part of a name is
+// written with a "\" in front of it; JoinPath does that. Where two children
+// share a name the first one added is taken. A name that can't be found
+// fails with ErrNotFound, and going on past an enlisted soldier with
+// ErrNotAUnit; both errors say where the lookup stopped.
+func (u *unit) FindByPath(path string) (Soldier, error) {
+	names, err := splitPath(path)
+	if err != nil {
+		return nil, err
+	}
+	if names[0] != u.name {
+		return nil, fmt.Errorf("%w: path starts at %q, not at %s", ErrNotFound, names[0], u.name)
+	}
+	at := u.self
+	for i, name := range names[1:] {
+		parent, ok := at.(interface{ Children() []Soldier })
+		if !ok {
+			return nil, fmt.Errorf("%w: no %q under %s", ErrNotAUnit, name, JoinPath(names[:i+1]...))
+		}
+		var next Soldier
+		for _, child := range parent.Children() {
+			if child.Name() == name {
+				next = child
+				break
+			}
+		}
+		if next == nil {
+			return nil, fmt.Errorf("%w: no %q under %s", ErrNotFound, name, JoinPath(names[:i+1]...))
+		}
+		at = next
+	}
+	return at, nil
+}
+
+// JoinPath is the path FindByPath follows through names, with "/" and "\"
+// inside a name escaped.
+func JoinPath(names ...string) string {
+	escaped := make([]string, len(names))
+	for i, name := range names {
+		escaped[i] = nameEscaper.Replace(name)
+	}
+	return strings.Join(escaped, "/")
+}
+
+// splitPath undoes JoinPath.
+func splitPath(path string) ([]string, error) {
+	names := make([]string, 0)
+	var name strings.Builder
+	escaped := false
+	for i, r := range path {
+		switch {
+		case escaped:
+			if r != '/' && r != '\\' {
+				return nil, fmt.Errorf("%w: %q can't be escaped, at %d", ErrBadPath, r, i)
+			}
+			name.WriteRune(r)
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == '/':
+			names = append(names, name.String())
+			name.Reset()
+		default:
+			name.WriteRune(r)
+		}
+	}
+	if escaped {
+		return nil, fmt.Errorf("%w: %q ends in an escape", ErrBadPath, path)
+	}
+	names = append(names, name.String())
+	for i, name := range names {
+		if name == "" {
+			return nil, fmt.Errorf("%w: name %d of %q is empty", ErrBadPath, i+1, path)
+		}
+	}
+	return names, nil
+}
@@ -0,0 +1,78 @@
+package composite
+
+import (
+	"errors"
+	"testing"
+)
+
+// pathTree is 1st Division: Alpha Brigade: 2nd Platoon: A/B: Kim, Lee.
+func pathTree() *Division {
+	squad := NewSquad("A/B")
+	squad.Add(NewEnlisted("Kim"), NewEnlisted(`Lee\Jr`))
+	platoon := NewPlatoon("2nd Platoon")
+	platoon.Add(squad)
+	brigade := NewBrigade("Alpha Brigade")
+	brigade.Add(NewPlatoon("1st Platoon"), platoon)
+	division := NewDivision("1st Division")
+	division.Add(brigade)
+	return division
+}
+
+func TestFindByPath(t *testing.T) {
+	division := pathTree()
+	for path, want := range map[string]string{
+		"1st Division":                                                            "1st Division",
+		"1st Division/Alpha Brigade":                                              "Alpha Brigade",
+		"1st Division/Alpha Brigade/2nd Platoon":                                  "2nd Platoon",
+		`1st Division/Alpha Brigade/2nd Platoon/A\/B`:                             "A/B",
+		`1st Division/Alpha Brigade/2nd Platoon/A\/B/Kim`:                         "Kim",
+		JoinPath("1st Division", "Alpha Brigade", "2nd Platoon", "A/B", `Lee\Jr`): `Lee\Jr`,
+	} {
+		got, err := division.FindByPath(path)
+		if err != nil || got.Name() != want {
+			t.Fatalf("FindByPath(%q) = %v, %v, want %s", path, got, err, want)
+		}
+	}
+
+	platoon, _ := division.FindByPath("1st Division/Alpha Brigade/2nd Platoon")
+	if kim, err := platoon.(*Platoon).FindByPath(`2nd Platoon/A\/B/Kim`); err != nil || kim != division.Find("Kim") {
+		t.Fatalf("FindByPath() from a platoon = %v, %v, want Kim", kim, err)
+	}
+}
+
+func TestFindByPathErrors(t *testing.T) {
+	tests := []struct {
+		path string
+		want error
+		msg  string
+	}{
+		{"2nd Division/Alpha Brigade", ErrNotFound, `composite: soldier not found: path starts at "2nd Division", not at 1st Division`},
+		{"1st Division/Bravo Brigade/2nd Platoon", ErrNotFound, `composite: soldier not found: no "Bravo Brigade" under 1st Division`},
+		{"1st Division/Alpha Brigade/2nd Platoon/A", ErrNotFound, `composite: soldier not found: no "A" under 1st Division/Alpha Brigade/2nd Platoon`},
+		{`1st Division/Alpha Brigade/2nd Platoon/A\/B/Kim/Lee`, ErrNotAUnit, `composite: enlisted soldiers have no children: no "Lee" under 1st Division/Alpha Brigade/2nd Platoon/A\/B/Kim`},
+		{"", ErrBadPath, `composite: malformed path: name 1 of "" is empty`},
+		{"1st Division//Alpha Brigade", ErrBadPath, `composite: malformed path: name 2 of "1st Division//Alpha Brigade" is empty`},
+		{`1st Division\`, ErrBadPath, `composite: malformed path: "1st Division\\" ends in an escape`},
+		{`1st\ Division`, ErrBadPath, `composite: malformed path: ' ' can't be escaped, at 4`},
+	}
+	division := pathTree()
+	for _, tt := range tests {
+		s, err := division.FindByPath(tt.path)
+		if s != nil || !errors.Is(err, tt.want) || err.Error() != tt.msg {
+			t.Fatalf("FindByPath(%q) = %v, %v, want %q", tt.path, s, err, tt.msg)
+		}
+	}
+}
+
+func TestJoinPathRoundTrip(t *testing.T) {
+	names := []string{"a/b", `c\d`, `\/`, "plain"}
+	got, err := splitPath(JoinPath(names...))
+	if err != nil || len(got) != len(names) {
+		t.Fatalf("splitPath(JoinPath()) = %q, %v", got, err)
+	}
+	for i := range names {
+		if got[i] != names[i] {
+			t.Fatalf("splitPath(JoinPath()) = %q, want %q", got, names)
+		}
+	}
+}
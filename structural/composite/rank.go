@@ -0,0 +1,91 @@
+package composite
+
+// Rank is a soldier's rank, from Private up. Soldiers are Unranked until
+// given one.
+type Rank int
+
+const (
+	Unranked Rank = iota
+	Private
+	Corporal
+	Sergeant
+	Lieutenant
+	Captain
+	Major
+	Colonel
+	General
+)
+
+func (r Rank) String() string {
+	switch r {
+	case Unranked:
+		return "Unranked"
+	case Private:
+		return "Private"
+	case Corporal:
+		return "Corporal"
+	case Sergeant:
+		return "Sergeant"
+	case Lieutenant:
+		return "Lieutenant"
+	case Captain:
+		return "Captain"
+	case Major:
+		return "Major"
+	case Colonel:
+		return "Colonel"
+	case General:
+		return "General"
+	}
+	return "Rank(unknown)"
+}
+
+// Officer reports whether r is Lieutenant or above.
+func (r Rank) Officer() bool {
+	return r >= Lieutenant
+}
+
+// WithRank gives the soldier, or the officer leading the unit, rank r.
+func WithRank(r Rank) UnitOption {
+	return func(u *unit) {
+		u.rank = r
+	}
+}
+
+func (u *unit) Rank() Rank {
+	return u.rank
+}
+
+func (u *unit) SetRank(r Rank) {
+	u.rank = r
+}
+
+// BriefWhere is Brief for the soldiers pred picks: an enlisted soldier, or
+// one from another package, hears the orders only if pred returns true for
+// it, and a unit only announces itself if pred returns true for it. Every
+// unit is gone through either way, so a pick deep in the tree is still
+// reached when the units above it aren't picked. Failures are collected as
+// Brief collects them.
+func (u *unit) BriefWhere(orders string, pred func(Soldier) bool) error {
+	return briefWhere(u.self, orders, pred)
+}
+
+func briefWhere(s Soldier, orders string, pred func(Soldier) bool) error {
+	c, ok := s.(container)
+	if !ok {
+		if !pred(s) {
+			return nil
+		}
+		return s.Brief(orders)
+	}
+	u := baseOf(c)
+	if pred(s) {
+		u.sink().Println(c.briefing())
+	}
+	children := u.snapshot()
+	errs := make([]error, len(children))
+	for i, child := range children {
+		errs[i] = briefWhere(child, orders, pred)
+	}
+	return u.collect(children, errs)
+}
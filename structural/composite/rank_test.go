@@ -0,0 +1,91 @@
+package composite
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/Antonious-Stewart/15-Most-Common-Design-Patterns/internal/output"
+)
+
+// rankedTree is a platoon led by a lieutenant with squads A (Sergeant
+// Ortiz, Private Kim) and B (Captain Lee, Private Park), every soldier
+// printing behind their name.
+func rankedTree(log *output.Recorder) (*Platoon, map[string]*Enlisted) {
+	soldiers := make(map[string]*Enlisted)
+	enlist := func(name string, r Rank) *Enlisted {
+		soldiers[name] = NewEnlisted(name, WithRank(r), WithSink(output.NewPrefixed(name+": ", log)))
+		return soldiers[name]
+	}
+	a, b := NewSquad("A", WithRank(Sergeant)), NewSquad("B")
+	a.Add(enlist("Ortiz", Sergeant), enlist("Kim", Private))
+	b.Add(enlist("Lee", Captain), enlist("Park", Private))
+	platoon := NewPlatoon("1st", WithRank(Lieutenant), WithSink(log))
+	platoon.Add(a, b)
+	return platoon, soldiers
+}
+
+func TestBriefWhereRank(t *testing.T) {
+	tests := []struct {
+		name string
+		pred func(Soldier) bool
+		want []string
+	}{
+		{"privates", func(s Soldier) bool { return s.Rank() == Private }, []string{"Kim: Hold", "Park: Hold"}},
+		{"officers", func(s Soldier) bool { return s.Rank().Officer() }, []string{"1st: Briefing 2 Squads", "Lee: Hold"}},
+		{"sergeants", func(s Soldier) bool { return s.Rank() == Sergeant }, []string{"A: Briefing 2 Enlistees", "Ortiz: Hold"}},
+		{"nobody", func(Soldier) bool { return false }, []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			log := output.NewRecorder()
+			platoon, _ := rankedTree(log)
+			if err := platoon.BriefWhere("Hold", tt.pred); err != nil {
+				t.Fatal(err)
+			}
+			if got := log.Lines(); !slices.Equal(got, tt.want) {
+				t.Fatalf("briefed %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBriefWhereSeesEverySoldier(t *testing.T) {
+	platoon, soldiers := rankedTree(output.NewRecorder())
+	soldiers["Park"].SetAvailable(false)
+	seen := make([]string, 0)
+	err := platoon.BriefWhere("Hold", func(s Soldier) bool {
+		seen = append(seen, s.Name())
+		return true
+	})
+	if want := []string{"1st", "A", "Ortiz", "Kim", "B", "Lee", "Park"}; !slices.Equal(seen, want) {
+		t.Fatalf("pred saw %v, want %v", seen, want)
+	}
+	if !errors.Is(err, ErrUnavailable) || err.Error() != "1st > B > Park: composite: soldier unavailable" {
+		t.Fatalf("BriefWhere() = %v, want Park unavailable", err)
+	}
+}
+
+func TestRank(t *testing.T) {
+	kim := NewEnlisted("Kim")
+	if kim.Rank() != Unranked {
+		t.Fatalf("Rank() = %v, want %v", kim.Rank(), Unranked)
+	}
+	kim.SetRank(Corporal)
+	if got := kim.Clone().Rank(); got != Corporal {
+		t.Fatalf("cloned Rank() = %v, want %v", got, Corporal)
+	}
+	var officers []Rank
+	for r := Unranked; r <= General; r++ {
+		if r.Officer() {
+			officers = append(officers, r)
+		}
+	}
+	if want := []Rank{Lieutenant, Captain, Major, Colonel, General}; !reflect.DeepEqual(officers, want) {
+		t.Fatalf("officers = %v, want %v", officers, want)
+	}
+	if got := Rank(42).String(); got != "Rank(unknown)" {
+		t.Fatalf("Rank(42).String() = %q", got)
+	}
+}
@@ -0,0 +1,54 @@
+package composite
+
+// Readiness is a roll call of the enlisted soldiers in a subtree. ByUnit
+// breaks a unit's count down by the units directly under it, each with its
+// own breakdown; it is nil for an enlisted soldier.
+type Readiness struct {
+	Ready, NotReady, Total int
+	ByUnit                 map[string]Readiness
+}
+
+// plus is r and other counted together. Units of the same name in both are
+// combined.
+func (r Readiness) plus(other Readiness) Readiness {
+	sum := Readiness{
+		Ready:    r.Ready + other.Ready,
+		NotReady: r.NotReady + other.NotReady,
+		Total:    r.Total + other.Total,
+	}
+	if r.ByUnit == nil && other.ByUnit == nil {
+		return sum
+	}
+	sum.ByUnit = make(map[string]Readiness, len(r.ByUnit)+len(other.ByUnit))
+	for _, byUnit := range []map[string]Readiness{r.ByUnit, other.ByUnit} {
+		for name, child := range byUnit {
+			sum.ByUnit[name] = sum.ByUnit[name].plus(child)
+		}
+	}
+	return sum
+}
+
+// Report adds up what every soldier below the unit reports. Units that
+// share a name under the same parent are combined in ByUnit.
+func (u *unit) Report() Readiness {
+	r := Readiness{ByUnit: make(map[string]Readiness)}
+	for _, child := range u.snapshot() {
+		report := child.Report()
+		r.Ready += report.Ready
+		r.NotReady += report.NotReady
+		r.Total += report.Total
+		if _, ok := child.(container); ok {
+			r.ByUnit[child.Name()] = r.ByUnit[child.Name()].plus(report)
+		}
+	}
+	return r
+}
+
+// Report counts the soldier as ready unless SetAvailable(false) marked it
+// otherwise.
+func (e *Enlisted) Report() Readiness {
+	if e.unavailable {
+		return Readiness{NotReady: 1, Total: 1}
+	}
+	return Readiness{Ready: 1, Total: 1}
+}
@@ -0,0 +1,67 @@
+package composite
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestReport(t *testing.T) {
+	division, soldiers := briefTree()
+	soldiers["Ortiz"].SetAvailable(false)
+	soldiers["Kim"].SetAvailable(false)
+	soldiers["Park"].SetAvailable(false)
+
+	tests := []struct {
+		name string
+		s    Soldier
+		want Readiness
+	}{
+		{"enlisted", soldiers["Lee"], Readiness{Ready: 1, Total: 1}},
+		{"squad", division.Find("A"), Readiness{NotReady: 2, Total: 2, ByUnit: map[string]Readiness{}}},
+		{"brigade", division.Find("2nd Brigade"), Readiness{
+			Ready: 1, NotReady: 1, Total: 2,
+			ByUnit: map[string]Readiness{"1st Platoon": {
+				Ready: 1, NotReady: 1, Total: 2,
+				ByUnit: map[string]Readiness{"B": {Ready: 1, NotReady: 1, Total: 2, ByUnit: map[string]Readiness{}}},
+			}},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.s.Report(); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("Report() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	r := division.Report()
+	if r.Ready != 1 || r.NotReady != 3 || r.Total != 4 {
+		t.Fatalf("division Report() = %+v, want 1 of 4 ready", r)
+	}
+	if got := r.ByUnit["1st Brigade"].ByUnit["1st Platoon"].ByUnit["A"]; got.NotReady != 2 || got.Ready != 0 {
+		t.Fatalf("squad A under the division = %+v, want both not ready", got)
+	}
+	soldiers["Kim"].SetAvailable(true)
+	if r := division.Report(); r.Ready != 2 || r.ByUnit["1st Brigade"].Ready != 1 {
+		t.Fatalf("Report() after Kim came back = %+v", r)
+	}
+}
+
+func TestReportCombinesNamesakes(t *testing.T) {
+	a, b := NewSquad("A"), NewSquad("A")
+	a.Add(NewEnlisted("Ortiz"), NewEnlisted("Kim"))
+	kim := NewEnlisted("Kim")
+	kim.SetAvailable(false)
+	b.Add(kim)
+	platoon := NewPlatoon("1st")
+	platoon.Add(a, b, stranger{})
+
+	r := platoon.Report()
+	want := Readiness{Ready: 2, NotReady: 1, Total: 3, ByUnit: map[string]Readiness{}}
+	if got := r.ByUnit["A"]; !reflect.DeepEqual(got, want) || len(r.ByUnit) != 1 {
+		t.Fatalf("ByUnit = %+v, want both squads as one A", r.ByUnit)
+	}
+	if r.Ready != 3 || r.Total != 4 {
+		t.Fatalf("Report() = %+v, want the stranger counted", r)
+	}
+}
@@ -0,0 +1,57 @@
+package composite
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+// Render draws the unit's subtree with two spaces of indentation per level:
+//
+//	Division: 1st
+//	  Brigade: Alpha
+//	    Platoon: 1
+//	      Squad: A
+//	        Enlisted: Jones
+//
+// Every line ends in a newline.
+func (u *unit) Render() string {
+	var b strings.Builder
+	u.PrintTree(&b)
+	return b.String()
+}
+
+// PrintTree writes what Render returns to w.
+func (u *unit) PrintTree(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	u.Walk(func(s Soldier, depth int) error {
+		for range depth {
+			bw.WriteString("  ")
+		}
+		if rank := rankOf(s); rank != "" {
+			bw.WriteString(rank)
+			bw.WriteString(": ")
+		}
+		bw.WriteString(s.Name())
+		return bw.WriteByte('\n')
+	})
+	return bw.Flush()
+}
+
+// rankOf names the type of the package's own units. Soldiers from other
+// packages have no rank.
+func rankOf(s Soldier) string {
+	switch s.(type) {
+	case *Division:
+		return "Division"
+	case *Brigade:
+		return "Brigade"
+	case *Platoon:
+		return "Platoon"
+	case *Squad:
+		return "Squad"
+	case *Enlisted:
+		return "Enlisted"
+	}
+	return ""
+}
@@ -0,0 +1,104 @@
+package composite
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestRender(t *testing.T) {
+	division := NewDivision("1st")
+	alpha := NewBrigade("Alpha")
+	platoon := NewPlatoon("1")
+	squad := NewSquad("A")
+	squad.Add(NewEnlisted("Jones"), &outpost{name: "Outpost"})
+	platoon.Add(squad, NewSquad("B"))
+	alpha.Add(platoon)
+	division.Add(alpha, NewBrigade("Bravo"))
+
+	want := `Division: 1st
+  Brigade: Alpha
+    Platoon: 1
+      Squad: A
+        Enlisted: Jones
+        Outpost
+      Squad: B
+  Brigade: Bravo
+`
+	if got := division.Render(); got != want {
+		t.Fatalf("Render() =\n%s\nwant\n%s", got, want)
+	}
+	if got, want := platoon.Render(), "Platoon: 1\n  Squad: A\n    Enlisted: Jones\n    Outpost\n  Squad: B\n"; got != want {
+		t.Fatalf("Render() of a subtree = %q, want %q", got, want)
+	}
+	if got := NewDivision("empty").Render(); got != "Division: empty\n" {
+		t.Fatalf("Render() of an empty division = %q", got)
+	}
+	if got := NewEnlisted("Kim").Render(); got != "Enlisted: Kim\n" {
+		t.Fatalf("Render() of an enlisted soldier = %q", got)
+	}
+}
+
+func TestRenderDeepTree(t *testing.T) {
+	// containers whose children are containers, as deep as the tree goes
+	root := NewPlatoon("0", Relaxed())
+	parent := root
+	for i := 1; i < 50; i++ {
+		child := NewPlatoon(fmt.Sprint(i), Relaxed())
+		parent.Add(child)
+		parent = child
+	}
+	var want bytes.Buffer
+	for i := range 50 {
+		fmt.Fprintf(&want, "%*sPlatoon: %d\n", 2*i, "", i)
+	}
+	if got := root.Render(); got != want.String() {
+		t.Fatalf("Render() of a 50 level tree =\n%s", got)
+	}
+}
+
+type brokenWriter struct{}
+
+func (brokenWriter) Write([]byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+func TestPrintTree(t *testing.T) {
+	squad := NewSquad("A")
+	squad.Add(NewEnlisted("Jones"))
+	var buf bytes.Buffer
+	if err := squad.PrintTree(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != squad.Render() {
+		t.Fatalf("PrintTree() wrote %q, want %q", buf.String(), squad.Render())
+	}
+	if err := squad.PrintTree(brokenWriter{}); err == nil {
+		t.Fatal("PrintTree() to a broken writer = nil")
+	}
+}
+
+func BenchmarkRender(b *testing.B) {
+	division := NewDivision("1st")
+	for i := range 4 {
+		brigade := NewBrigade(fmt.Sprint("Brigade ", i))
+		for j := range 5 {
+			platoon := NewPlatoon(fmt.Sprint("Platoon ", j))
+			for k := range 10 {
+				squad := NewSquad(fmt.Sprint("Squad ", k))
+				for l := range 10 {
+					squad.Add(NewEnlisted(fmt.Sprint("Private ", l)))
+				}
+				platoon.Add(squad)
+			}
+			brigade.Add(platoon)
+		}
+		division.Add(brigade)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		division.Render()
+	}
+}
@@ -0,0 +1,41 @@
+package composite
+
+import "slices"
+
+// RosterEntry is an enlisted soldier and the names of the units above it,
+// from the unit the roster was taken of down to the soldier's own.
+type RosterEntry struct {
+	Name string
+	Path []string
+}
+
+// String is the entry as a path, e.g. "1st Division/Alpha/2nd/B/Jones".
+func (e RosterEntry) String() string {
+	return JoinPath(append(slices.Clip(e.Path), e.Name)...)
+}
+
+// Roster lists every enlisted soldier in the subtree, depth first in the
+// order they were added, wherever they were added: one under a platoon
+// simply has a shorter path. An enlisted soldier's roster is itself with
+// an empty path.
+func (u *unit) Roster() []RosterEntry {
+	roster := make([]RosterEntry, 0)
+	var visit func(s Soldier, path []string)
+	visit = func(s Soldier, path []string) {
+		if e, ok := s.(*Enlisted); ok {
+			roster = append(roster, RosterEntry{Name: e.name, Path: slices.Clone(path)})
+			return
+		}
+		c, ok := s.(container)
+		if !ok {
+			return
+		}
+		base := baseOf(c)
+		below := append(path, base.name)
+		for _, child := range base.snapshot() {
+			visit(child, below)
+		}
+	}
+	visit(u.self, []string{})
+	return roster
+}
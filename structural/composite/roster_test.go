@@ -0,0 +1,49 @@
+package composite
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRoster(t *testing.T) {
+	squad := NewSquad("B")
+	squad.Add(NewEnlisted("Jones"), NewEnlisted("Lee"))
+	platoon := NewPlatoon("2nd", Relaxed())
+	platoon.Add(squad, NewEnlisted("Runner"), stranger{})
+	alpha := NewBrigade("Alpha")
+	alpha.Add(platoon)
+	division := NewDivision("1st Division", Relaxed())
+	division.Add(NewEnlisted("Aide"), alpha, NewBrigade("Bravo"))
+
+	want := []RosterEntry{
+		{Name: "Aide", Path: []string{"1st Division"}},
+		{Name: "Jones", Path: []string{"1st Division", "Alpha", "2nd", "B"}},
+		{Name: "Lee", Path: []string{"1st Division", "Alpha", "2nd", "B"}},
+		{Name: "Runner", Path: []string{"1st Division", "Alpha", "2nd"}},
+	}
+	roster := division.Roster()
+	if !reflect.DeepEqual(roster, want) {
+		t.Fatalf("Roster() = %q, want %q", roster, want)
+	}
+	if got := roster[1].String(); got != "1st Division/Alpha/2nd/B/Jones" {
+		t.Fatalf("String() = %q", got)
+	}
+	// paths don't share storage
+	roster[1].Path[3] = "changed"
+	if roster[2].Path[3] != "B" {
+		t.Fatal("Roster entries share a path")
+	}
+
+	if got := squad.Roster(); len(got) != 2 || !reflect.DeepEqual(got[0].Path, []string{"B"}) {
+		t.Fatalf("squad Roster() = %q", got)
+	}
+	if got := NewEnlisted("Solo").Roster(); !reflect.DeepEqual(got, []RosterEntry{{Name: "Solo", Path: []string{}}}) {
+		t.Fatalf("enlisted Roster() = %q", got)
+	}
+}
+
+func TestRosterEmpty(t *testing.T) {
+	if got := NewDivision("1st").Roster(); got == nil || len(got) != 0 {
+		t.Fatalf("empty division Roster() = %#v, want an empty roster", got)
+	}
+}
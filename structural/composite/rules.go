@@ -0,0 +1,127 @@
+package composite
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrInvalidChild     = errors.New("composite: wrong kind of child")
+	ErrCapacityExceeded = errors.New("composite: unit is full")
+	ErrDuplicateName    = errors.New("composite: name already used in the unit")
+)
+
+// childRanks says what each container takes: Divisions take Brigades,
+// Brigades take Platoons, Platoons take Squads and Squads take Enlisted.
+var childRanks = map[string]string{
+	"Division": "Brigade",
+	"Brigade":  "Platoon",
+	"Platoon":  "Squad",
+	"Squad":    "Enlisted",
+}
+
+// InvalidChildError is an Add that broke the hierarchy rules. It matches
+// ErrInvalidChild with errors.Is.
+type InvalidChildError struct {
+	Parent, Child Soldier
+}
+
+func (e *InvalidChildError) Error() string {
+	parent := rankOf(e.Parent)
+	// soldiers from other packages have no rank to show
+	child := strings.TrimSpace(rankOf(e.Child) + " " + e.Child.Name())
+	takes, ok := childRanks[parent]
+	if !ok {
+		return fmt.Sprintf("composite: %s %s takes no soldiers, not %s", parent, e.Parent.Name(), child)
+	}
+	return fmt.Sprintf("composite: %s %s takes %s soldiers, not %s", parent, e.Parent.Name(), takes, child)
+}
+
+func (e *InvalidChildError) Unwrap() error {
+	return ErrInvalidChild
+}
+
+// Relaxed lets the unit take children of any kind, for free-form trees.
+// It only applies to the unit it is given to, not to the units below it.
+func Relaxed() UnitOption {
+	return func(u *unit) {
+		u.relaxed = true
+	}
+}
+
+// WithCapacity limits the unit to max direct children. Zero or less means
+// no limit, which is the default. An Add that would take the unit past max
+// adds nobody, even if some of the soldiers would have fit.
+func WithCapacity(max int) UnitOption {
+	return func(u *unit) {
+		u.capacity = max
+	}
+}
+
+// fits reports whether u has room for children; soldiers that are already
+// its children only change places and take no extra room.
+func (u *unit) fits(children []Soldier) error {
+	if u.capacity <= 0 {
+		return nil
+	}
+	n := len(u.children)
+	for _, child := range children {
+		if c := baseOf(child); c == nil || c.parent != u.self {
+			n++
+		}
+	}
+	if n > u.capacity {
+		return fmt.Errorf("composite: adding %d to %s, which has %d of %d: %w",
+			len(children), u.name, len(u.children), u.capacity, ErrCapacityExceeded)
+	}
+	return nil
+}
+
+// WithUniqueNames makes Add fail with ErrDuplicateName, adding nobody, when
+// a soldier would get the same name as another direct child of the unit,
+// one already there or one added in the same call. Only the unit's own
+// children are checked: two divisions can each have an Alpha brigade.
+func WithUniqueNames() UnitOption {
+	return func(u *unit) {
+		u.unique = true
+	}
+}
+
+// distinct reports whether children can join u without two of its children
+// sharing a name.
+func (u *unit) distinct(children []Soldier) error {
+	if !u.unique {
+		return nil
+	}
+	taken := make(map[string]Soldier, len(u.children)+len(children))
+	for _, child := range u.children {
+		taken[nameUnlocked(child)] = child
+	}
+	for _, child := range children {
+		name := nameUnlocked(child)
+		if other, ok := taken[name]; ok && other != child {
+			return fmt.Errorf("composite: adding %s to %s: %w", name, u.name, ErrDuplicateName)
+		}
+		taken[name] = child
+	}
+	return nil
+}
+
+// nameUnlocked is Name for use with structure locked.
+func nameUnlocked(s Soldier) string {
+	if u := baseOf(s); u != nil {
+		return u.name
+	}
+	return s.Name()
+}
+
+// accepts reports whether child may go directly below u. Soldiers from
+// other packages have no rank and go anywhere.
+func (u *unit) accepts(child Soldier) error {
+	rank := rankOf(child)
+	if u.relaxed || rank == "" || rank == childRanks[rankOf(u.self)] {
+		return nil
+	}
+	return &InvalidChildError{Parent: u.self, Child: child}
+}
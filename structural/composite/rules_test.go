@@ -0,0 +1,113 @@
+package composite
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestWithCapacity(t *testing.T) {
+	squad := NewSquad("A", WithCapacity(3))
+	if err := squad.Add(NewEnlisted("Ortiz"), NewEnlisted("Kim")); err != nil {
+		t.Fatalf("Add() within capacity = %v", err)
+	}
+
+	// two more would make four: nobody is added
+	err := squad.Add(NewEnlisted("Lee"), NewEnlisted("Park"))
+	want := "composite: adding 2 to A, which has 2 of 3: composite: unit is full"
+	if !errors.Is(err, ErrCapacityExceeded) || err.Error() != want {
+		t.Fatalf("Add() over capacity = %v, want %q", err, want)
+	}
+	if got := names(squad.Children()); len(got) != 2 {
+		t.Fatalf("Children() = %v after a rejected Add, want Ortiz and Kim", got)
+	}
+
+	if err := squad.Add(NewEnlisted("Lee")); err != nil {
+		t.Fatalf("Add() up to capacity = %v", err)
+	}
+	if err := squad.Add(NewEnlisted("Park")); !errors.Is(err, ErrCapacityExceeded) {
+		t.Fatalf("Add() to a full squad = %v, want %v", err, ErrCapacityExceeded)
+	}
+
+	// moving a soldier within a full squad takes no extra room
+	if err := squad.Add(squad.Children()[0]); err != nil {
+		t.Fatalf("re-adding a member of a full squad = %v", err)
+	}
+	if got := names(squad.Children()); got[2] != "Ortiz" {
+		t.Fatalf("Children() = %v, want Ortiz moved to the end", got)
+	}
+
+	// room made by removing a member can be used again
+	squad.RemoveByName("Kim")
+	if err := squad.Add(NewEnlisted("Park")); err != nil {
+		t.Fatalf("Add() after a removal = %v", err)
+	}
+}
+
+func TestWithCapacityUnlimited(t *testing.T) {
+	for _, max := range []int{0, -1} {
+		platoon := NewPlatoon("1st", WithCapacity(max))
+		for range 20 {
+			if err := platoon.Add(NewSquad("A")); err != nil {
+				t.Fatalf("WithCapacity(%d): Add() = %v, want no limit", max, err)
+			}
+		}
+	}
+}
+
+func TestWithUniqueNames(t *testing.T) {
+	division := NewDivision("1st", WithUniqueNames())
+	alpha := NewBrigade("Alpha")
+	if err := division.Add(alpha, NewBrigade("Bravo")); err != nil {
+		t.Fatalf("Add() of distinct names = %v", err)
+	}
+
+	err := division.Add(NewBrigade("Charlie"), NewBrigade("Alpha"))
+	want := "composite: adding Alpha to 1st: composite: name already used in the unit"
+	if !errors.Is(err, ErrDuplicateName) || err.Error() != want {
+		t.Fatalf("Add() of a second Alpha = %v, want %q", err, want)
+	}
+	if err := division.Add(NewBrigade("Delta"), NewBrigade("Delta")); !errors.Is(err, ErrDuplicateName) {
+		t.Fatalf("Add() of Delta twice = %v, want %v", err, ErrDuplicateName)
+	}
+	if got := names(division.Children()); len(got) != 2 {
+		t.Fatalf("Children() = %v after the rejected Adds, want Alpha and Bravo", got)
+	}
+
+	// re-adding a child moves it and clashes with nobody
+	if err := division.Add(alpha); err != nil {
+		t.Fatalf("re-adding Alpha = %v", err)
+	}
+	// a removed name is free again
+	division.Remove(alpha)
+	if err := division.Add(NewBrigade("Alpha")); err != nil {
+		t.Fatalf("Add() after removing Alpha = %v", err)
+	}
+}
+
+func TestUniqueNamesArePerUnit(t *testing.T) {
+	first, second := NewDivision("1st", WithUniqueNames()), NewDivision("2nd", WithUniqueNames())
+	if err := first.Add(NewBrigade("Alpha")); err != nil {
+		t.Fatal(err)
+	}
+	if err := second.Add(NewBrigade("Alpha")); err != nil {
+		t.Fatalf("Alpha under a second division = %v, want nil", err)
+	}
+	// the rule isn't inherited by the children
+	alpha := first.Find("Alpha")
+	if err := alpha.Add(NewPlatoon("1"), NewPlatoon("1")); err != nil {
+		t.Fatalf("Add() below a strict unit = %v, want nil", err)
+	}
+	if !first.Clone().(*Division).unique {
+		t.Fatal("Clone() dropped WithUniqueNames")
+	}
+}
+
+func TestDuplicateNamesAllowedByDefault(t *testing.T) {
+	squad := NewSquad("A")
+	if err := squad.Add(NewEnlisted("Kim"), NewEnlisted("Kim")); err != nil {
+		t.Fatalf("Add() of two Kims = %v, want nil", err)
+	}
+	if err := squad.Add(NewEnlisted("Kim")); err != nil || len(squad.Children()) != 3 {
+		t.Fatalf("Add() of a third Kim = %v with %d children", err, len(squad.Children()))
+	}
+}
@@ -0,0 +1,49 @@
+package composite
+
+import (
+	"slices"
+	"sort"
+)
+
+// SortChildren reorders the unit's direct children by less, keeping
+// children that compare equal in the order they were in.
+func (u *unit) SortChildren(less func(a, b Soldier) bool) {
+	for {
+		structure.RLock()
+		children, changes := slices.Clone(u.children), u.changes
+		structure.RUnlock()
+		// less may look at the tree, so it runs unlocked; if the children
+		// changed in the meantime the sort starts over
+		sort.SliceStable(children, func(i, j int) bool {
+			return less(children[i], children[j])
+		})
+		structure.Lock()
+		if u.changes == changes {
+			u.children = children
+			u.changes++
+			structure.Unlock()
+			return
+		}
+		structure.Unlock()
+	}
+}
+
+// SortByName orders the unit's direct children by name.
+func (u *unit) SortByName() {
+	u.SortChildren(byName)
+}
+
+// SortTree is SortChildren for this unit and every unit below it.
+// Soldiers from other packages keep their own children as they are.
+func (u *unit) SortTree(less func(a, b Soldier) bool) {
+	u.SortChildren(less)
+	for _, child := range u.snapshot() {
+		if c := baseOf(child); c != nil {
+			c.SortTree(less)
+		}
+	}
+}
+
+func byName(a, b Soldier) bool {
+	return a.Name() < b.Name()
+}
@@ -0,0 +1,73 @@
+package composite
+
+import (
+	"slices"
+	"testing"
+)
+
+// shuffled is 1st: Bravo: 2: D(Vo) C(Xu Lee), Alpha: 1: B(Park) A(Kim Ortiz Adams).
+func shuffled() *Division {
+	a, b, c, d := NewSquad("A"), NewSquad("B"), NewSquad("C"), NewSquad("D")
+	a.Add(NewEnlisted("Kim"), NewEnlisted("Ortiz"), NewEnlisted("Adams"))
+	b.Add(NewEnlisted("Park"))
+	c.Add(NewEnlisted("Xu"), NewEnlisted("Lee"))
+	d.Add(NewEnlisted("Vo"))
+	first, second := NewPlatoon("1"), NewPlatoon("2")
+	first.Add(b, a)
+	second.Add(d, c)
+	alpha, bravo := NewBrigade("Alpha"), NewBrigade("Bravo")
+	alpha.Add(first)
+	bravo.Add(second)
+	division := NewDivision("1st")
+	division.Add(bravo, alpha)
+	return division
+}
+
+func walkOrder(root Soldier) []string {
+	visited := make([]string, 0)
+	walk(root, 0, func(s Soldier, depth int) error {
+		visited = append(visited, s.Name())
+		return nil
+	})
+	return visited
+}
+
+func TestSortTreeByName(t *testing.T) {
+	division := shuffled()
+	division.SortTree(byName)
+	want := []string{"1st", "Alpha", "1", "A", "Adams", "Kim", "Ortiz", "B", "Park",
+		"Bravo", "2", "C", "Lee", "Xu", "D", "Vo"}
+	if got := walkOrder(division); !slices.Equal(got, want) {
+		t.Fatalf("walk after SortTree = %v, want %v", got, want)
+	}
+}
+
+func TestSortByNameOnlyDirectChildren(t *testing.T) {
+	division := shuffled()
+	division.SortByName()
+	if got := names(division.Children()); !slices.Equal(got, []string{"Alpha", "Bravo"}) {
+		t.Fatalf("Children() = %v, want Alpha, Bravo", got)
+	}
+	platoon := division.Find("1").(*Platoon)
+	if got := names(platoon.Children()); !slices.Equal(got, []string{"B", "A"}) {
+		t.Fatalf("grandchildren were sorted too: %v", got)
+	}
+}
+
+func TestSortChildrenIsStable(t *testing.T) {
+	squad := NewSquad("A")
+	squad.Add(NewEnlisted("Kim"), NewEnlisted("Lee"), NewEnlisted("Ortiz"), NewEnlisted("Li"))
+	// by the first letter only, so Lee and Li compare equal
+	squad.SortChildren(func(a, b Soldier) bool { return a.Name()[0] < b.Name()[0] })
+	if got := names(squad.Children()); !slices.Equal(got, []string{"Kim", "Lee", "Li", "Ortiz"}) {
+		t.Fatalf("Children() = %v, want Lee still before Li", got)
+	}
+}
+
+func TestSortChildrenByHeadcount(t *testing.T) {
+	platoon := shuffled().Find("1").(*Platoon)
+	platoon.SortChildren(func(a, b Soldier) bool { return a.Count() > b.Count() })
+	if got := names(platoon.Children()); !slices.Equal(got, []string{"A", "B"}) {
+		t.Fatalf("Children() = %v, want the biggest squad first", got)
+	}
+}
@@ -0,0 +1,22 @@
+package composite
+
+import "sync"
+
+// structure guards the shape of every tree: children, parent links and
+// change counts. One lock covers all trees because soldiers move from one
+// tree to another and Add checks several units at once, so there is no lock
+// order to get wrong. It is only held while the links are read or changed,
+// never while a soldier is briefed, something is printed, or a hook or
+// publisher is called, so those can change the tree themselves. The only
+// calls made with it held are Name on soldiers from other packages, for
+// WithUniqueNames.
+var structure sync.RWMutex
+
+// snapshot is the unit's children as they are now. Changes never write
+// into the part of the slice that was handed out, so it can be ranged over
+// while the tree changes.
+func (u *unit) snapshot() []Soldier {
+	structure.RLock()
+	defer structure.RUnlock()
+	return u.children
+}
@@ -0,0 +1,83 @@
+package composite
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/Antonious-Stewart/15-Most-Common-Design-Patterns/internal/output"
+)
+
+// Run with -race: writers change the tree while readers go over it.
+func TestConcurrentChangesAndBriefings(t *testing.T) {
+	const rounds = 100
+	division := NewDivision("1st", WithSink(output.NewRecorder()))
+	alpha := NewBrigade("Alpha", OnAdd(func(parent, child Soldier) {
+		// hooks run unlocked and can look at the tree
+		parent.(*Brigade).Children()
+	}))
+	bravo := NewBrigade("Bravo")
+	first := NewPlatoon("1st Platoon")
+	alpha.Add(first)
+	division.Add(alpha, bravo)
+
+	var wg sync.WaitGroup
+	run := func(fn func(i int)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range rounds {
+				fn(i)
+			}
+		}()
+	}
+
+	// writers
+	run(func(i int) {
+		squad := NewSquad(fmt.Sprint("S", i))
+		squad.Add(NewEnlisted("Kim"), NewEnlisted("Lee"))
+		first.Add(squad)
+		if i%2 == 0 {
+			first.Remove(squad)
+		}
+	})
+	run(func(i int) {
+		// moves in both directions at once
+		Move(first, alpha, bravo)
+	})
+	run(func(i int) {
+		Move(first, bravo, alpha)
+	})
+	run(func(i int) {
+		p := NewPlatoon(fmt.Sprint("P", i))
+		bravo.Add(p)
+		division.RemoveRecursive(p)
+	})
+
+	// readers
+	run(func(int) { division.Brief("Hold") })
+	run(func(int) { division.BriefParallel("Hold", 2) })
+	run(func(int) { division.BriefBreadthFirst("Hold") })
+	run(func(int) {
+		division.Count()
+		division.CountUnits()
+		division.Find("Lee")
+		division.Render()
+	})
+	run(func(int) {
+		for range division.Leaves() {
+		}
+		json.Marshal(division)
+		division.Clone()
+	})
+	run(func(int) { first.SortByName() })
+	wg.Wait()
+
+	if got := division.Count(); got != rounds {
+		t.Fatalf("Count() = %d after the writers finished, want %d", got, rounds)
+	}
+	if p := first.Parent(); p != Soldier(alpha) && p != Soldier(bravo) {
+		t.Fatalf("1st Platoon ended up under %v", p)
+	}
+}
@@ -0,0 +1,60 @@
+package composite
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+var ErrNoTemplate = errors.New("composite: no orders template")
+
+// BriefTemplate is Brief with orders addressed to each soldier: tmpl is run
+// with the soldier as its data, so "{{.Name}}, move to sector 7." reads
+// "Alpha, move to sector 7." for the Alpha brigade. Units print their own
+// orders after announcing themselves, and enlisted soldiers are briefed
+// with theirs. A soldier the template fails for, such as one without a
+// method it calls, hears nothing and is reported with the template's error,
+// but the soldiers below it are still briefed.
+func (u *unit) BriefTemplate(tmpl *template.Template) error {
+	if tmpl == nil {
+		return ErrNoTemplate
+	}
+	return briefTemplate(u.self, tmpl)
+}
+
+func briefTemplate(s Soldier, tmpl *template.Template) error {
+	var orders strings.Builder
+	err := tmpl.Execute(&orders, s)
+	if err != nil {
+		err = fmt.Errorf("composite: writing orders: %w", err)
+	}
+	c, ok := s.(container)
+	if !ok {
+		if err != nil {
+			return err
+		}
+		return s.Brief(orders.String())
+	}
+
+	u := baseOf(c)
+	u.sink().Println(c.briefing())
+	failed := make(BriefErrors, 0)
+	if err != nil {
+		failed = append(failed, &BriefError{Path: []string{u.name}, Err: err})
+	} else {
+		u.sink().Println(orders.String())
+	}
+	children := u.snapshot()
+	errs := make([]error, len(children))
+	for i, child := range children {
+		errs[i] = briefTemplate(child, tmpl)
+	}
+	if below, ok := u.collect(children, errs).(BriefErrors); ok {
+		failed = append(failed, below...)
+	}
+	if len(failed) == 0 {
+		return nil
+	}
+	return failed
+}
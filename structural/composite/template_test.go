@@ -0,0 +1,78 @@
+package composite
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"text/template"
+
+	"github.com/Antonious-Stewart/15-Most-Common-Design-Patterns/internal/output"
+)
+
+func TestBriefTemplate(t *testing.T) {
+	log := output.NewRecorder()
+	platoon, _ := twoLevels(log)
+	tmpl := template.Must(template.New("orders").Parse("{{.Name}}, move to sector 7."))
+	if err := platoon.BriefTemplate(tmpl); err != nil {
+		t.Fatalf("BriefTemplate() = %v", err)
+	}
+	want := []string{
+		"1: Briefing 2 Squads", "1, move to sector 7.",
+		"A: Briefing 2 Enlistees", "A, move to sector 7.",
+		"Ortiz: Ortiz, move to sector 7.", "Kim: Kim, move to sector 7.",
+		"B: Briefing 1 Enlistees", "B, move to sector 7.",
+		"Lee: Lee, move to sector 7.",
+	}
+	if got := log.Lines(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("BriefTemplate() printed %q, want %q", got, want)
+	}
+}
+
+func TestBriefTemplateErrors(t *testing.T) {
+	log := output.NewRecorder()
+	platoon, _ := twoLevels(log)
+	// enlisted soldiers have no Children
+	tmpl := template.Must(template.New("orders").Parse("{{len .Children}} under {{.Name}}"))
+	err := platoon.BriefTemplate(tmpl)
+
+	var failed BriefErrors
+	if !errors.As(err, &failed) || len(failed) != 3 {
+		t.Fatalf("BriefTemplate() = %v, want the three enlisted failing", err)
+	}
+	if got := failed[2].Path; !reflect.DeepEqual(got, []string{"1", "B", "Lee"}) {
+		t.Fatalf("Path = %q", got)
+	}
+	var exec template.ExecError
+	if !errors.As(err, &exec) {
+		t.Fatalf("BriefTemplate() = %v, want the template's error", err)
+	}
+	want := []string{
+		"1: Briefing 2 Squads", "2 under 1",
+		"A: Briefing 2 Enlistees", "2 under A",
+		"B: Briefing 1 Enlistees", "1 under B",
+	}
+	if got := log.Lines(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("BriefTemplate() printed %q, want only the units", got)
+	}
+
+	// a unit the template fails for still passes it down
+	log = output.NewRecorder()
+	platoon, _ = twoLevels(log)
+	tmpl = template.Must(template.New("orders").Parse("{{if eq .Count 2}}{{.Missing}}{{end}}{{.Name}}"))
+	err = platoon.BriefTemplate(tmpl)
+	if !errors.As(err, &failed) || len(failed) != 1 || !reflect.DeepEqual(failed[0].Path, []string{"1", "A"}) {
+		t.Fatalf("BriefTemplate() = %v, want only squad A failing", err)
+	}
+	want = []string{
+		"1: Briefing 2 Squads", "1",
+		"A: Briefing 2 Enlistees", "Ortiz: Ortiz", "Kim: Kim",
+		"B: Briefing 1 Enlistees", "B", "Lee: Lee",
+	}
+	if got := log.Lines(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("BriefTemplate() printed %q, want %q", got, want)
+	}
+
+	if err := platoon.BriefTemplate(nil); !errors.Is(err, ErrNoTemplate) {
+		t.Fatalf("BriefTemplate(nil) = %v, want %v", err, ErrNoTemplate)
+	}
+}
@@ -0,0 +1,96 @@
+package composite
+
+import "errors"
+
+// SkipSubtree can be returned from a container's visit, or from a Walk
+// callback, to leave everything below it unvisited. Neither Accept nor Walk
+// passes it on as an error.
+var SkipSubtree = errors.New("composite: skip this subtree")
+
+// Visitor is an operation over a soldier tree, one method per unit type,
+// so new operations can be written without touching the units; see Accept.
+type Visitor interface {
+	VisitDivision(d *Division) error
+	VisitBrigade(b *Brigade) error
+	VisitPlatoon(p *Platoon) error
+	VisitSquad(s *Squad) error
+	VisitEnlisted(e *Enlisted) error
+}
+
+// Leaver is implemented by visitors that need to know when the subtree of
+// a container is finished, for instance to keep track of the current path.
+type Leaver interface {
+	Leave(s Soldier)
+}
+
+// DefaultVisitor is implemented by visitors that want to hear about
+// soldiers from outside this package, which have no Accept method and no
+// visiting method of their own. Other visitors pass over them.
+type DefaultVisitor interface {
+	VisitDefault(element any) error
+}
+
+// acceptor is satisfied by every unit in this package.
+type acceptor interface {
+	Accept(v Visitor) error
+}
+
+// Accept visits d and then everything below it, depth first in the order
+// the children were added. The first error a visit returns stops the whole
+// traversal and is returned; containers being left on the way out are not
+// told. A visit returning SkipSubtree only prunes its own subtree.
+func (d *Division) Accept(v Visitor) error {
+	return d.accept(v, v.VisitDivision(d))
+}
+
+func (b *Brigade) Accept(v Visitor) error {
+	return b.accept(v, v.VisitBrigade(b))
+}
+
+func (p *Platoon) Accept(v Visitor) error {
+	return p.accept(v, v.VisitPlatoon(p))
+}
+
+func (s *Squad) Accept(v Visitor) error {
+	return s.accept(v, v.VisitSquad(s))
+}
+
+// Accept visits e. Enlisted soldiers have no subtree, so Leave isn't
+// called and SkipSubtree has nothing to skip.
+func (e *Enlisted) Accept(v Visitor) error {
+	if err := v.VisitEnlisted(e); err != nil && err != SkipSubtree {
+		return err
+	}
+	return nil
+}
+
+// accept carries on after the container's own visit returned visited. A
+// pruned container is still left, so visitors tracking a path stay balanced.
+func (u *unit) accept(v Visitor, visited error) error {
+	if visited != nil && visited != SkipSubtree {
+		return visited
+	}
+	if visited == nil {
+		for _, child := range u.childList() {
+			if err := acceptChild(child, v); err != nil {
+				return err
+			}
+		}
+	}
+	if l, ok := v.(Leaver); ok {
+		l.Leave(u.self)
+	}
+	return nil
+}
+
+func acceptChild(child Soldier, v Visitor) error {
+	if a, ok := child.(acceptor); ok {
+		return a.Accept(v)
+	}
+	if d, ok := v.(DefaultVisitor); ok {
+		if err := d.VisitDefault(child); err != nil && err != SkipSubtree {
+			return err
+		}
+	}
+	return nil
+}
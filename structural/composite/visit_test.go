@@ -0,0 +1,164 @@
+package composite
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+// trace records every visit and leave as "type:name" or "leave:name". A
+// visit of the unit named in fail returns errVisit, one named in skip
+// returns SkipSubtree.
+type trace struct {
+	got        []string
+	fail, skip string
+}
+
+var errVisit = errors.New("visit failed")
+
+func (t *trace) visit(kind, name string) error {
+	t.got = append(t.got, kind+":"+name)
+	switch name {
+	case t.fail:
+		return errVisit
+	case t.skip:
+		return SkipSubtree
+	}
+	return nil
+}
+
+func (t *trace) VisitDivision(d *Division) error { return t.visit("division", d.Name()) }
+func (t *trace) VisitBrigade(b *Brigade) error   { return t.visit("brigade", b.Name()) }
+func (t *trace) VisitPlatoon(p *Platoon) error   { return t.visit("platoon", p.Name()) }
+func (t *trace) VisitSquad(s *Squad) error       { return t.visit("squad", s.Name()) }
+func (t *trace) VisitEnlisted(e *Enlisted) error { return t.visit("enlisted", e.Name()) }
+
+func (t *trace) Leave(s Soldier) {
+	t.got = append(t.got, "leave:"+s.Name())
+}
+
+type stranger struct{}
+
+func (stranger) Brief(string) error   { return nil }
+func (stranger) Add(...Soldier) error { return nil }
+func (stranger) Count() int           { return 1 }
+func (stranger) Name() string         { return "stranger" }
+func (stranger) Parent() Soldier      { return nil }
+func (stranger) Clone() Soldier       { return stranger{} }
+func (stranger) Rank() Rank           { return Unranked }
+func (stranger) Report() Readiness    { return Readiness{Ready: 1, Total: 1} }
+
+func visitTree() *Division {
+	division := NewDivision("1st")
+	brigade := NewBrigade("A")
+	platoon := NewPlatoon("P")
+	squad := NewSquad("S")
+	division.Add(brigade, NewBrigade("B"))
+	brigade.Add(platoon)
+	platoon.Add(squad)
+	squad.Add(NewEnlisted("Ortiz"), stranger{}, NewEnlisted("Kim"))
+	return division
+}
+
+func TestAccept(t *testing.T) {
+	tests := []struct {
+		name    string
+		visitor trace
+		want    []string
+		wantErr error
+	}{
+		{
+			name: "everything",
+			want: []string{
+				"division:1st",
+				"brigade:A", "platoon:P", "squad:S",
+				"enlisted:Ortiz", "enlisted:Kim",
+				"leave:S", "leave:P", "leave:A",
+				"brigade:B", "leave:B",
+				"leave:1st",
+			},
+		},
+		{
+			name:    "fails at a leaf",
+			visitor: trace{fail: "Ortiz"},
+			want:    []string{"division:1st", "brigade:A", "platoon:P", "squad:S", "enlisted:Ortiz"},
+			wantErr: errVisit,
+		},
+		{
+			name:    "fails at a container",
+			visitor: trace{fail: "B"},
+			want: []string{
+				"division:1st",
+				"brigade:A", "platoon:P", "squad:S",
+				"enlisted:Ortiz", "enlisted:Kim",
+				"leave:S", "leave:P", "leave:A",
+				"brigade:B",
+			},
+			wantErr: errVisit,
+		},
+		{
+			name:    "prunes a subtree",
+			visitor: trace{skip: "P"},
+			want: []string{
+				"division:1st",
+				"brigade:A", "platoon:P", "leave:P", "leave:A",
+				"brigade:B", "leave:B",
+				"leave:1st",
+			},
+		},
+		{
+			name:    "skip at a leaf does nothing",
+			visitor: trace{skip: "Ortiz"},
+			want: []string{
+				"division:1st",
+				"brigade:A", "platoon:P", "squad:S",
+				"enlisted:Ortiz", "enlisted:Kim",
+				"leave:S", "leave:P", "leave:A",
+				"brigade:B", "leave:B",
+				"leave:1st",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := tt.visitor
+			err := visitTree().Accept(&v)
+			if err != tt.wantErr {
+				t.Fatalf("Accept() = %v, want %v", err, tt.wantErr)
+			}
+			if !slices.Equal(v.got, tt.want) {
+				t.Fatalf("visits = %v, want %v", v.got, tt.want)
+			}
+		})
+	}
+}
+
+// defaulting is a trace that also hears about foreign soldiers.
+type defaulting struct {
+	trace
+	err error
+}
+
+func (d *defaulting) VisitDefault(element any) error {
+	d.got = append(d.got, "default")
+	return d.err
+}
+
+func TestAcceptRoutesForeignSoldiers(t *testing.T) {
+	v := &defaulting{}
+	if err := visitTree().Accept(v); err != nil {
+		t.Fatal(err)
+	}
+	i := slices.Index(v.got, "enlisted:Ortiz")
+	if i < 0 || v.got[i+1] != "default" || v.got[i+2] != "enlisted:Kim" {
+		t.Fatalf("visits = %v, want the stranger between Ortiz and Kim", v.got)
+	}
+
+	v = &defaulting{err: errVisit}
+	if err := visitTree().Accept(v); err != errVisit {
+		t.Fatalf("Accept() = %v, want the default visit's error", err)
+	}
+	if last := v.got[len(v.got)-1]; last != "default" {
+		t.Fatalf("visits = %v, want to stop at the stranger", v.got)
+	}
+}
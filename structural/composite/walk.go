@@ -0,0 +1,30 @@
+package composite
+
+// Walk calls fn for the unit and then for everything below it, depth first
+// in the order the children were added. depth is 0 for the unit and one
+// more for each level below it. Returning SkipSubtree from fn leaves the
+// children of that soldier out; any other error stops the walk and is
+// returned. Soldiers from other packages are walked through if they have
+// Children.
+func (u *unit) Walk(fn func(s Soldier, depth int) error) error {
+	return walk(u.self, 0, fn)
+}
+
+func walk(s Soldier, depth int, fn func(s Soldier, depth int) error) error {
+	if err := fn(s, depth); err != nil {
+		if err == SkipSubtree {
+			return nil
+		}
+		return err
+	}
+	c, ok := s.(interface{ Children() []Soldier })
+	if !ok {
+		return nil
+	}
+	for _, child := range c.Children() {
+		if err := walk(child, depth+1, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package composite
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestWalkOrderAndDepth(t *testing.T) {
+	division := findTree()
+	got := make([]string, 0)
+	err := division.Walk(func(s Soldier, depth int) error {
+		got = append(got, fmt.Sprintf("%d %s", depth, s.Name()))
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"0 1st",
+		"1 Alpha", "2 1st", "3 A", "4 Ortiz", "4 Kim", "3 B", "4 Kim",
+		"1 Bravo", "2 3rd Platoon", "3 1st",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Walk visited %q, want %q", got, want)
+	}
+}
+
+func TestWalkSkipSubtree(t *testing.T) {
+	division := findTree()
+	got := make([]string, 0)
+	division.Walk(func(s Soldier, depth int) error {
+		got = append(got, s.Name())
+		if _, ok := s.(*Squad); ok && s.Name() == "A" {
+			return SkipSubtree
+		}
+		if _, ok := s.(*Enlisted); ok {
+			// nothing below to skip
+			return SkipSubtree
+		}
+		return nil
+	})
+	want := []string{"1st", "Alpha", "1st", "A", "B", "Kim", "Bravo", "3rd Platoon", "1st"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Walk visited %q, want %q", got, want)
+	}
+
+	calls := 0
+	err := division.Walk(func(Soldier, int) error {
+		calls++
+		return SkipSubtree
+	})
+	if err != nil || calls != 1 {
+		t.Fatalf("skipping the root: Walk() = %v after %d calls, want nil after 1", err, calls)
+	}
+}
+
+func TestWalkStopsOnError(t *testing.T) {
+	division := findTree()
+	halt := errors.New("halt")
+	got := make([]string, 0)
+	err := division.Walk(func(s Soldier, depth int) error {
+		got = append(got, s.Name())
+		if s.Name() == "Kim" {
+			return fmt.Errorf("at %s: %w", s.Name(), halt)
+		}
+		return nil
+	})
+	if !errors.Is(err, halt) {
+		t.Fatalf("Walk() = %v, want %v", err, halt)
+	}
+	if want := []string{"1st", "Alpha", "1st", "A", "Ortiz", "Kim"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("Walk visited %q before stopping, want %q", got, want)
+	}
+}
+
+func TestWalkForeignSoldiers(t *testing.T) {
+	squad := NewSquad("A")
+	squad.Add(&outpost{name: "Outpost", children: []Soldier{NewEnlisted("Lee")}})
+	got := make([]string, 0)
+	squad.Walk(func(s Soldier, depth int) error {
+		got = append(got, fmt.Sprintf("%d %s", depth, s.Name()))
+		return nil
+	})
+	if want := []string{"0 A", "1 Outpost", "2 Lee"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("Walk visited %q, want %q", got, want)
+	}
+}